@@ -0,0 +1,86 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/security"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+const (
+	// Name of the API key issued when creating a config
+	DEFAULT_API_KEY_NAME = "default"
+
+	DEFAULT_ROTATION_GRACE_PERIOD = 24 * time.Hour
+	MAX_ROTATION_GRACE_PERIOD     = 30 * 24 * time.Hour
+)
+
+// ApiKey describes an API key without revealing it, only its prefix.
+type ApiKey struct {
+	Id         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Access     string     `json:"access"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	Active     bool       `json:"active"`
+}
+
+func newApiKey(auth *security.Authorization, now time.Time) ApiKey {
+	return ApiKey{
+		Id:         auth.Id().Value(),
+		Name:       auth.Name(),
+		Prefix:     auth.Prefix(),
+		Access:     string(auth.Access()),
+		CreatedAt:  auth.CreatedAt(),
+		LastUsedAt: auth.LastUsedAt(),
+		ExpiresAt:  auth.ExpiresAt(),
+		RevokedAt:  auth.RevokedAt(),
+		Active:     auth.IsActive(now),
+	}
+}
+
+// findConfigAuthorization returns an authorization of an existing config
+// whose keys can be managed by the user.
+func findConfigAuthorization(
+	ctx context.Context,
+	configRepo config.ConfigRepository,
+	authorizationRepo security.AuthorizationRepository,
+	rawConfigId string,
+	rawKeyId string,
+) (*security.Authorization, error) {
+	configId, err := models.BuildId(rawConfigId)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := configRepo.FindById(ctx, configId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requirePermission(ctx, user.KEY_MANAGE_PERMISSION, configResource(c)); err != nil {
+		return nil, err
+	}
+
+	keyId, err := models.BuildId(rawKeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := authorizationRepo.FindById(ctx, keyId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !auth.ResourceId().Equals(configId) {
+		return nil, security.ErrNotFound
+	}
+
+	return auth, nil
+}
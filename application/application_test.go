@@ -0,0 +1,151 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/infrastructure"
+	"github.com/aboglioli/configd/pkg/models"
+	"github.com/aboglioli/configd/pkg/utils"
+)
+
+// deps are in-memory repositories shared by the use cases under test.
+type deps struct {
+	eventBus          *infrastructure.InMemEventBus
+	schemaRepo        *infrastructure.InMemSchemaRepository
+	versionRepo       *infrastructure.InMemSchemaVersionRepository
+	configRepo        *infrastructure.InMemConfigRepository
+	revisionRepo      *infrastructure.InMemRevisionRepository
+	authorizationRepo *infrastructure.InMemAuthorizationRepository
+	userRepo          *infrastructure.InMemUserRepository
+	assignmentRepo    *infrastructure.InMemRoleAssignmentRepository
+}
+
+func newDeps() *deps {
+	return &deps{
+		eventBus:          infrastructure.NewInMemEventBus(),
+		schemaRepo:        infrastructure.NewInMemSchemaRepository(),
+		versionRepo:       infrastructure.NewInMemSchemaVersionRepository(),
+		configRepo:        infrastructure.NewInMemConfigRepository(),
+		revisionRepo:      infrastructure.NewInMemRevisionRepository(),
+		authorizationRepo: infrastructure.NewInMemAuthorizationRepository(),
+		userRepo:          infrastructure.NewInMemUserRepository(),
+		assignmentRepo:    infrastructure.NewInMemRoleAssignmentRepository(),
+	}
+}
+
+// systemContext is allowed to do anything.
+func systemContext() context.Context {
+	return user.NewSystemContext(context.Background())
+}
+
+func (d *deps) createSchema(t *testing.T, id string) *CreateSchemaResponse {
+	t.Helper()
+
+	res, err := NewCreateSchema(d.schemaRepo, d.versionRepo, d.eventBus).Exec(
+		systemContext(),
+		&CreateSchemaCommand{
+			Id:   &id,
+			Name: id,
+			Schema: map[string]interface{}{
+				"port": map[string]interface{}{
+					"$schema": map[string]interface{}{
+						"type": "integer",
+					},
+				},
+			},
+		},
+	)
+	utils.Ok(err)
+
+	return res
+}
+
+func (d *deps) createConfig(t *testing.T, schemaId, id string, port int) *CreateConfigResponse {
+	t.Helper()
+
+	res, err := NewCreateConfig(
+		d.schemaRepo,
+		d.versionRepo,
+		d.configRepo,
+		d.revisionRepo,
+		d.authorizationRepo,
+		d.eventBus,
+	).Exec(
+		systemContext(),
+		&CreateConfigCommand{
+			Id:       &id,
+			SchemaId: schemaId,
+			Name:     id,
+			Config:   config.ConfigData{"port": port},
+		},
+	)
+	utils.Ok(err)
+
+	return res
+}
+
+func (d *deps) updateConfig(t *testing.T, cmd *UpdateConfigCommand) *UpdateConfigResponse {
+	t.Helper()
+
+	res, err := NewUpdateConfig(
+		d.schemaRepo,
+		d.versionRepo,
+		d.configRepo,
+		d.revisionRepo,
+		d.eventBus,
+	).Exec(systemContext(), cmd)
+	utils.Ok(err)
+
+	return res
+}
+
+// userContext carries a user with the given grants.
+func userContext(t *testing.T, grants ...user.Grant) context.Context {
+	t.Helper()
+
+	username, err := user.NewUsername("team-lead")
+	utils.Ok(err)
+
+	password, err := user.NewPassword("password123")
+	utils.Ok(err)
+
+	u, err := user.NewUser(username, password, user.READ_ONLY_ACCESS)
+	utils.Ok(err)
+
+	return user.NewContext(context.Background(), u, grants)
+}
+
+// roleContext carries a user granted a builtin role within the scope.
+func roleContext(t *testing.T, rawRoleId, rawScope string) context.Context {
+	t.Helper()
+
+	roleId, err := models.BuildId(rawRoleId)
+	utils.Ok(err)
+
+	r, ok := user.BuiltinRole(roleId)
+	if !ok {
+		t.Fatalf("unknown role %s", rawRoleId)
+	}
+
+	grants := make([]user.Grant, 0, len(r.Permissions()))
+	for _, p := range r.Permissions() {
+		grants = append(grants, grant(t, p, rawScope))
+	}
+
+	return userContext(t, grants...)
+}
+
+func grant(t *testing.T, permission user.Permission, rawScope string) user.Grant {
+	t.Helper()
+
+	scope, err := user.NewScope(rawScope)
+	utils.Ok(err)
+
+	return user.Grant{
+		Permission: permission,
+		Scope:      scope,
+	}
+}
@@ -0,0 +1,91 @@
+package application
+
+import (
+	"context"
+
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+type AssignRoleCommand struct {
+	Username string `json:"username"`
+	RoleId   string `json:"role_id"`
+	// Global (*) when empty
+	Scope string `json:"scope"`
+}
+
+type AssignRoleResponse struct {
+	Role RoleAssignment `json:"role"`
+}
+
+// AssignRole grants a role to a user within a scope. Changes apply to the
+// next requests of the user.
+type AssignRole struct {
+	userRepo       user.UserRepository
+	roleRepo       user.RoleRepository
+	assignmentRepo user.RoleAssignmentRepository
+}
+
+func NewAssignRole(
+	userRepo user.UserRepository,
+	roleRepo user.RoleRepository,
+	assignmentRepo user.RoleAssignmentRepository,
+) *AssignRole {
+	return &AssignRole{
+		userRepo:       userRepo,
+		roleRepo:       roleRepo,
+		assignmentRepo: assignmentRepo,
+	}
+}
+
+func (uc *AssignRole) Exec(
+	ctx context.Context,
+	cmd *AssignRoleCommand,
+) (*AssignRoleResponse, error) {
+	if err := requirePermission(ctx, user.USER_MANAGE_PERMISSION, user.Resource{}); err != nil {
+		return nil, err
+	}
+
+	username, err := user.NewUsername(cmd.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := uc.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	roleId, err := models.BuildId(cmd.RoleId)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := findRole(ctx, uc.roleRepo, roleId)
+	if err != nil {
+		return nil, err
+	}
+
+	rawScope := cmd.Scope
+	if rawScope == "" {
+		rawScope = user.GLOBAL_SCOPE_VALUE
+	}
+
+	scope, err := user.NewScope(rawScope)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment, err := user.NewRoleAssignment(u.Username(), r.Id(), scope)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.assignmentRepo.Save(ctx, assignment); err != nil {
+		return nil, err
+	}
+
+	return &AssignRoleResponse{
+		Role: newRoleAssignment(assignment),
+	}, nil
+}
@@ -0,0 +1,116 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+type AuthenticateUserCommand struct {
+	Token string `json:"token"`
+}
+
+// AuthenticateUserResponse is returned as is to be carried in the request
+// context.
+type AuthenticateUserResponse struct {
+	User   *user.User
+	Grants user.Grants
+}
+
+type AuthenticateUser struct {
+	userRepo       user.UserRepository
+	sessionRepo    user.SessionRepository
+	roleRepo       user.RoleRepository
+	assignmentRepo user.RoleAssignmentRepository
+	tokenIssuer    *user.TokenIssuer
+}
+
+func NewAuthenticateUser(
+	userRepo user.UserRepository,
+	sessionRepo user.SessionRepository,
+	roleRepo user.RoleRepository,
+	assignmentRepo user.RoleAssignmentRepository,
+	tokenIssuer *user.TokenIssuer,
+) *AuthenticateUser {
+	return &AuthenticateUser{
+		userRepo:       userRepo,
+		sessionRepo:    sessionRepo,
+		roleRepo:       roleRepo,
+		assignmentRepo: assignmentRepo,
+		tokenIssuer:    tokenIssuer,
+	}
+}
+
+// Exec validates an access token and loads its user with the permissions
+// granted by their roles.
+func (uc *AuthenticateUser) Exec(
+	ctx context.Context,
+	cmd *AuthenticateUserCommand,
+) (*AuthenticateUserResponse, error) {
+	s, err := findTokenSession(ctx, uc.sessionRepo, uc.tokenIssuer, cmd.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tokens of sessions logged out are no longer valid
+	if err := s.Check(time.Now()); err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	// Users removed after logging in are no longer authenticated
+	u, err := uc.userRepo.FindByUsername(ctx, s.Username())
+	if err == user.ErrNotFound {
+		return nil, ErrUnauthorized
+	} else if err != nil {
+		return nil, err
+	}
+
+	grants, err := findGrants(ctx, uc.roleRepo, uc.assignmentRepo, u.Username())
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthenticateUserResponse{
+		User:   u,
+		Grants: grants,
+	}, nil
+}
+
+// findTokenSession verifies the access token and loads the session it was
+// issued for.
+func findTokenSession(
+	ctx context.Context,
+	sessionRepo user.SessionRepository,
+	tokenIssuer *user.TokenIssuer,
+	rawToken string,
+) (*user.Session, error) {
+	token, err := user.NewToken(rawToken)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	claims, err := tokenIssuer.Parse(token)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	sessionId, err := models.BuildId(claims.SessionId)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	s, err := sessionRepo.FindById(ctx, sessionId)
+	if err == user.ErrSessionNotFound {
+		return nil, ErrUnauthorized
+	} else if err != nil {
+		return nil, err
+	}
+
+	if s.Username().Value() != claims.Subject {
+		return nil, ErrUnauthorized
+	}
+
+	return s, nil
+}
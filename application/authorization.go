@@ -0,0 +1,133 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/security"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+// authorizeConfigRead checks that the request can read the config.
+// Authenticated users need config:read on it, other callers an API key
+// granting access to it.
+func authorizeConfigRead(
+	ctx context.Context,
+	configRepo config.ConfigRepository,
+	authorizationRepo security.AuthorizationRepository,
+	rawApiKey string,
+	configId models.Id,
+) error {
+	if _, ok := user.GrantsFromContext(ctx); ok {
+		// Missing configs are only reported to users allowed to read them
+		r := user.ConfigResource(configId.Value(), "")
+		if c, err := configRepo.FindById(ctx, configId); err == nil {
+			r = configResource(c)
+		} else if err != config.ErrNotFound {
+			return err
+		}
+
+		return requirePermission(ctx, user.CONFIG_READ_PERMISSION, r)
+	}
+
+	return authorizeApiKey(ctx, configRepo, authorizationRepo, rawApiKey, configId)
+}
+
+// authorizeApiKey checks that the raw API key grants access to the given
+// config. Keys of deleted configs are kept until purged but no longer work.
+func authorizeApiKey(
+	ctx context.Context,
+	configRepo config.ConfigRepository,
+	authorizationRepo security.AuthorizationRepository,
+	rawApiKey string,
+	resourceId models.Id,
+) error {
+	apiKey, err := security.NewApiKey(rawApiKey)
+	if err != nil {
+		return err
+	}
+
+	auth, err := findApiKeyAuthorization(ctx, authorizationRepo, apiKey)
+	if err != nil {
+		return err
+	}
+
+	if !auth.ResourceId().Equals(resourceId) {
+		return ErrUnauthorized
+	}
+
+	now := time.Now()
+	if err := auth.Check(now); err != nil {
+		return ErrUnauthorized
+	}
+
+	if _, err := configRepo.FindById(ctx, resourceId); err == config.ErrNotFound {
+		return ErrUnauthorized
+	} else if err != nil {
+		return err
+	}
+
+	// Only the last use is written, a revocation made meanwhile must stay
+	if auth.Use(now) {
+		return authorizationRepo.UpdateLastUsedAt(ctx, auth.Id(), now)
+	}
+
+	return nil
+}
+
+// findApiKeyAuthorization looks the authorization up by the id embedded in
+// the API key, falling back to the key hash for legacy keys.
+func findApiKeyAuthorization(
+	ctx context.Context,
+	authorizationRepo security.AuthorizationRepository,
+	apiKey security.ApiKey,
+) (*security.Authorization, error) {
+	id, ok := apiKey.Id()
+	if !ok {
+		hashedApiKey, err := apiKey.Hash()
+		if err != nil {
+			return nil, err
+		}
+
+		auth, err := authorizationRepo.FindByApiKey(ctx, hashedApiKey)
+		if err != nil {
+			return nil, ErrUnauthorized
+		}
+
+		return auth, nil
+	}
+
+	auth, err := authorizationRepo.FindById(ctx, id)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	if !auth.HashedApiKey().Validate(apiKey) {
+		return nil, ErrUnauthorized
+	}
+
+	return auth, nil
+}
+
+// requirePermission checks that the user or system carried by ctx is granted
+// the permission on the resource.
+func requirePermission(ctx context.Context, permission user.Permission, r user.Resource) error {
+	grants, ok := user.GrantsFromContext(ctx)
+	if !ok {
+		return ErrUnauthorized
+	}
+
+	if !grants.Allows(permission, r) {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// configResource is the resource of a config, covered by the scopes of its
+// schema too.
+func configResource(c *config.Config) user.Resource {
+	return user.ConfigResource(c.Base().Id().Value(), c.SchemaId().Value())
+}
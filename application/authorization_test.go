@@ -0,0 +1,46 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aboglioli/configd/pkg/models"
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiKeysOfDeletedConfigs(t *testing.T) {
+	d := newDeps()
+	d.createSchema(t, "service")
+	created := d.createConfig(t, "service", "development", 80)
+
+	ctx := context.Background()
+
+	getConfigVersion := NewGetConfigVersion(d.configRepo, d.revisionRepo, d.authorizationRepo)
+	cmd := &GetConfigVersionCommand{
+		Id:      "development",
+		Version: 1,
+		ApiKey:  created.ApiKey,
+	}
+
+	_, err := getConfigVersion.Exec(ctx, cmd)
+	assert.NoError(t, err)
+
+	_, err = NewDeleteConfig(d.configRepo, d.revisionRepo, d.eventBus).Exec(
+		systemContext(),
+		&DeleteConfigCommand{
+			Id: "development",
+		},
+	)
+	utils.Ok(err)
+
+	// Keys are kept until purged but no longer work
+	_, err = getConfigVersion.Exec(ctx, cmd)
+	assert.Equal(t, ErrUnauthorized, err)
+
+	id, err := models.BuildId("development")
+	utils.Ok(err)
+
+	err = authorizeApiKey(ctx, d.configRepo, d.authorizationRepo, created.ApiKey, id)
+	assert.Equal(t, ErrUnauthorized, err)
+}
@@ -0,0 +1,22 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+// checkExpectedVersion fails when the client expects a version other than the
+// current one, meaning it is working over stale data.
+func checkExpectedVersion(base models.ReadOnlyAggregateRoot, expected *uint) error {
+	if expected != nil && *expected != base.Version() {
+		return fmt.Errorf(
+			"%w: expected version %d but current version is %d",
+			models.ErrVersionConflict,
+			*expected,
+			base.Version(),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/security"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+type CreateApiKeyCommand struct {
+	ConfigId string `json:"config_id"`
+	Name     string `json:"name"`
+	// Never expires when empty
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type CreateApiKeyResponse struct {
+	// Only returned once
+	ApiKey string `json:"api_key"`
+	Key    ApiKey `json:"key"`
+}
+
+type CreateApiKey struct {
+	configRepo        config.ConfigRepository
+	authorizationRepo security.AuthorizationRepository
+}
+
+func NewCreateApiKey(
+	configRepo config.ConfigRepository,
+	authorizationRepo security.AuthorizationRepository,
+) *CreateApiKey {
+	return &CreateApiKey{
+		configRepo:        configRepo,
+		authorizationRepo: authorizationRepo,
+	}
+}
+
+func (uc *CreateApiKey) Exec(
+	ctx context.Context,
+	cmd *CreateApiKeyCommand,
+) (*CreateApiKeyResponse, error) {
+	configId, err := models.BuildId(cmd.ConfigId)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := uc.configRepo.FindById(ctx, configId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requirePermission(ctx, user.KEY_MANAGE_PERMISSION, configResource(c)); err != nil {
+		return nil, err
+	}
+
+	apiKey, err := security.GenerateApiKey()
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := security.NewAuthorization(
+		apiKey,
+		cmd.Name,
+		c.Base().Id(),
+		security.READ_ONLY_ACCESS,
+		cmd.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.authorizationRepo.Save(ctx, auth); err != nil {
+		return nil, err
+	}
+
+	return &CreateApiKeyResponse{
+		ApiKey: apiKey.Value(),
+		Key:    newApiKey(auth, time.Now()),
+	}, nil
+}
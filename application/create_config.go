@@ -7,41 +7,68 @@ import (
 	"github.com/aboglioli/configd/domain/config"
 	"github.com/aboglioli/configd/domain/schema"
 	"github.com/aboglioli/configd/domain/security"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/events"
 	"github.com/aboglioli/configd/pkg/models"
 )
 
 type CreateConfigCommand struct {
-	Id       *string           `json:"id"`
+	Id *string `json:"id"`
+	// Either schema_id or schema_id@version
 	SchemaId string            `json:"schema_id"`
 	Name     string            `json:"name"`
 	Config   config.ConfigData `json:"config"`
+	// Order of the keys of Config as written
+	KeyOrder codec.KeyOrder `json:"-"`
+	// Imports the config from a flattened document instead, restoring value
+	// types from the schema
+	Flat           *FlatCommand `json:"flat"`
+	Document       string       `json:"document"`
+	ValidationMode *string      `json:"validation_mode"`
+	Author         string       `json:"author"`
 }
 
 type CreateConfigResponse struct {
-	Id          string            `json:"id"`
-	SchemaId    string            `json:"schema_id"`
-	Name        string            `json:"name"`
-	Config      config.ConfigData `json:"config"`
-	ValidSchema bool              `json:"valid_schema"`
-	ConfigSum   string            `json:"config_sum"`
-	ApiKey      string            `json:"api_key"`
+	Id       string `json:"id"`
+	SchemaId string `json:"schema_id"`
+	// Schema version the config was validated against and whether the
+	// config is pinned to it or tracks the latest one
+	SchemaVersion uint                     `json:"schema_version"`
+	SchemaPinned  bool                     `json:"schema_pinned"`
+	Name          string                   `json:"name"`
+	Config        codec.Ordered            `json:"config"`
+	ValidSchema   bool                     `json:"valid_schema"`
+	Validation    *schema.ValidationResult `json:"validation"`
+	ConfigSum     string                   `json:"config_sum"`
+	Version       uint                     `json:"version"`
+	ApiKey        string                   `json:"api_key"`
 }
 
 type CreateConfig struct {
 	schemaRepo        schema.SchemaRepository
+	versionRepo       schema.VersionRepository
 	configRepo        config.ConfigRepository
+	revisionRepo      config.RevisionRepository
 	authorizationRepo security.AuthorizationRepository
+	eventPublisher    events.EventPublisher
 }
 
 func NewCreateConfig(
 	schemaRepo schema.SchemaRepository,
+	versionRepo schema.VersionRepository,
 	configRepo config.ConfigRepository,
+	revisionRepo config.RevisionRepository,
 	authorizationRepo security.AuthorizationRepository,
+	eventPublisher events.EventPublisher,
 ) *CreateConfig {
 	return &CreateConfig{
 		configRepo:        configRepo,
+		revisionRepo:      revisionRepo,
 		schemaRepo:        schemaRepo,
+		versionRepo:       versionRepo,
 		authorizationRepo: authorizationRepo,
+		eventPublisher:    eventPublisher,
 	}
 }
 
@@ -49,8 +76,8 @@ func (uc *CreateConfig) Exec(
 	ctx context.Context,
 	cmd *CreateConfigCommand,
 ) (*CreateConfigResponse, error) {
-	// Check schema existence
-	schemaId, err := models.BuildId(cmd.SchemaId)
+	// Check schema and schema version existence
+	schemaId, schemaVersion, err := config.ParseSchemaRef(cmd.SchemaId)
 	if err != nil {
 		return nil, err
 	}
@@ -60,6 +87,11 @@ func (uc *CreateConfig) Exec(
 		return nil, err
 	}
 
+	v, err := findSchemaVersion(ctx, uc.versionRepo, schemaId, schemaVersion)
+	if err != nil {
+		return nil, err
+	}
+
 	// Name
 	name, err := config.NewName(cmd.Name)
 	if err != nil {
@@ -77,26 +109,57 @@ func (uc *CreateConfig) Exec(
 		return nil, err
 	}
 
+	if err := requirePermission(
+		ctx,
+		user.CONFIG_WRITE_PERMISSION,
+		user.ConfigResource(id.Value(), schemaId.Value()),
+	); err != nil {
+		return nil, err
+	}
+
 	// Check unique id
 	if _, err := uc.configRepo.FindById(ctx, id); err != config.ErrNotFound {
 		return nil, fmt.Errorf("config with id %s already exists", id.Value())
 	}
 
+	if _, err := uc.configRepo.FindDeletedById(ctx, id); err != config.ErrNotFound {
+		return nil, fmt.Errorf("config with id %s is deleted, restore it or wait until it is purged", id.Value())
+	}
+
+	data := cmd.Config
+	if cmd.Flat != nil {
+		opts, err := cmd.Flat.options()
+		if err != nil {
+			return nil, err
+		}
+
+		data, err = config.Unflatten(cmd.Document, v.Props(), opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Create new config
-	c, err := config.NewConfig(id, schemaId, name, cmd.Config)
+	c, err := config.NewConfig(id, schemaId, schemaVersion, name, data, cmd.KeyOrder)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := uc.configRepo.Save(ctx, c); err != nil {
+	validation, err := validateConfig(s, v, c.Config(), cmd.ValidationMode)
+	if err != nil {
 		return nil, err
 	}
 
-	validSchema := true
-	if err := s.Validate(c.Config()); err != nil {
-		validSchema = false
+	if err := saveConfig(ctx, uc.configRepo, uc.revisionRepo, c, cmd.Author); err != nil {
+		return nil, err
+	}
+
+	if err := uc.eventPublisher.Publish(c.Base().Events()...); err != nil {
+		return nil, err
 	}
 
+	c.ClearEvents()
+
 	// Create API Key
 	apiKey, err := security.GenerateApiKey()
 	if err != nil {
@@ -105,20 +168,30 @@ func (uc *CreateConfig) Exec(
 
 	auth, err := security.NewAuthorization(
 		apiKey,
+		DEFAULT_API_KEY_NAME,
 		c.Base().Id(),
 		security.READ_ONLY_ACCESS,
+		nil,
 	)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := uc.authorizationRepo.Save(ctx, auth); err != nil {
 		return nil, err
 	}
 
 	return &CreateConfigResponse{
-		Id:          c.Base().Id().Value(),
-		SchemaId:    c.SchemaId().Value(),
-		Name:        c.Name().Value(),
-		Config:      c.Config(),
-		ValidSchema: validSchema,
-		ConfigSum:   c.Config().Hash(),
-		ApiKey:      apiKey.Value(),
+		Id:            c.Base().Id().Value(),
+		SchemaId:      c.SchemaId().Value(),
+		SchemaVersion: v.Version(),
+		SchemaPinned:  c.SchemaVersion() > 0,
+		Name:          c.Name().Value(),
+		Config:        codec.Ordered{Value: c.Config(), Order: c.KeyOrder()},
+		ValidSchema:   validation.Valid,
+		Validation:    validation,
+		ConfigSum:     c.Config().Hash(),
+		Version:       c.Base().Version(),
+		ApiKey:        apiKey.Value(),
 	}, nil
 }
@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+type CreateRoleCommand struct {
+	Id          string   `json:"id"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+type CreateRoleResponse struct {
+	Role Role `json:"role"`
+}
+
+type CreateRole struct {
+	roleRepo user.RoleRepository
+}
+
+func NewCreateRole(roleRepo user.RoleRepository) *CreateRole {
+	return &CreateRole{
+		roleRepo: roleRepo,
+	}
+}
+
+func (uc *CreateRole) Exec(
+	ctx context.Context,
+	cmd *CreateRoleCommand,
+) (*CreateRoleResponse, error) {
+	if err := requirePermission(ctx, user.USER_MANAGE_PERMISSION, user.Resource{}); err != nil {
+		return nil, err
+	}
+
+	id, err := models.NewSlug(cmd.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.roleRepo.FindById(ctx, id); err != user.ErrRoleNotFound {
+		return nil, fmt.Errorf("role %s already exists", id.Value())
+	}
+
+	permissions, err := newPermissions(cmd.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := user.NewRole(id, cmd.Name, permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.roleRepo.Save(ctx, r); err != nil {
+		return nil, err
+	}
+
+	return &CreateRoleResponse{
+		Role: newRole(r),
+	}, nil
+}
@@ -5,30 +5,46 @@ import (
 	"fmt"
 
 	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/events"
 	"github.com/aboglioli/configd/pkg/models"
 )
 
 type CreateSchemaCommand struct {
-	Id     *string                `json:"id"`
-	Name   string                 `json:"name"`
-	Schema map[string]interface{} `json:"schema"`
+	Id             *string                `json:"id"`
+	Name           string                 `json:"name"`
+	ValidationMode *string                `json:"validation_mode"`
+	Schema         map[string]interface{} `json:"schema"`
+	// Format of Schema, also used in the response
+	Format string `json:"format"`
+	// Order of the keys of Schema as written
+	KeyOrder codec.KeyOrder `json:"-"`
 }
 
 type CreateSchemaResponse struct {
-	Id     string                 `json:"id"`
-	Name   string                 `json:"name"`
-	Schema map[string]interface{} `json:"schema"`
+	Id             string        `json:"id"`
+	Name           string        `json:"name"`
+	ValidationMode string        `json:"validation_mode"`
+	Schema         codec.Ordered `json:"schema"`
+	Version        uint          `json:"version"`
 }
 
 type CreateSchema struct {
-	schemaRepo schema.SchemaRepository
+	schemaRepo     schema.SchemaRepository
+	versionRepo    schema.VersionRepository
+	eventPublisher events.EventPublisher
 }
 
 func NewCreateSchema(
 	schemaRepo schema.SchemaRepository,
+	versionRepo schema.VersionRepository,
+	eventPublisher events.EventPublisher,
 ) *CreateSchema {
 	return &CreateSchema{
-		schemaRepo: schemaRepo,
+		schemaRepo:     schemaRepo,
+		versionRepo:    versionRepo,
+		eventPublisher: eventPublisher,
 	}
 }
 
@@ -36,6 +52,16 @@ func (uc *CreateSchema) Exec(
 	ctx context.Context,
 	cmd *CreateSchemaCommand,
 ) (*CreateSchemaResponse, error) {
+	format, err := schema.NewFormat(cmd.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON Schema documents may carry the name as their title
+	if title, ok := cmd.Schema["title"].(string); ok && cmd.Name == "" && format == schema.JSON_SCHEMA_FORMAT {
+		cmd.Name = title
+	}
+
 	// Name
 	name, err := schema.NewName(cmd.Name)
 	if err != nil {
@@ -53,17 +79,40 @@ func (uc *CreateSchema) Exec(
 		return nil, err
 	}
 
+	if err := requirePermission(ctx, user.SCHEMA_WRITE_PERMISSION, user.SchemaResource(id.Value())); err != nil {
+		return nil, err
+	}
+
 	if _, err := uc.schemaRepo.FindById(ctx, id); err != schema.ErrNotFound {
 		return nil, fmt.Errorf("schema with id %s already exists", id.Value())
 	}
 
+	if _, err := uc.schemaRepo.FindDeletedById(ctx, id); err != schema.ErrNotFound {
+		return nil, fmt.Errorf("schema with id %s is deleted, restore it or wait until it is purged", id.Value())
+	}
+
+	// Validation mode
+	validationMode := schema.WARN_VALIDATION
+	if cmd.ValidationMode != nil {
+		validationMode, err = schema.NewValidationMode(*cmd.ValidationMode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Parse props
-	props, err := schema.PropsFromMap(cmd.Schema)
+	props, err := format.Parse(cmd.Schema)
 	if err != nil {
 		return nil, err
 	}
 
-	s, err := schema.NewSchema(id, name, props...)
+	// JSON Schema documents describe props with other keys
+	keyOrder := cmd.KeyOrder
+	if format != schema.CONFIGD_FORMAT {
+		keyOrder = nil
+	}
+
+	s, err := schema.NewSchema(id, name, validationMode, keyOrder, props...)
 	if err != nil {
 		return nil, err
 	}
@@ -72,9 +121,21 @@ func (uc *CreateSchema) Exec(
 		return nil, err
 	}
 
+	if err := saveSchemaVersion(ctx, uc.versionRepo, s); err != nil {
+		return nil, err
+	}
+
+	if err := uc.eventPublisher.Publish(s.Base().Events()...); err != nil {
+		return nil, err
+	}
+
+	s.ClearEvents()
+
 	return &CreateSchemaResponse{
-		Id:     s.Base().Id().Value(),
-		Name:   s.Name().Value(),
-		Schema: s.ToMap(),
+		Id:             s.Base().Id().Value(),
+		Name:           s.Name().Value(),
+		ValidationMode: s.ValidationMode().String(),
+		Schema:         format.Export(s),
+		Version:        s.Base().Version(),
 	}, nil
 }
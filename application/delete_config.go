@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/events"
 	"github.com/aboglioli/configd/pkg/models"
 )
 
@@ -16,14 +18,20 @@ type DeleteConfigResponse struct {
 }
 
 type DeleteConfig struct {
-	configRepo config.ConfigRepository
+	configRepo     config.ConfigRepository
+	revisionRepo   config.RevisionRepository
+	eventPublisher events.EventPublisher
 }
 
 func NewDeleteConfig(
 	configRepo config.ConfigRepository,
+	revisionRepo config.RevisionRepository,
+	eventPublisher events.EventPublisher,
 ) *DeleteConfig {
 	return &DeleteConfig{
-		configRepo: configRepo,
+		configRepo:     configRepo,
+		revisionRepo:   revisionRepo,
+		eventPublisher: eventPublisher,
 	}
 }
 
@@ -41,10 +49,25 @@ func (uc *DeleteConfig) Exec(
 		return nil, err
 	}
 
-	if err := uc.configRepo.Delete(ctx, c.Base().Id()); err != nil {
+	if err := requirePermission(ctx, user.CONFIG_WRITE_PERMISSION, configResource(c)); err != nil {
 		return nil, err
 	}
 
+	if err := c.Delete(); err != nil {
+		return nil, err
+	}
+
+	// Soft delete, purged after the retention window
+	if err := saveConfig(ctx, uc.configRepo, uc.revisionRepo, c, ""); err != nil {
+		return nil, err
+	}
+
+	if err := uc.eventPublisher.Publish(c.Base().Events()...); err != nil {
+		return nil, err
+	}
+
+	c.ClearEvents()
+
 	return &DeleteConfigResponse{
 		Success: true,
 	}, nil
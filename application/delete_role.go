@@ -0,0 +1,73 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+type DeleteRoleCommand struct {
+	RoleId string `json:"role_id"`
+}
+
+type DeleteRoleResponse struct {
+	Id string `json:"id"`
+}
+
+// DeleteRole removes a custom role no longer assigned to anyone.
+type DeleteRole struct {
+	roleRepo       user.RoleRepository
+	assignmentRepo user.RoleAssignmentRepository
+}
+
+func NewDeleteRole(
+	roleRepo user.RoleRepository,
+	assignmentRepo user.RoleAssignmentRepository,
+) *DeleteRole {
+	return &DeleteRole{
+		roleRepo:       roleRepo,
+		assignmentRepo: assignmentRepo,
+	}
+}
+
+func (uc *DeleteRole) Exec(
+	ctx context.Context,
+	cmd *DeleteRoleCommand,
+) (*DeleteRoleResponse, error) {
+	if err := requirePermission(ctx, user.USER_MANAGE_PERMISSION, user.Resource{}); err != nil {
+		return nil, err
+	}
+
+	id, err := models.BuildId(cmd.RoleId)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := findRole(ctx, uc.roleRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.IsBuiltin() {
+		return nil, user.ErrBuiltinRole
+	}
+
+	assignments, err := uc.assignmentRepo.FindByRoleId(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(assignments) > 0 {
+		return nil, fmt.Errorf("role %s is assigned to %d users", id.Value(), len(assignments))
+	}
+
+	if err := uc.roleRepo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return &DeleteRoleResponse{
+		Id: id.Value(),
+	}, nil
+}
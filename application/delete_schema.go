@@ -2,28 +2,49 @@ package application
 
 import (
 	"context"
+	"sort"
 
+	"github.com/aboglioli/configd/domain/config"
 	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/domain/security"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/events"
 	"github.com/aboglioli/configd/pkg/models"
 )
 
 type DeleteSchemaCommand struct {
 	Id string `json:"id"`
+	// Cascade deletes the configs using the schema and their API keys.
+	// Otherwise deletion is refused while configs use the schema.
+	Cascade bool `json:"cascade"`
 }
 
 type DeleteSchemaResponse struct {
-	Success bool `json:"success"`
+	Success        bool     `json:"success"`
+	DeletedConfigs []string `json:"deleted_configs"`
 }
 
 type DeleteSchema struct {
-	schemaRepo schema.SchemaRepository
+	schemaRepo        schema.SchemaRepository
+	configRepo        config.ConfigRepository
+	revisionRepo      config.RevisionRepository
+	authorizationRepo security.AuthorizationRepository
+	eventPublisher    events.EventPublisher
 }
 
 func NewDeleteSchema(
 	schemaRepo schema.SchemaRepository,
+	configRepo config.ConfigRepository,
+	revisionRepo config.RevisionRepository,
+	authorizationRepo security.AuthorizationRepository,
+	eventPublisher events.EventPublisher,
 ) *DeleteSchema {
 	return &DeleteSchema{
-		schemaRepo: schemaRepo,
+		schemaRepo:        schemaRepo,
+		configRepo:        configRepo,
+		revisionRepo:      revisionRepo,
+		authorizationRepo: authorizationRepo,
+		eventPublisher:    eventPublisher,
 	}
 }
 
@@ -36,17 +57,84 @@ func (uc *DeleteSchema) Exec(
 		return nil, err
 	}
 
+	if err := requirePermission(ctx, user.SCHEMA_WRITE_PERMISSION, user.SchemaResource(id.Value())); err != nil {
+		return nil, err
+	}
+
 	s, err := uc.schemaRepo.FindById(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	// Delete
-	if err := uc.schemaRepo.Delete(ctx, s.Base().Id()); err != nil {
+	configs, err := uc.configRepo.FindBySchemaId(ctx, s.Base().Id())
+	if err != nil {
 		return nil, err
 	}
 
+	if len(configs) > 0 && !cmd.Cascade {
+		inUse := &SchemaInUseError{
+			SchemaId: s.Base().Id().Value(),
+			Configs:  make([]string, 0, len(configs)),
+		}
+
+		for _, c := range configs {
+			inUse.Configs = append(inUse.Configs, c.Base().Id().Value())
+		}
+		sort.Strings(inUse.Configs)
+
+		return nil, inUse
+	}
+
+	// Cascading writes every config, nothing is deleted unless all of them
+	// can be
+	for _, c := range configs {
+		if err := requirePermission(ctx, user.CONFIG_WRITE_PERMISSION, configResource(c)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Configs go first so a failure leaves the schema in place and the
+	// operation can be retried
+	deletedConfigs := make([]string, 0, len(configs))
+	for _, c := range configs {
+		if err := c.Delete(); err != nil {
+			return nil, err
+		}
+
+		if err := saveConfig(ctx, uc.configRepo, uc.revisionRepo, c, ""); err != nil {
+			return nil, err
+		}
+
+		if err := uc.authorizationRepo.DeleteByResourceId(ctx, c.Base().Id()); err != nil {
+			return nil, err
+		}
+
+		if err := uc.eventPublisher.Publish(c.Base().Events()...); err != nil {
+			return nil, err
+		}
+
+		c.ClearEvents()
+
+		deletedConfigs = append(deletedConfigs, c.Base().Id().Value())
+	}
+
+	if err := s.Delete(); err != nil {
+		return nil, err
+	}
+
+	// Soft delete, purged after the retention window
+	if err := uc.schemaRepo.Save(ctx, s); err != nil {
+		return nil, err
+	}
+
+	if err := uc.eventPublisher.Publish(s.Base().Events()...); err != nil {
+		return nil, err
+	}
+
+	s.ClearEvents()
+
 	return &DeleteSchemaResponse{
-		Success: true,
+		Success:        true,
+		DeletedConfigs: deletedConfigs,
 	}, nil
 }
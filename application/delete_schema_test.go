@@ -0,0 +1,143 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteSchemaInUse(t *testing.T) {
+	d := newDeps()
+	d.createSchema(t, "service")
+	d.createConfig(t, "service", "staging", 80)
+	d.createConfig(t, "service", "development", 80)
+
+	_, err := NewDeleteSchema(d.schemaRepo, d.configRepo, d.revisionRepo, d.authorizationRepo, d.eventBus).Exec(
+		systemContext(),
+		&DeleteSchemaCommand{
+			Id: "service",
+		},
+	)
+	assert.Equal(t, &SchemaInUseError{
+		SchemaId: "service",
+		Configs:  []string{"development", "staging"},
+	}, err)
+
+	// Nothing is deleted
+	id, err := models.BuildId("service")
+	utils.Ok(err)
+
+	_, err = d.schemaRepo.FindById(systemContext(), id)
+	assert.NoError(t, err)
+
+	configs, err := d.configRepo.FindBySchemaId(systemContext(), id)
+	utils.Ok(err)
+	assert.Len(t, configs, 2)
+}
+
+func TestDeleteSchemaCascade(t *testing.T) {
+	d := newDeps()
+	d.createSchema(t, "service")
+	d.createConfig(t, "service", "staging", 80)
+	d.createConfig(t, "service", "development", 80)
+
+	// Configs of other schemas are left untouched
+	d.createSchema(t, "other")
+	d.createConfig(t, "other", "production", 80)
+
+	res, err := NewDeleteSchema(d.schemaRepo, d.configRepo, d.revisionRepo, d.authorizationRepo, d.eventBus).Exec(
+		systemContext(),
+		&DeleteSchemaCommand{
+			Id:      "service",
+			Cascade: true,
+		},
+	)
+	if assert.NoError(t, err) {
+		assert.True(t, res.Success)
+		assert.ElementsMatch(t, []string{"development", "staging"}, res.DeletedConfigs)
+	}
+
+	ctx := systemContext()
+
+	schemaId, err := models.BuildId("service")
+	utils.Ok(err)
+
+	_, err = d.schemaRepo.FindById(ctx, schemaId)
+	assert.Equal(t, schema.ErrNotFound, err)
+
+	_, err = d.schemaRepo.FindDeletedById(ctx, schemaId)
+	assert.NoError(t, err)
+
+	for _, rawId := range []string{"development", "staging"} {
+		id, err := models.BuildId(rawId)
+		utils.Ok(err)
+
+		_, err = d.configRepo.FindById(ctx, id)
+		assert.Equal(t, config.ErrNotFound, err)
+
+		// Soft deleted until purged
+		_, err = d.configRepo.FindDeletedById(ctx, id)
+		assert.NoError(t, err)
+
+		auths, err := d.authorizationRepo.FindByResourceId(ctx, id)
+		utils.Ok(err)
+		assert.Empty(t, auths)
+	}
+
+	id, err := models.BuildId("production")
+	utils.Ok(err)
+
+	_, err = d.configRepo.FindById(ctx, id)
+	assert.NoError(t, err)
+
+	auths, err := d.authorizationRepo.FindByResourceId(ctx, id)
+	utils.Ok(err)
+	assert.Len(t, auths, 1)
+}
+
+func TestDeleteSchemaCascadeRequiresConfigWrite(t *testing.T) {
+	d := newDeps()
+	d.createSchema(t, "service")
+	d.createConfig(t, "service", "staging", 80)
+	d.createConfig(t, "service", "development", 80)
+
+	// The schema is writable but only one of its configs
+	ctx := userContext(
+		t,
+		grant(t, user.SCHEMA_WRITE_PERMISSION, "schema:service"),
+		grant(t, user.CONFIG_WRITE_PERMISSION, "config:development"),
+	)
+
+	_, err := NewDeleteSchema(d.schemaRepo, d.configRepo, d.revisionRepo, d.authorizationRepo, d.eventBus).Exec(
+		ctx,
+		&DeleteSchemaCommand{
+			Id:      "service",
+			Cascade: true,
+		},
+	)
+	assert.Equal(t, ErrForbidden, err)
+
+	// Nothing is deleted
+	schemaId, err := models.BuildId("service")
+	utils.Ok(err)
+
+	_, err = d.schemaRepo.FindById(systemContext(), schemaId)
+	assert.NoError(t, err)
+
+	for _, rawId := range []string{"development", "staging"} {
+		id, err := models.BuildId(rawId)
+		utils.Ok(err)
+
+		_, err = d.configRepo.FindById(systemContext(), id)
+		assert.NoError(t, err)
+
+		auths, err := d.authorizationRepo.FindByResourceId(systemContext(), id)
+		utils.Ok(err)
+		assert.Len(t, auths, 1)
+	}
+}
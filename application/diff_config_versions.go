@@ -0,0 +1,105 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/security"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+type DiffConfigVersionsCommand struct {
+	Id     string `json:"id"`
+	From   *uint  `json:"from"`
+	To     *uint  `json:"to"`
+	ApiKey string `json:"api_key"`
+}
+
+type DiffConfigVersionsResponse struct {
+	Id      string          `json:"id"`
+	From    uint            `json:"from"`
+	To      uint            `json:"to"`
+	Changes []config.Change `json:"changes"`
+}
+
+type DiffConfigVersions struct {
+	configRepo        config.ConfigRepository
+	revisionRepo      config.RevisionRepository
+	authorizationRepo security.AuthorizationRepository
+}
+
+func NewDiffConfigVersions(
+	configRepo config.ConfigRepository,
+	revisionRepo config.RevisionRepository,
+	authorizationRepo security.AuthorizationRepository,
+) *DiffConfigVersions {
+	return &DiffConfigVersions{
+		configRepo:        configRepo,
+		revisionRepo:      revisionRepo,
+		authorizationRepo: authorizationRepo,
+	}
+}
+
+func (uc *DiffConfigVersions) Exec(
+	ctx context.Context,
+	cmd *DiffConfigVersionsCommand,
+) (*DiffConfigVersionsResponse, error) {
+	id, err := models.BuildId(cmd.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check API Key
+	if err := authorizeConfigRead(ctx, uc.configRepo, uc.authorizationRepo, cmd.ApiKey, id); err != nil {
+		return nil, err
+	}
+
+	c, err := uc.configRepo.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := uc.revisionRepo.FindByConfigId(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// By default compare the latest revision with the previous one
+	var to *config.Revision
+	if cmd.To != nil {
+		to, err = uc.revisionRepo.FindByVersion(ctx, id, *cmd.To)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(revisions) > 0 {
+		to = revisions[len(revisions)-1]
+	} else {
+		return nil, config.ErrRevisionNotFound
+	}
+
+	var from *config.Revision
+	if cmd.From != nil {
+		from, err = uc.revisionRepo.FindByVersion(ctx, id, *cmd.From)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for _, rev := range revisions {
+			if rev.Version() < to.Version() {
+				from = rev
+			}
+		}
+
+		if from == nil {
+			return nil, fmt.Errorf("version %d has no previous version", to.Version())
+		}
+	}
+
+	return &DiffConfigVersionsResponse{
+		Id:      c.Base().Id().Value(),
+		From:    from.Version(),
+		To:      to.Version(),
+		Changes: config.Diff(from.Config(), to.Config()),
+	}, nil
+}
@@ -0,0 +1,54 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffConfigVersionsDefaultsToLatestVersion(t *testing.T) {
+	d := newDeps()
+	d.createSchema(t, "service")
+	d.createConfig(t, "service", "development", 80)
+
+	// Version 2 changes data, version 3 only renames the config
+	data := config.ConfigData{"port": 8080}
+	d.updateConfig(t, &UpdateConfigCommand{
+		Id:     "development",
+		Config: &data,
+	})
+
+	name := "renamed"
+	updated := d.updateConfig(t, &UpdateConfigCommand{
+		Id:   "development",
+		Name: &name,
+	})
+	assert.Equal(t, uint(3), updated.Version)
+
+	res, err := NewDiffConfigVersions(d.configRepo, d.revisionRepo, d.authorizationRepo).Exec(
+		systemContext(),
+		&DiffConfigVersionsCommand{
+			Id: "development",
+		},
+	)
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint(2), res.From)
+		assert.Equal(t, uint(3), res.To)
+		assert.Empty(t, res.Changes)
+	}
+
+	from := uint(1)
+	res, err = NewDiffConfigVersions(d.configRepo, d.revisionRepo, d.authorizationRepo).Exec(
+		systemContext(),
+		&DiffConfigVersionsCommand{
+			Id:   "development",
+			From: &from,
+		},
+	)
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint(1), res.From)
+		assert.Equal(t, uint(3), res.To)
+		assert.Len(t, res.Changes, 1)
+	}
+}
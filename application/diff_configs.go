@@ -0,0 +1,104 @@
+package application
+
+import (
+	"context"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/security"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+type DiffConfigsCommand struct {
+	A string `json:"a"`
+	B string `json:"b"`
+	// Each config must be readable with one of the keys
+	ApiKeys []string `json:"api_keys"`
+}
+
+type DiffedConfig struct {
+	Id        string `json:"id"`
+	Version   uint   `json:"version"`
+	ConfigSum string `json:"config_sum"`
+}
+
+type DiffConfigsResponse struct {
+	A       DiffedConfig    `json:"a"`
+	B       DiffedConfig    `json:"b"`
+	Changes []config.Change `json:"changes"`
+}
+
+type DiffConfigs struct {
+	configRepo        config.ConfigRepository
+	authorizationRepo security.AuthorizationRepository
+}
+
+func NewDiffConfigs(
+	configRepo config.ConfigRepository,
+	authorizationRepo security.AuthorizationRepository,
+) *DiffConfigs {
+	return &DiffConfigs{
+		configRepo:        configRepo,
+		authorizationRepo: authorizationRepo,
+	}
+}
+
+func (uc *DiffConfigs) Exec(
+	ctx context.Context,
+	cmd *DiffConfigsCommand,
+) (*DiffConfigsResponse, error) {
+	a, err := uc.findAuthorizedConfig(ctx, cmd.A, cmd.ApiKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := uc.findAuthorizedConfig(ctx, cmd.B, cmd.ApiKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiffConfigsResponse{
+		A: DiffedConfig{
+			Id:        a.Base().Id().Value(),
+			Version:   a.Base().Version(),
+			ConfigSum: a.Config().Hash(),
+		},
+		B: DiffedConfig{
+			Id:        b.Base().Id().Value(),
+			Version:   b.Base().Version(),
+			ConfigSum: b.Config().Hash(),
+		},
+		Changes: config.Diff(a.Config(), b.Config()),
+	}, nil
+}
+
+func (uc *DiffConfigs) findAuthorizedConfig(
+	ctx context.Context,
+	rawId string,
+	apiKeys []string,
+) (*config.Config, error) {
+	id, err := models.BuildId(rawId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Authenticated users need permissions instead of API keys
+	if _, ok := user.GrantsFromContext(ctx); ok {
+		if err := authorizeConfigRead(ctx, uc.configRepo, uc.authorizationRepo, "", id); err != nil {
+			return nil, err
+		}
+
+		return uc.configRepo.FindById(ctx, id)
+	}
+
+	authorized := false
+	for i := 0; !authorized && i < len(apiKeys); i++ {
+		authorized = authorizeApiKey(ctx, uc.configRepo, uc.authorizationRepo, apiKeys[i], id) == nil
+	}
+
+	if !authorized {
+		return nil, ErrUnauthorized
+	}
+
+	return uc.configRepo.FindById(ctx, id)
+}
@@ -2,8 +2,51 @@ package application
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aboglioli/configd/domain/schema"
 )
 
 var (
 	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
 )
+
+// SchemaInUseError is returned when deleting a schema still used by configs.
+type SchemaInUseError struct {
+	SchemaId string   `json:"schema_id"`
+	Configs  []string `json:"configs"`
+}
+
+func (err *SchemaInUseError) Error() string {
+	return fmt.Sprintf(
+		"schema %s is used by configs %s",
+		err.SchemaId,
+		strings.Join(err.Configs, ", "),
+	)
+}
+
+// BreakingSchemaChangeError is returned when a schema update would break
+// configs and it was not forced.
+type BreakingSchemaChangeError struct {
+	SchemaId string         `json:"schema_id"`
+	Impact   *schema.Impact `json:"impact"`
+}
+
+func (err *BreakingSchemaChangeError) Error() string {
+	if len(err.Impact.InvalidConfigs) == 0 {
+		return fmt.Sprintf("schema %s update has breaking changes, force it to apply", err.SchemaId)
+	}
+
+	configs := make([]string, 0, len(err.Impact.InvalidConfigs))
+	for _, c := range err.Impact.InvalidConfigs {
+		configs = append(configs, c.Id)
+	}
+
+	return fmt.Sprintf(
+		"schema %s update invalidates configs %s, force it to apply",
+		err.SchemaId,
+		strings.Join(configs, ", "),
+	)
+}
@@ -0,0 +1,19 @@
+package application
+
+import (
+	"github.com/aboglioli/configd/domain/config"
+)
+
+// FlatCommand describes a config as key/value lines: env, dotenv or
+// properties. Empty fields take the format defaults, see
+// config.NewFlatOptions.
+type FlatCommand struct {
+	Format    string `json:"format"`
+	Separator string `json:"separator"`
+	Prefix    string `json:"prefix"`
+	Case      string `json:"case"`
+}
+
+func (cmd *FlatCommand) options() (config.FlatOptions, error) {
+	return config.NewFlatOptions(cmd.Format, cmd.Separator, cmd.Prefix, cmd.Case)
+}
@@ -0,0 +1,71 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/security"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+type GetApiKeysCommand struct {
+	ConfigId string `json:"config_id"`
+}
+
+type GetApiKeysResponse struct {
+	ConfigId string   `json:"config_id"`
+	Keys     []ApiKey `json:"keys"`
+}
+
+type GetApiKeys struct {
+	configRepo        config.ConfigRepository
+	authorizationRepo security.AuthorizationRepository
+}
+
+func NewGetApiKeys(
+	configRepo config.ConfigRepository,
+	authorizationRepo security.AuthorizationRepository,
+) *GetApiKeys {
+	return &GetApiKeys{
+		configRepo:        configRepo,
+		authorizationRepo: authorizationRepo,
+	}
+}
+
+func (uc *GetApiKeys) Exec(
+	ctx context.Context,
+	cmd *GetApiKeysCommand,
+) (*GetApiKeysResponse, error) {
+	configId, err := models.BuildId(cmd.ConfigId)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := uc.configRepo.FindById(ctx, configId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requirePermission(ctx, user.KEY_MANAGE_PERMISSION, configResource(c)); err != nil {
+		return nil, err
+	}
+
+	found, err := uc.authorizationRepo.FindByResourceId(ctx, c.Base().Id())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	keys := make([]ApiKey, 0, len(found))
+	for _, auth := range found {
+		keys = append(keys, newApiKey(auth, now))
+	}
+
+	return &GetApiKeysResponse{
+		ConfigId: c.Base().Id().Value(),
+		Keys:     keys,
+	}, nil
+}
@@ -6,36 +6,48 @@ import (
 	"github.com/aboglioli/configd/domain/config"
 	"github.com/aboglioli/configd/domain/schema"
 	"github.com/aboglioli/configd/domain/security"
+	"github.com/aboglioli/configd/pkg/codec"
 	"github.com/aboglioli/configd/pkg/models"
 )
 
 type GetConfigCommand struct {
-	Id     string `json:"id"`
-	ApiKey string `json:"api_key"`
+	Id       string `json:"id"`
+	ApiKey   string `json:"api_key"`
+	Resolved bool   `json:"resolved"`
+	// Renders the config flattened into Document
+	Flat *FlatCommand `json:"flat"`
 }
 
 type GetConfigResponse struct {
-	Id          string            `json:"id"`
-	SchemaId    string            `json:"schema_id"`
-	Name        string            `json:"name"`
-	Config      config.ConfigData `json:"config"`
-	ValidSchema bool              `json:"valid_schema"`
-	ConfigSum   string            `json:"config_sum"`
+	Id       string `json:"id"`
+	SchemaId string `json:"schema_id"`
+	// Schema version the config was validated against and whether the
+	// config is pinned to it or tracks the latest one
+	SchemaVersion uint                     `json:"schema_version"`
+	SchemaPinned  bool                     `json:"schema_pinned"`
+	Name          string                   `json:"name"`
+	Config        codec.Ordered            `json:"config"`
+	Resolved      bool                     `json:"resolved"`
+	ValidSchema   bool                     `json:"valid_schema"`
+	Validation    *schema.ValidationResult `json:"validation"`
+	ConfigSum     string                   `json:"config_sum"`
+	Version       uint                     `json:"version"`
+	Document      string                   `json:"document,omitempty"`
 }
 
 type GetConfig struct {
-	schemaRepo        schema.SchemaRepository
+	versionRepo       schema.VersionRepository
 	configRepo        config.ConfigRepository
 	authorizationRepo security.AuthorizationRepository
 }
 
 func NewGetConfig(
-	schemaRepo schema.SchemaRepository,
+	versionRepo schema.VersionRepository,
 	configRepo config.ConfigRepository,
 	authorizationRepo security.AuthorizationRepository,
 ) *GetConfig {
 	return &GetConfig{
-		schemaRepo:        schemaRepo,
+		versionRepo:       versionRepo,
 		configRepo:        configRepo,
 		authorizationRepo: authorizationRepo,
 	}
@@ -51,46 +63,54 @@ func (uc *GetConfig) Exec(
 	}
 
 	// Check API Key
-	apiKey, err := security.NewApiKey(cmd.ApiKey)
-	if err != nil {
+	if err := authorizeConfigRead(ctx, uc.configRepo, uc.authorizationRepo, cmd.ApiKey, id); err != nil {
 		return nil, err
 	}
 
-	hashedApiKey, err := apiKey.Hash()
+	c, err := uc.configRepo.FindById(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	auth, err := uc.authorizationRepo.FindByApiKey(ctx, hashedApiKey)
+	// Pinned configs are checked against their schema version
+	v, err := findSchemaVersion(ctx, uc.versionRepo, c.SchemaId(), c.SchemaVersion())
 	if err != nil {
-		return nil, ErrUnauthorized
+		return nil, err
 	}
 
-	if !auth.ResourceId().Equals(id) {
-		return nil, ErrUnauthorized
+	// Fill missing keys with schema defaults
+	data := c.Config()
+	if cmd.Resolved {
+		data = v.Resolve(data)
 	}
 
-	c, err := uc.configRepo.FindById(ctx, id)
-	if err != nil {
-		return nil, err
-	}
+	validation := v.Check(data)
 
-	s, err := uc.schemaRepo.FindById(ctx, c.SchemaId())
-	if err != nil {
-		return nil, err
-	}
+	document := ""
+	if cmd.Flat != nil {
+		opts, err := cmd.Flat.options()
+		if err != nil {
+			return nil, err
+		}
 
-	validSchema := true
-	if err := s.Validate(c.Config()); err != nil {
-		validSchema = false
+		document, err = config.Flatten(data, opts)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &GetConfigResponse{
-		Id:          c.Base().Id().Value(),
-		SchemaId:    c.SchemaId().Value(),
-		Name:        c.Name().Value(),
-		Config:      c.Config(),
-		ValidSchema: validSchema,
-		ConfigSum:   c.Config().Hash(),
+		Id:            c.Base().Id().Value(),
+		SchemaId:      c.SchemaId().Value(),
+		SchemaVersion: v.Version(),
+		SchemaPinned:  c.SchemaVersion() > 0,
+		Name:          c.Name().Value(),
+		Config:        codec.Ordered{Value: data, Order: c.KeyOrder()},
+		Resolved:      cmd.Resolved,
+		ValidSchema:   validation.Valid,
+		Validation:    validation,
+		ConfigSum:     data.Hash(),
+		Version:       c.Base().Version(),
+		Document:      document,
 	}, nil
 }
@@ -0,0 +1,78 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/security"
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+type GetConfigVersionCommand struct {
+	Id      string `json:"id"`
+	Version uint   `json:"version"`
+	ApiKey  string `json:"api_key"`
+}
+
+type GetConfigVersionResponse struct {
+	Id        string        `json:"id"`
+	Version   uint          `json:"version"`
+	Config    codec.Ordered `json:"config"`
+	ConfigSum string        `json:"config_sum"`
+	Author    string        `json:"author"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type GetConfigVersion struct {
+	configRepo        config.ConfigRepository
+	revisionRepo      config.RevisionRepository
+	authorizationRepo security.AuthorizationRepository
+}
+
+func NewGetConfigVersion(
+	configRepo config.ConfigRepository,
+	revisionRepo config.RevisionRepository,
+	authorizationRepo security.AuthorizationRepository,
+) *GetConfigVersion {
+	return &GetConfigVersion{
+		configRepo:        configRepo,
+		revisionRepo:      revisionRepo,
+		authorizationRepo: authorizationRepo,
+	}
+}
+
+func (uc *GetConfigVersion) Exec(
+	ctx context.Context,
+	cmd *GetConfigVersionCommand,
+) (*GetConfigVersionResponse, error) {
+	id, err := models.BuildId(cmd.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check API Key
+	if err := authorizeConfigRead(ctx, uc.configRepo, uc.authorizationRepo, cmd.ApiKey, id); err != nil {
+		return nil, err
+	}
+
+	// Revisions of deleted configs are kept until purged
+	if _, err := uc.configRepo.FindById(ctx, id); err != nil {
+		return nil, err
+	}
+
+	rev, err := uc.revisionRepo.FindByVersion(ctx, id, cmd.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetConfigVersionResponse{
+		Id:        rev.ConfigId().Value(),
+		Version:   rev.Version(),
+		Config:    codec.Ordered{Value: rev.Config(), Order: rev.KeyOrder()},
+		ConfigSum: rev.ConfigSum(),
+		Author:    rev.Author(),
+		CreatedAt: rev.CreatedAt(),
+	}, nil
+}
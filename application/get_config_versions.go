@@ -0,0 +1,87 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/security"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+type GetConfigVersionsCommand struct {
+	Id     string `json:"id"`
+	ApiKey string `json:"api_key"`
+}
+
+type ConfigVersion struct {
+	Version   uint      `json:"version"`
+	ConfigSum string    `json:"config_sum"`
+	Author    string    `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GetConfigVersionsResponse struct {
+	Id       string          `json:"id"`
+	Version  uint            `json:"version"`
+	Versions []ConfigVersion `json:"versions"`
+}
+
+type GetConfigVersions struct {
+	configRepo        config.ConfigRepository
+	revisionRepo      config.RevisionRepository
+	authorizationRepo security.AuthorizationRepository
+}
+
+func NewGetConfigVersions(
+	configRepo config.ConfigRepository,
+	revisionRepo config.RevisionRepository,
+	authorizationRepo security.AuthorizationRepository,
+) *GetConfigVersions {
+	return &GetConfigVersions{
+		configRepo:        configRepo,
+		revisionRepo:      revisionRepo,
+		authorizationRepo: authorizationRepo,
+	}
+}
+
+func (uc *GetConfigVersions) Exec(
+	ctx context.Context,
+	cmd *GetConfigVersionsCommand,
+) (*GetConfigVersionsResponse, error) {
+	id, err := models.BuildId(cmd.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check API Key
+	if err := authorizeConfigRead(ctx, uc.configRepo, uc.authorizationRepo, cmd.ApiKey, id); err != nil {
+		return nil, err
+	}
+
+	c, err := uc.configRepo.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := uc.revisionRepo.FindByConfigId(ctx, c.Base().Id())
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]ConfigVersion, 0, len(revisions))
+	for _, rev := range revisions {
+		versions = append(versions, ConfigVersion{
+			Version:   rev.Version(),
+			ConfigSum: rev.ConfigSum(),
+			Author:    rev.Author(),
+			CreatedAt: rev.CreatedAt(),
+		})
+	}
+
+	return &GetConfigVersionsResponse{
+		Id:       c.Base().Id().Value(),
+		Version:  c.Base().Version(),
+		Versions: versions,
+	}, nil
+}
@@ -0,0 +1,83 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEveryConfigVersionHasARevision(t *testing.T) {
+	d := newDeps()
+	d.createSchema(t, "service")
+	d.createSchema(t, "other-service")
+	d.createConfig(t, "service", "development", 80)
+
+	// Version 2 changes data, the rest of versions keep it
+	data := config.ConfigData{"port": 8080}
+	d.updateConfig(t, &UpdateConfigCommand{
+		Id:     "development",
+		Config: &data,
+	})
+
+	name := "renamed"
+	d.updateConfig(t, &UpdateConfigCommand{
+		Id:   "development",
+		Name: &name,
+	})
+
+	schemaId := "other-service"
+	d.updateConfig(t, &UpdateConfigCommand{
+		Id:       "development",
+		SchemaId: &schemaId,
+	})
+
+	_, err := NewDeleteConfig(d.configRepo, d.revisionRepo, d.eventBus).Exec(
+		systemContext(),
+		&DeleteConfigCommand{Id: "development"},
+	)
+	utils.Ok(err)
+
+	restored, err := NewRestoreConfig(d.schemaRepo, d.configRepo, d.revisionRepo, d.eventBus).Exec(
+		systemContext(),
+		&RestoreConfigCommand{Id: "development"},
+	)
+	utils.Ok(err)
+	assert.Equal(t, uint(6), restored.Version)
+
+	res, err := NewGetConfigVersions(d.configRepo, d.revisionRepo, d.authorizationRepo).Exec(
+		systemContext(),
+		&GetConfigVersionsCommand{Id: "development"},
+	)
+	if !assert.NoError(t, err) || !assert.Len(t, res.Versions, 6) {
+		return
+	}
+
+	for i, v := range res.Versions {
+		assert.Equal(t, uint(i+1), v.Version)
+
+		version, err := NewGetConfigVersion(d.configRepo, d.revisionRepo, d.authorizationRepo).Exec(
+			systemContext(),
+			&GetConfigVersionCommand{Id: "development", Version: v.Version},
+		)
+		if assert.NoError(t, err) {
+			assert.Equal(t, v.ConfigSum, version.ConfigSum)
+		}
+	}
+
+	// Rolling back to a version only renaming the config keeps its data
+	rollback := NewRollbackConfig(d.schemaRepo, d.versionRepo, d.configRepo, d.revisionRepo, d.eventBus)
+
+	rolledBack, err := rollback.Exec(systemContext(), &RollbackConfigCommand{Id: "development", Version: 3})
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint(6), rolledBack.Version)
+		assert.Equal(t, config.ConfigData{"port": float64(8080)}, rolledBack.Config.Value)
+	}
+
+	rolledBack, err = rollback.Exec(systemContext(), &RollbackConfigCommand{Id: "development", Version: 1})
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint(7), rolledBack.Version)
+		assert.Equal(t, config.ConfigData{"port": float64(80)}, rolledBack.Config.Value)
+	}
+}
@@ -0,0 +1,103 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/domain/user"
+)
+
+type GetDeletedCommand struct{}
+
+type DeletedConfig struct {
+	Id        string    `json:"id"`
+	SchemaId  string    `json:"schema_id"`
+	Name      string    `json:"name"`
+	Version   uint      `json:"version"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type DeletedSchema struct {
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	Version   uint      `json:"version"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type GetDeletedResponse struct {
+	Configs []DeletedConfig `json:"configs"`
+	Schemas []DeletedSchema `json:"schemas"`
+}
+
+// GetDeleted lists soft-deleted configs and schemas that can still be
+// restored, among those readable by the user.
+type GetDeleted struct {
+	schemaRepo schema.SchemaRepository
+	configRepo config.ConfigRepository
+}
+
+func NewGetDeleted(
+	schemaRepo schema.SchemaRepository,
+	configRepo config.ConfigRepository,
+) *GetDeleted {
+	return &GetDeleted{
+		schemaRepo: schemaRepo,
+		configRepo: configRepo,
+	}
+}
+
+func (uc *GetDeleted) Exec(
+	ctx context.Context,
+	cmd *GetDeletedCommand,
+) (*GetDeletedResponse, error) {
+	grants, ok := user.GrantsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthorized
+	}
+
+	configs, err := uc.configRepo.FindDeleted(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas, err := uc.schemaRepo.FindDeleted(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &GetDeletedResponse{
+		Configs: make([]DeletedConfig, 0, len(configs)),
+		Schemas: make([]DeletedSchema, 0, len(schemas)),
+	}
+
+	for _, c := range configs {
+		if !grants.Allows(user.CONFIG_READ_PERMISSION, configResource(c)) {
+			continue
+		}
+
+		res.Configs = append(res.Configs, DeletedConfig{
+			Id:        c.Base().Id().Value(),
+			SchemaId:  c.SchemaId().Value(),
+			Name:      c.Name().Value(),
+			Version:   c.Base().Version(),
+			DeletedAt: *c.Base().DeletedAt(),
+		})
+	}
+
+	for _, s := range schemas {
+		if !grants.Allows(user.SCHEMA_READ_PERMISSION, user.SchemaResource(s.Base().Id().Value())) {
+			continue
+		}
+
+		res.Schemas = append(res.Schemas, DeletedSchema{
+			Id:        s.Base().Id().Value(),
+			Name:      s.Name().Value(),
+			Version:   s.Base().Version(),
+			DeletedAt: *s.Base().DeletedAt(),
+		})
+	}
+
+	return res, nil
+}
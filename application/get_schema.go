@@ -4,17 +4,22 @@ import (
 	"context"
 
 	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/codec"
 	"github.com/aboglioli/configd/pkg/models"
 )
 
 type GetSchemaCommand struct {
-	Id string `json:"id"`
+	Id     string `json:"id"`
+	Format string `json:"format"`
 }
 
 type GetSchemaResponse struct {
-	Id     string                 `json:"id"`
-	Name   string                 `json:"name"`
-	Schema map[string]interface{} `json:"schema"`
+	Id             string        `json:"id"`
+	Name           string        `json:"name"`
+	ValidationMode string        `json:"validation_mode"`
+	Schema         codec.Ordered `json:"schema"`
+	Version        uint          `json:"version"`
 }
 
 type GetSchema struct {
@@ -38,14 +43,25 @@ func (uc *GetSchema) Exec(
 		return nil, err
 	}
 
+	if err := requirePermission(ctx, user.SCHEMA_READ_PERMISSION, user.SchemaResource(id.Value())); err != nil {
+		return nil, err
+	}
+
+	format, err := schema.NewFormat(cmd.Format)
+	if err != nil {
+		return nil, err
+	}
+
 	s, err := uc.schemaRepo.FindById(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
 	return &GetSchemaResponse{
-		Id:     s.Base().Id().Value(),
-		Name:   s.Name().Value(),
-		Schema: s.ToMap(),
+		Id:             s.Base().Id().Value(),
+		Name:           s.Name().Value(),
+		ValidationMode: s.ValidationMode().String(),
+		Schema:         format.Export(s),
+		Version:        s.Base().Version(),
 	}, nil
 }
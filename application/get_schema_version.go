@@ -0,0 +1,69 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+type GetSchemaVersionCommand struct {
+	Id      string `json:"id"`
+	Version uint   `json:"version"`
+}
+
+type GetSchemaVersionResponse struct {
+	Id        string        `json:"id"`
+	Version   uint          `json:"version"`
+	Schema    codec.Ordered `json:"schema"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type GetSchemaVersion struct {
+	schemaRepo  schema.SchemaRepository
+	versionRepo schema.VersionRepository
+}
+
+func NewGetSchemaVersion(
+	schemaRepo schema.SchemaRepository,
+	versionRepo schema.VersionRepository,
+) *GetSchemaVersion {
+	return &GetSchemaVersion{
+		schemaRepo:  schemaRepo,
+		versionRepo: versionRepo,
+	}
+}
+
+func (uc *GetSchemaVersion) Exec(
+	ctx context.Context,
+	cmd *GetSchemaVersionCommand,
+) (*GetSchemaVersionResponse, error) {
+	id, err := models.BuildId(cmd.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requirePermission(ctx, user.SCHEMA_READ_PERMISSION, user.SchemaResource(id.Value())); err != nil {
+		return nil, err
+	}
+
+	s, err := uc.schemaRepo.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := uc.versionRepo.FindByVersion(ctx, s.Base().Id(), cmd.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetSchemaVersionResponse{
+		Id:        s.Base().Id().Value(),
+		Version:   v.Version(),
+		Schema:    codec.Ordered{Value: v.ToMap(), Order: v.KeyOrder()},
+		CreatedAt: v.CreatedAt(),
+	}, nil
+}
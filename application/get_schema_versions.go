@@ -0,0 +1,78 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+type GetSchemaVersionsCommand struct {
+	Id string `json:"id"`
+}
+
+type SchemaVersion struct {
+	Version   uint      `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GetSchemaVersionsResponse struct {
+	Id       string          `json:"id"`
+	Version  uint            `json:"version"`
+	Versions []SchemaVersion `json:"versions"`
+}
+
+type GetSchemaVersions struct {
+	schemaRepo  schema.SchemaRepository
+	versionRepo schema.VersionRepository
+}
+
+func NewGetSchemaVersions(
+	schemaRepo schema.SchemaRepository,
+	versionRepo schema.VersionRepository,
+) *GetSchemaVersions {
+	return &GetSchemaVersions{
+		schemaRepo:  schemaRepo,
+		versionRepo: versionRepo,
+	}
+}
+
+func (uc *GetSchemaVersions) Exec(
+	ctx context.Context,
+	cmd *GetSchemaVersionsCommand,
+) (*GetSchemaVersionsResponse, error) {
+	id, err := models.BuildId(cmd.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requirePermission(ctx, user.SCHEMA_READ_PERMISSION, user.SchemaResource(id.Value())); err != nil {
+		return nil, err
+	}
+
+	s, err := uc.schemaRepo.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := uc.versionRepo.FindBySchemaId(ctx, s.Base().Id())
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]SchemaVersion, 0, len(found))
+	for _, v := range found {
+		versions = append(versions, SchemaVersion{
+			Version:   v.Version(),
+			CreatedAt: v.CreatedAt(),
+		})
+	}
+
+	return &GetSchemaVersionsResponse{
+		Id:       s.Base().Id().Value(),
+		Version:  s.Base().Version(),
+		Versions: versions,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+
+	"github.com/aboglioli/configd/domain/user"
+)
+
+type GetUserRolesCommand struct {
+	Username string `json:"username"`
+}
+
+type GetUserRolesResponse struct {
+	Username string           `json:"username"`
+	Roles    []RoleAssignment `json:"roles"`
+}
+
+type GetUserRoles struct {
+	userRepo       user.UserRepository
+	assignmentRepo user.RoleAssignmentRepository
+}
+
+func NewGetUserRoles(
+	userRepo user.UserRepository,
+	assignmentRepo user.RoleAssignmentRepository,
+) *GetUserRoles {
+	return &GetUserRoles{
+		userRepo:       userRepo,
+		assignmentRepo: assignmentRepo,
+	}
+}
+
+func (uc *GetUserRoles) Exec(
+	ctx context.Context,
+	cmd *GetUserRolesCommand,
+) (*GetUserRolesResponse, error) {
+	if err := requirePermission(ctx, user.USER_MANAGE_PERMISSION, user.Resource{}); err != nil {
+		return nil, err
+	}
+
+	username, err := user.NewUsername(cmd.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := uc.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := uc.assignmentRepo.FindByUsername(ctx, u.Username())
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]RoleAssignment, 0, len(assignments))
+	for _, a := range assignments {
+		roles = append(roles, newRoleAssignment(a))
+	}
+
+	return &GetUserRolesResponse{
+		Username: u.Username().Value(),
+		Roles:    roles,
+	}, nil
+}
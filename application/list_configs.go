@@ -0,0 +1,174 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+type ListConfigsCommand struct {
+	SchemaId *string `json:"schema_id"`
+	Name     string  `json:"name"`
+	// Only configs passing (true) or failing (false) validation
+	Valid  *bool  `json:"valid"`
+	Sort   string `json:"sort"`
+	Order  string `json:"order"`
+	Cursor string `json:"cursor"`
+	Limit  int    `json:"limit"`
+}
+
+type ConfigSummary struct {
+	Id            string    `json:"id"`
+	SchemaId      string    `json:"schema_id"`
+	SchemaVersion uint      `json:"schema_version"`
+	SchemaPinned  bool      `json:"schema_pinned"`
+	Name          string    `json:"name"`
+	ValidSchema   bool      `json:"valid_schema"`
+	ConfigSum     string    `json:"config_sum"`
+	Version       uint      `json:"version"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type ListConfigsResponse struct {
+	Configs    []ConfigSummary `json:"configs"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+type ListConfigs struct {
+	versionRepo schema.VersionRepository
+	configRepo  config.ConfigRepository
+}
+
+func NewListConfigs(
+	versionRepo schema.VersionRepository,
+	configRepo config.ConfigRepository,
+) *ListConfigs {
+	return &ListConfigs{
+		versionRepo: versionRepo,
+		configRepo:  configRepo,
+	}
+}
+
+func (uc *ListConfigs) Exec(
+	ctx context.Context,
+	cmd *ListConfigsCommand,
+) (*ListConfigsResponse, error) {
+	sort, err := models.NewSort(cmd.Sort, cmd.Order)
+	if err != nil {
+		return nil, err
+	}
+
+	pagination, err := models.NewPagination(sort, cmd.Cursor, cmd.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	grants, ok := user.GrantsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthorized
+	}
+
+	criteria := config.Criteria{
+		Name:       cmd.Name,
+		Pagination: pagination,
+	}
+
+	if cmd.SchemaId != nil {
+		schemaId, err := models.BuildId(*cmd.SchemaId)
+		if err != nil {
+			return nil, err
+		}
+
+		criteria.SchemaId = &schemaId
+	}
+
+	// One extra item tells whether there is a next page
+	limit := pagination.Limit
+	criteria.Pagination.Limit = limit + 1
+
+	// Schema versions by schema_id@version, zero being the latest
+	versions := make(map[string]*schema.Version)
+	validatedWith := make(map[string]uint)
+	validity := make(map[string]bool)
+	matched := make([]*config.Config, 0, limit+1)
+
+	// Validity depends on the schema and readability on the grants so they
+	// are filtered here, fetching pages until filling the requested one
+	for {
+		page, err := uc.configRepo.Find(ctx, criteria)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range page {
+			if !grants.Allows(user.CONFIG_READ_PERMISSION, configResource(c)) {
+				continue
+			}
+
+			ref := fmt.Sprintf("%s@%d", c.SchemaId().Value(), c.SchemaVersion())
+			v, ok := versions[ref]
+			if !ok {
+				v, err = findSchemaVersion(ctx, uc.versionRepo, c.SchemaId(), c.SchemaVersion())
+				if err != nil {
+					return nil, err
+				}
+
+				versions[ref] = v
+			}
+
+			valid := v.Check(c.Config()).Valid
+			if cmd.Valid != nil && *cmd.Valid != valid {
+				continue
+			}
+
+			validity[c.Base().Id().Value()] = valid
+			validatedWith[c.Base().Id().Value()] = v.Version()
+			matched = append(matched, c)
+
+			if len(matched) > limit {
+				break
+			}
+		}
+
+		if len(matched) > limit || len(page) < criteria.Pagination.Limit {
+			break
+		}
+
+		last := page[len(page)-1]
+		criteria.Pagination.Cursor = models.NewCursor(sort, last.Base(), last.Name().Value())
+	}
+
+	res := &ListConfigsResponse{
+		Configs: make([]ConfigSummary, 0, limit),
+	}
+
+	if len(matched) > limit {
+		matched = matched[:limit]
+
+		last := matched[limit-1]
+		res.NextCursor = models.NewCursor(sort, last.Base(), last.Name().Value()).Encode()
+	}
+
+	for _, c := range matched {
+		res.Configs = append(res.Configs, ConfigSummary{
+			Id:            c.Base().Id().Value(),
+			SchemaId:      c.SchemaId().Value(),
+			SchemaVersion: validatedWith[c.Base().Id().Value()],
+			SchemaPinned:  c.SchemaVersion() > 0,
+			Name:          c.Name().Value(),
+			ValidSchema:   validity[c.Base().Id().Value()],
+			ConfigSum:     c.Config().Hash(),
+			Version:       c.Base().Version(),
+			CreatedAt:     c.Base().CreatedAt(),
+			UpdatedAt:     c.Base().UpdatedAt(),
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,53 @@
+package application
+
+import (
+	"context"
+
+	"github.com/aboglioli/configd/domain/user"
+)
+
+type ListRolesCommand struct{}
+
+type ListRolesResponse struct {
+	Roles []Role `json:"roles"`
+}
+
+// ListRoles lists builtin roles followed by custom ones.
+type ListRoles struct {
+	roleRepo user.RoleRepository
+}
+
+func NewListRoles(roleRepo user.RoleRepository) *ListRoles {
+	return &ListRoles{
+		roleRepo: roleRepo,
+	}
+}
+
+func (uc *ListRoles) Exec(
+	ctx context.Context,
+	cmd *ListRolesCommand,
+) (*ListRolesResponse, error) {
+	if err := requirePermission(ctx, user.USER_MANAGE_PERMISSION, user.Resource{}); err != nil {
+		return nil, err
+	}
+
+	custom, err := uc.roleRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	builtin := user.BuiltinRoles()
+
+	roles := make([]Role, 0, len(builtin)+len(custom))
+	for _, r := range builtin {
+		roles = append(roles, newRole(r))
+	}
+
+	for _, r := range custom {
+		roles = append(roles, newRole(r))
+	}
+
+	return &ListRolesResponse{
+		Roles: roles,
+	}, nil
+}
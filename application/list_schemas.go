@@ -0,0 +1,124 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+type ListSchemasCommand struct {
+	Name   string `json:"name"`
+	Sort   string `json:"sort"`
+	Order  string `json:"order"`
+	Cursor string `json:"cursor"`
+	Limit  int    `json:"limit"`
+}
+
+type SchemaSummary struct {
+	Id             string    `json:"id"`
+	Name           string    `json:"name"`
+	ValidationMode string    `json:"validation_mode"`
+	Version        uint      `json:"version"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type ListSchemasResponse struct {
+	Schemas    []SchemaSummary `json:"schemas"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+type ListSchemas struct {
+	schemaRepo schema.SchemaRepository
+}
+
+func NewListSchemas(schemaRepo schema.SchemaRepository) *ListSchemas {
+	return &ListSchemas{
+		schemaRepo: schemaRepo,
+	}
+}
+
+func (uc *ListSchemas) Exec(
+	ctx context.Context,
+	cmd *ListSchemasCommand,
+) (*ListSchemasResponse, error) {
+	sort, err := models.NewSort(cmd.Sort, cmd.Order)
+	if err != nil {
+		return nil, err
+	}
+
+	pagination, err := models.NewPagination(sort, cmd.Cursor, cmd.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	grants, ok := user.GrantsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthorized
+	}
+
+	// One extra item tells whether there is a next page
+	limit := pagination.Limit
+
+	criteria := schema.Criteria{
+		Name:       cmd.Name,
+		Pagination: pagination,
+	}
+	criteria.Pagination.Limit = limit + 1
+
+	// Readability depends on the grants so it is filtered here, fetching
+	// pages until filling the requested one
+	schemas := make([]*schema.Schema, 0, limit+1)
+	for {
+		page, err := uc.schemaRepo.Find(ctx, criteria)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range page {
+			if !grants.Allows(user.SCHEMA_READ_PERMISSION, user.SchemaResource(s.Base().Id().Value())) {
+				continue
+			}
+
+			schemas = append(schemas, s)
+
+			if len(schemas) > limit {
+				break
+			}
+		}
+
+		if len(schemas) > limit || len(page) < criteria.Pagination.Limit {
+			break
+		}
+
+		last := page[len(page)-1]
+		criteria.Pagination.Cursor = models.NewCursor(sort, last.Base(), last.Name().Value())
+	}
+
+	res := &ListSchemasResponse{
+		Schemas: make([]SchemaSummary, 0, limit),
+	}
+
+	if len(schemas) > limit {
+		schemas = schemas[:limit]
+
+		last := schemas[limit-1]
+		res.NextCursor = models.NewCursor(sort, last.Base(), last.Name().Value()).Encode()
+	}
+
+	for _, s := range schemas {
+		res.Schemas = append(res.Schemas, SchemaSummary{
+			Id:             s.Base().Id().Value(),
+			Name:           s.Name().Value(),
+			ValidationMode: s.ValidationMode().String(),
+			Version:        s.Base().Version(),
+			CreatedAt:      s.Base().CreatedAt(),
+			UpdatedAt:      s.Base().UpdatedAt(),
+		})
+	}
+
+	return res, nil
+}
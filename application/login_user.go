@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"time"
 
 	"github.com/aboglioli/configd/domain/user"
 )
@@ -11,26 +12,29 @@ type LoginUserCommand struct {
 	Password string `json:"password"`
 }
 
-type LoginUserResponse struct {
-	Token string `json:"auth_token"`
-}
-
 type LoginUser struct {
-	userRepo user.UserRepository
+	userRepo    user.UserRepository
+	sessionRepo user.SessionRepository
+	tokenIssuer *user.TokenIssuer
 }
 
 func NewLoginUser(
 	userRepo user.UserRepository,
+	sessionRepo user.SessionRepository,
+	tokenIssuer *user.TokenIssuer,
 ) *LoginUser {
 	return &LoginUser{
-		userRepo: userRepo,
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		tokenIssuer: tokenIssuer,
 	}
 }
 
+// Exec opens a session for the user and issues its first tokens.
 func (uc *LoginUser) Exec(
 	ctx context.Context,
 	cmd *LoginUserCommand,
-) (*LoginUserResponse, error) {
+) (*TokenResponse, error) {
 	username, err := user.NewUsername(cmd.Username)
 	if err != nil {
 		return nil, err
@@ -46,12 +50,16 @@ func (uc *LoginUser) Exec(
 		return nil, err
 	}
 
-	token, err := u.Login(username, password)
+	now := time.Now()
+
+	s, refreshToken, err := u.Login(username, password, uc.tokenIssuer.RefreshTtl(), now)
 	if err != nil {
 		return nil, err
 	}
 
-	return &LoginUserResponse{
-		Token: token.Value(),
-	}, nil
+	if err := uc.sessionRepo.Save(ctx, s); err != nil {
+		return nil, err
+	}
+
+	return issueTokens(uc.tokenIssuer, s, refreshToken, now)
 }
@@ -0,0 +1,58 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/aboglioli/configd/domain/user"
+)
+
+type LogoutUserCommand struct {
+	Token string `json:"token"`
+}
+
+type LogoutUserResponse struct {
+	SessionId string    `json:"session_id"`
+	RevokedAt time.Time `json:"revoked_at"`
+}
+
+type LogoutUser struct {
+	sessionRepo user.SessionRepository
+	tokenIssuer *user.TokenIssuer
+}
+
+func NewLogoutUser(
+	sessionRepo user.SessionRepository,
+	tokenIssuer *user.TokenIssuer,
+) *LogoutUser {
+	return &LogoutUser{
+		sessionRepo: sessionRepo,
+		tokenIssuer: tokenIssuer,
+	}
+}
+
+// Exec revokes the session of the access token, invalidating its access and
+// refresh tokens before they expire.
+func (uc *LogoutUser) Exec(
+	ctx context.Context,
+	cmd *LogoutUserCommand,
+) (*LogoutUserResponse, error) {
+	s, err := findTokenSession(ctx, uc.sessionRepo, uc.tokenIssuer, cmd.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.Revoke(now); err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	if err := uc.sessionRepo.Save(ctx, s); err != nil {
+		return nil, err
+	}
+
+	return &LogoutUserResponse{
+		SessionId: s.Id().Value(),
+		RevokedAt: now,
+	}, nil
+}
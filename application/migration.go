@@ -0,0 +1,39 @@
+package application
+
+import (
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/schema"
+)
+
+// MigrationCommand describes a config migration. See config.NewMigration for
+// the meaning of each field per op.
+type MigrationCommand struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	To    string      `json:"to"`
+	Value interface{} `json:"value"`
+	Type  string      `json:"type"`
+}
+
+// MigratedConfig is the result of migrating a single config.
+type MigratedConfig struct {
+	Id          string                   `json:"id"`
+	Changes     []config.Change          `json:"changes"`
+	ValidSchema bool                     `json:"valid_schema"`
+	Validation  *schema.ValidationResult `json:"validation,omitempty"`
+	Error       string                   `json:"error,omitempty"`
+}
+
+func parseMigrations(cmds []MigrationCommand) ([]*config.Migration, error) {
+	migrations := make([]*config.Migration, 0, len(cmds))
+	for _, cmd := range cmds {
+		m, err := config.NewMigration(config.MigrationOp(cmd.Op), cmd.Path, cmd.To, cmd.Value, cmd.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		migrations = append(migrations, m)
+	}
+
+	return migrations, nil
+}
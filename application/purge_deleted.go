@@ -0,0 +1,116 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/domain/security"
+	"github.com/aboglioli/configd/domain/user"
+)
+
+type PurgeDeletedCommand struct {
+	// Only items deleted longer than Retention ago are purged
+	Retention time.Duration `json:"retention"`
+}
+
+type PurgeDeletedResponse struct {
+	Configs []string `json:"configs"`
+	Schemas []string `json:"schemas"`
+}
+
+// PurgeDeleted permanently removes soft-deleted configs, with their history
+// and API keys, and soft-deleted schemas with their versions.
+type PurgeDeleted struct {
+	schemaRepo        schema.SchemaRepository
+	versionRepo       schema.VersionRepository
+	configRepo        config.ConfigRepository
+	revisionRepo      config.RevisionRepository
+	authorizationRepo security.AuthorizationRepository
+}
+
+func NewPurgeDeleted(
+	schemaRepo schema.SchemaRepository,
+	versionRepo schema.VersionRepository,
+	configRepo config.ConfigRepository,
+	revisionRepo config.RevisionRepository,
+	authorizationRepo security.AuthorizationRepository,
+) *PurgeDeleted {
+	return &PurgeDeleted{
+		schemaRepo:        schemaRepo,
+		versionRepo:       versionRepo,
+		configRepo:        configRepo,
+		revisionRepo:      revisionRepo,
+		authorizationRepo: authorizationRepo,
+	}
+}
+
+func (uc *PurgeDeleted) Exec(
+	ctx context.Context,
+	cmd *PurgeDeletedCommand,
+) (*PurgeDeletedResponse, error) {
+	// Purging removes anything deleted, so it is not scoped
+	for _, p := range []user.Permission{user.SCHEMA_WRITE_PERMISSION, user.CONFIG_WRITE_PERMISSION} {
+		if err := requirePermission(ctx, p, user.Resource{}); err != nil {
+			return nil, err
+		}
+	}
+
+	before := time.Now().Add(-cmd.Retention)
+
+	res := &PurgeDeletedResponse{
+		Configs: make([]string, 0),
+		Schemas: make([]string, 0),
+	}
+
+	configs, err := uc.configRepo.FindDeleted(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range configs {
+		if !c.Base().DeletedAt().Before(before) {
+			continue
+		}
+
+		id := c.Base().Id()
+
+		if err := uc.authorizationRepo.DeleteByResourceId(ctx, id); err != nil {
+			return nil, err
+		}
+
+		if err := uc.revisionRepo.DeleteByConfigId(ctx, id); err != nil {
+			return nil, err
+		}
+
+		if err := uc.configRepo.Delete(ctx, id); err != nil {
+			return nil, err
+		}
+
+		res.Configs = append(res.Configs, id.Value())
+	}
+
+	schemas, err := uc.schemaRepo.FindDeleted(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range schemas {
+		if !s.Base().DeletedAt().Before(before) {
+			continue
+		}
+
+		if err := uc.versionRepo.DeleteBySchemaId(ctx, s.Base().Id()); err != nil {
+			return nil, err
+		}
+
+		if err := uc.schemaRepo.Delete(ctx, s.Base().Id()); err != nil {
+			return nil, err
+		}
+
+		res.Schemas = append(res.Schemas, s.Base().Id().Value())
+	}
+
+	return res, nil
+}
@@ -0,0 +1,74 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/aboglioli/configd/domain/user"
+)
+
+type RefreshTokenCommand struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type RefreshToken struct {
+	userRepo    user.UserRepository
+	sessionRepo user.SessionRepository
+	tokenIssuer *user.TokenIssuer
+}
+
+func NewRefreshToken(
+	userRepo user.UserRepository,
+	sessionRepo user.SessionRepository,
+	tokenIssuer *user.TokenIssuer,
+) *RefreshToken {
+	return &RefreshToken{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		tokenIssuer: tokenIssuer,
+	}
+}
+
+// Exec exchanges a refresh token for new tokens of the same session. The
+// refresh token is rotated, so each one is only used once.
+func (uc *RefreshToken) Exec(
+	ctx context.Context,
+	cmd *RefreshTokenCommand,
+) (*TokenResponse, error) {
+	refreshToken, err := user.NewRefreshToken(cmd.RefreshToken)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	s, err := uc.sessionRepo.FindById(ctx, refreshToken.SessionId())
+	if err == user.ErrSessionNotFound {
+		return nil, ErrUnauthorized
+	} else if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	previousHashedRefreshToken := s.HashedRefreshToken()
+	next, err := s.Refresh(refreshToken, uc.tokenIssuer.RefreshTtl(), now)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	// Users removed after logging in cannot refresh their sessions
+	if _, err := uc.userRepo.FindByUsername(ctx, s.Username()); err == user.ErrNotFound {
+		return nil, ErrUnauthorized
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Concurrent refreshes with the same token or a logout made meanwhile
+	// leave the session unchanged
+	if err := uc.sessionRepo.SaveRefreshed(ctx, s, previousHashedRefreshToken); err == user.ErrInvalidRefreshToken {
+		return nil, ErrUnauthorized
+	} else if err != nil {
+		return nil, err
+	}
+
+	return issueTokens(uc.tokenIssuer, s, next, now)
+}
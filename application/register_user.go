@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/events"
 )
 
 type RegisterUserCommand struct {
@@ -13,19 +14,28 @@ type RegisterUserCommand struct {
 }
 
 type RegisterUserResponse struct {
-	Username string `json:"username"`
-	Access   string `json:"access"`
+	Username string           `json:"username"`
+	Access   string           `json:"access"`
+	Roles    []RoleAssignment `json:"roles"`
 }
 
+// RegisterUser creates a user with the builtin role matching their access,
+// granted globally. Other roles are assigned afterwards.
 type RegisterUser struct {
-	userRepo user.UserRepository
+	userRepo       user.UserRepository
+	assignmentRepo user.RoleAssignmentRepository
+	eventPublisher events.EventPublisher
 }
 
 func NewRegisterUser(
 	userRepo user.UserRepository,
+	assignmentRepo user.RoleAssignmentRepository,
+	eventPublisher events.EventPublisher,
 ) *RegisterUser {
 	return &RegisterUser{
-		userRepo: userRepo,
+		userRepo:       userRepo,
+		assignmentRepo: assignmentRepo,
+		eventPublisher: eventPublisher,
 	}
 }
 
@@ -33,11 +43,22 @@ func (uc *RegisterUser) Exec(
 	ctx context.Context,
 	cmd *RegisterUserCommand,
 ) (*RegisterUserResponse, error) {
+	if err := requirePermission(ctx, user.USER_MANAGE_PERMISSION, user.Resource{}); err != nil {
+		return nil, err
+	}
+
 	username, err := user.NewUsername(cmd.Username)
 	if err != nil {
 		return nil, err
 	}
 
+	// Registering again would replace the password and stack roles
+	if _, err := uc.userRepo.FindByUsername(ctx, username); err == nil {
+		return nil, user.ErrAlreadyExists
+	} else if err != user.ErrNotFound {
+		return nil, err
+	}
+
 	password, err := user.NewPassword(cmd.Password)
 	if err != nil {
 		return nil, err
@@ -64,8 +85,29 @@ func (uc *RegisterUser) Exec(
 		return nil, err
 	}
 
+	scope, err := user.NewScope(user.GLOBAL_SCOPE_VALUE)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment, err := user.NewRoleAssignment(u.Username(), user.RoleForAccess(u.Access()), scope)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.assignmentRepo.Save(ctx, assignment); err != nil {
+		return nil, err
+	}
+
+	if err := uc.eventPublisher.Publish(u.Base().Events()...); err != nil {
+		return nil, err
+	}
+
+	u.ClearEvents()
+
 	return &RegisterUserResponse{
 		Username: u.Username().Value(),
 		Access:   string(u.Access()),
+		Roles:    []RoleAssignment{newRoleAssignment(assignment)},
 	}, nil
 }
@@ -0,0 +1,46 @@
+package application
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterUserTwice(t *testing.T) {
+	d := newDeps()
+
+	registerUser := NewRegisterUser(d.userRepo, d.assignmentRepo, d.eventBus)
+
+	_, err := registerUser.Exec(systemContext(), &RegisterUserCommand{
+		Username: "team-lead",
+		Password: "password123",
+	})
+	utils.Ok(err)
+
+	_, err = registerUser.Exec(systemContext(), &RegisterUserCommand{
+		Username: "team-lead",
+		Password: "other-password",
+	})
+	assert.Equal(t, user.ErrAlreadyExists, err)
+
+	// The password and roles are kept
+	username, err := user.NewUsername("team-lead")
+	utils.Ok(err)
+
+	u, err := d.userRepo.FindByUsername(systemContext(), username)
+	if assert.NoError(t, err) {
+		password, err := user.NewPassword("password123")
+		utils.Ok(err)
+
+		_, _, err = u.Login(username, password, time.Hour, time.Now())
+		assert.NoError(t, err)
+	}
+
+	assignments, err := d.assignmentRepo.FindByUsername(systemContext(), username)
+	if assert.NoError(t, err) {
+		assert.Len(t, assignments, 1)
+	}
+}
@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/events"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+type RestoreConfigCommand struct {
+	Id string `json:"id"`
+}
+
+type RestoreConfigResponse struct {
+	Id        string        `json:"id"`
+	SchemaId  string        `json:"schema_id"`
+	Name      string        `json:"name"`
+	Config    codec.Ordered `json:"config"`
+	ConfigSum string        `json:"config_sum"`
+	Version   uint          `json:"version"`
+}
+
+type RestoreConfig struct {
+	schemaRepo     schema.SchemaRepository
+	configRepo     config.ConfigRepository
+	revisionRepo   config.RevisionRepository
+	eventPublisher events.EventPublisher
+}
+
+func NewRestoreConfig(
+	schemaRepo schema.SchemaRepository,
+	configRepo config.ConfigRepository,
+	revisionRepo config.RevisionRepository,
+	eventPublisher events.EventPublisher,
+) *RestoreConfig {
+	return &RestoreConfig{
+		schemaRepo:     schemaRepo,
+		configRepo:     configRepo,
+		revisionRepo:   revisionRepo,
+		eventPublisher: eventPublisher,
+	}
+}
+
+func (uc *RestoreConfig) Exec(
+	ctx context.Context,
+	cmd *RestoreConfigCommand,
+) (*RestoreConfigResponse, error) {
+	id, err := models.BuildId(cmd.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := uc.configRepo.FindDeletedById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requirePermission(ctx, user.CONFIG_WRITE_PERMISSION, configResource(c)); err != nil {
+		return nil, err
+	}
+
+	// A config is useless without its schema
+	if _, err := uc.schemaRepo.FindById(ctx, c.SchemaId()); err != nil {
+		return nil, fmt.Errorf("cannot restore config %s: %w", id.Value(), err)
+	}
+
+	if err := c.Restore(); err != nil {
+		return nil, err
+	}
+
+	if err := saveConfig(ctx, uc.configRepo, uc.revisionRepo, c, ""); err != nil {
+		return nil, err
+	}
+
+	if err := uc.eventPublisher.Publish(c.Base().Events()...); err != nil {
+		return nil, err
+	}
+
+	c.ClearEvents()
+
+	return &RestoreConfigResponse{
+		Id:        c.Base().Id().Value(),
+		SchemaId:  c.SchemaId().Value(),
+		Name:      c.Name().Value(),
+		Config:    codec.Ordered{Value: c.Config(), Order: c.KeyOrder()},
+		ConfigSum: c.Config().Hash(),
+		Version:   c.Base().Version(),
+	}, nil
+}
@@ -0,0 +1,79 @@
+package application
+
+import (
+	"context"
+
+	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/events"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+type RestoreSchemaCommand struct {
+	Id string `json:"id"`
+}
+
+type RestoreSchemaResponse struct {
+	Id             string        `json:"id"`
+	Name           string        `json:"name"`
+	ValidationMode string        `json:"validation_mode"`
+	Schema         codec.Ordered `json:"schema"`
+	Version        uint          `json:"version"`
+}
+
+type RestoreSchema struct {
+	schemaRepo     schema.SchemaRepository
+	eventPublisher events.EventPublisher
+}
+
+func NewRestoreSchema(
+	schemaRepo schema.SchemaRepository,
+	eventPublisher events.EventPublisher,
+) *RestoreSchema {
+	return &RestoreSchema{
+		schemaRepo:     schemaRepo,
+		eventPublisher: eventPublisher,
+	}
+}
+
+func (uc *RestoreSchema) Exec(
+	ctx context.Context,
+	cmd *RestoreSchemaCommand,
+) (*RestoreSchemaResponse, error) {
+	id, err := models.BuildId(cmd.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requirePermission(ctx, user.SCHEMA_WRITE_PERMISSION, user.SchemaResource(id.Value())); err != nil {
+		return nil, err
+	}
+
+	s, err := uc.schemaRepo.FindDeletedById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Restore(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.schemaRepo.Save(ctx, s); err != nil {
+		return nil, err
+	}
+
+	if err := uc.eventPublisher.Publish(s.Base().Events()...); err != nil {
+		return nil, err
+	}
+
+	s.ClearEvents()
+
+	return &RestoreSchemaResponse{
+		Id:             s.Base().Id().Value(),
+		Name:           s.Name().Value(),
+		ValidationMode: s.ValidationMode().String(),
+		Schema:         codec.Ordered{Value: s.ToMap(), Order: s.KeyOrder()},
+		Version:        s.Base().Version(),
+	}, nil
+}
@@ -0,0 +1,45 @@
+package application
+
+import (
+	"context"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/user"
+)
+
+// saveConfig stores the config along with the revision of its new version,
+// so every listed version can be read and rolled back to.
+func saveConfig(
+	ctx context.Context,
+	configRepo config.ConfigRepository,
+	revisionRepo config.RevisionRepository,
+	c *config.Config,
+	author string,
+) error {
+	if err := configRepo.Save(ctx, c); err != nil {
+		return err
+	}
+
+	return saveRevision(ctx, revisionRepo, c, author)
+}
+
+// saveRevision stores the current config data as a new entry of its history.
+// Revisions made by an authenticated user are attributed to them, whatever
+// author was given.
+func saveRevision(
+	ctx context.Context,
+	revisionRepo config.RevisionRepository,
+	c *config.Config,
+	author string,
+) error {
+	if u, ok := user.FromContext(ctx); ok {
+		author = u.Username().Value()
+	}
+
+	rev, err := config.NewRevision(c, author)
+	if err != nil {
+		return err
+	}
+
+	return revisionRepo.Save(ctx, rev)
+}
@@ -0,0 +1,56 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/security"
+)
+
+type RevokeApiKeyCommand struct {
+	ConfigId string `json:"config_id"`
+	KeyId    string `json:"key_id"`
+}
+
+type RevokeApiKeyResponse struct {
+	Key ApiKey `json:"key"`
+}
+
+type RevokeApiKey struct {
+	configRepo        config.ConfigRepository
+	authorizationRepo security.AuthorizationRepository
+}
+
+func NewRevokeApiKey(
+	configRepo config.ConfigRepository,
+	authorizationRepo security.AuthorizationRepository,
+) *RevokeApiKey {
+	return &RevokeApiKey{
+		configRepo:        configRepo,
+		authorizationRepo: authorizationRepo,
+	}
+}
+
+func (uc *RevokeApiKey) Exec(
+	ctx context.Context,
+	cmd *RevokeApiKeyCommand,
+) (*RevokeApiKeyResponse, error) {
+	auth, err := findConfigAuthorization(ctx, uc.configRepo, uc.authorizationRepo, cmd.ConfigId, cmd.KeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := auth.Revoke(now); err != nil {
+		return nil, err
+	}
+
+	if err := uc.authorizationRepo.Save(ctx, auth); err != nil {
+		return nil, err
+	}
+
+	return &RevokeApiKeyResponse{
+		Key: newApiKey(auth, now),
+	}, nil
+}
@@ -0,0 +1,103 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+type Role struct {
+	Id          string   `json:"id"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+	Builtin     bool     `json:"builtin"`
+}
+
+func newRole(r *user.Role) Role {
+	permissions := make([]string, 0, len(r.Permissions()))
+	for _, p := range r.Permissions() {
+		permissions = append(permissions, string(p))
+	}
+
+	return Role{
+		Id:          r.Id().Value(),
+		Name:        r.Name(),
+		Permissions: permissions,
+		Builtin:     r.IsBuiltin(),
+	}
+}
+
+type RoleAssignment struct {
+	Id        string    `json:"id"`
+	Username  string    `json:"username"`
+	RoleId    string    `json:"role_id"`
+	Scope     string    `json:"scope"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newRoleAssignment(a *user.RoleAssignment) RoleAssignment {
+	return RoleAssignment{
+		Id:        a.Id().Value(),
+		Username:  a.Username().Value(),
+		RoleId:    a.RoleId().Value(),
+		Scope:     a.Scope().Value(),
+		CreatedAt: a.CreatedAt(),
+	}
+}
+
+func newPermissions(rawPermissions []string) ([]user.Permission, error) {
+	permissions := make([]user.Permission, 0, len(rawPermissions))
+	for _, rawPermission := range rawPermissions {
+		p, err := user.NewPermission(rawPermission)
+		if err != nil {
+			return nil, err
+		}
+
+		permissions = append(permissions, p)
+	}
+
+	return permissions, nil
+}
+
+// findRole returns a builtin role or a custom one.
+func findRole(ctx context.Context, roleRepo user.RoleRepository, id models.Id) (*user.Role, error) {
+	if r, ok := user.BuiltinRole(id); ok {
+		return r, nil
+	}
+
+	return roleRepo.FindById(ctx, id)
+}
+
+// findGrants resolves the permissions granted to a user by their role
+// assignments.
+func findGrants(
+	ctx context.Context,
+	roleRepo user.RoleRepository,
+	assignmentRepo user.RoleAssignmentRepository,
+	username user.Username,
+) (user.Grants, error) {
+	assignments, err := assignmentRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make(map[string]*user.Role)
+	for _, a := range assignments {
+		if _, ok := roles[a.RoleId().Value()]; ok {
+			continue
+		}
+
+		r, err := findRole(ctx, roleRepo, a.RoleId())
+		if err == user.ErrRoleNotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		roles[r.Id().Value()] = r
+	}
+
+	return user.NewGrants(assignments, roles), nil
+}
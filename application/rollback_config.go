@@ -0,0 +1,128 @@
+package application
+
+import (
+	"context"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/events"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+type RollbackConfigCommand struct {
+	Id             string  `json:"id"`
+	Version        uint    `json:"version"`
+	ValidationMode *string `json:"validation_mode"`
+	Author         string  `json:"author"`
+}
+
+type RollbackConfigResponse struct {
+	Id            string                   `json:"id"`
+	SchemaId      string                   `json:"schema_id"`
+	SchemaVersion uint                     `json:"schema_version"`
+	SchemaPinned  bool                     `json:"schema_pinned"`
+	Name          string                   `json:"name"`
+	Config        codec.Ordered            `json:"config"`
+	ValidSchema   bool                     `json:"valid_schema"`
+	Validation    *schema.ValidationResult `json:"validation"`
+	ConfigSum     string                   `json:"config_sum"`
+	Version       uint                     `json:"version"`
+	RolledBackTo  uint                     `json:"rolled_back_to"`
+}
+
+type RollbackConfig struct {
+	schemaRepo     schema.SchemaRepository
+	versionRepo    schema.VersionRepository
+	configRepo     config.ConfigRepository
+	revisionRepo   config.RevisionRepository
+	eventPublisher events.EventPublisher
+}
+
+func NewRollbackConfig(
+	schemaRepo schema.SchemaRepository,
+	versionRepo schema.VersionRepository,
+	configRepo config.ConfigRepository,
+	revisionRepo config.RevisionRepository,
+	eventPublisher events.EventPublisher,
+) *RollbackConfig {
+	return &RollbackConfig{
+		schemaRepo:     schemaRepo,
+		versionRepo:    versionRepo,
+		configRepo:     configRepo,
+		revisionRepo:   revisionRepo,
+		eventPublisher: eventPublisher,
+	}
+}
+
+func (uc *RollbackConfig) Exec(
+	ctx context.Context,
+	cmd *RollbackConfigCommand,
+) (*RollbackConfigResponse, error) {
+	id, err := models.BuildId(cmd.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := uc.configRepo.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requirePermission(ctx, user.CONFIG_WRITE_PERMISSION, configResource(c)); err != nil {
+		return nil, err
+	}
+
+	rev, err := uc.revisionRepo.FindByVersion(ctx, id, cmd.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := uc.schemaRepo.FindById(ctx, c.SchemaId())
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := findSchemaVersion(ctx, uc.versionRepo, c.SchemaId(), c.SchemaVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.Rollback(rev); err != nil {
+		return nil, err
+	}
+
+	// The schema may have changed since the revision was stored
+	validation, err := validateConfig(s, v, c.Config(), cmd.ValidationMode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rolling back to the current data is a no-op
+	if len(c.Base().Events()) > 0 {
+		if err := saveConfig(ctx, uc.configRepo, uc.revisionRepo, c, cmd.Author); err != nil {
+			return nil, err
+		}
+
+		if err := uc.eventPublisher.Publish(c.Base().Events()...); err != nil {
+			return nil, err
+		}
+
+		c.ClearEvents()
+	}
+
+	return &RollbackConfigResponse{
+		Id:            c.Base().Id().Value(),
+		SchemaId:      c.SchemaId().Value(),
+		SchemaVersion: v.Version(),
+		SchemaPinned:  c.SchemaVersion() > 0,
+		Name:          c.Name().Value(),
+		Config:        codec.Ordered{Value: c.Config(), Order: c.KeyOrder()},
+		ValidSchema:   validation.Valid,
+		Validation:    validation,
+		ConfigSum:     c.Config().Hash(),
+		Version:       c.Base().Version(),
+		RolledBackTo:  rev.Version(),
+	}, nil
+}
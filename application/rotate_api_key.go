@@ -0,0 +1,115 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/security"
+)
+
+type RotateApiKeyCommand struct {
+	ConfigId string `json:"config_id"`
+	KeyId    string `json:"key_id"`
+	// Duration, like 1h, the replaced key keeps working along with the new
+	// one. A zero grace period revokes it at once.
+	GracePeriod *string `json:"grace_period"`
+	// The new key keeps the replaced key expiration when empty
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type RotateApiKeyResponse struct {
+	// Only returned once
+	ApiKey   string `json:"api_key"`
+	Key      ApiKey `json:"key"`
+	Replaced ApiKey `json:"replaced"`
+}
+
+type RotateApiKey struct {
+	configRepo        config.ConfigRepository
+	authorizationRepo security.AuthorizationRepository
+}
+
+func NewRotateApiKey(
+	configRepo config.ConfigRepository,
+	authorizationRepo security.AuthorizationRepository,
+) *RotateApiKey {
+	return &RotateApiKey{
+		configRepo:        configRepo,
+		authorizationRepo: authorizationRepo,
+	}
+}
+
+func (uc *RotateApiKey) Exec(
+	ctx context.Context,
+	cmd *RotateApiKeyCommand,
+) (*RotateApiKeyResponse, error) {
+	grace := DEFAULT_ROTATION_GRACE_PERIOD
+	if cmd.GracePeriod != nil {
+		var err error
+		grace, err = time.ParseDuration(*cmd.GracePeriod)
+		if err != nil {
+			return nil, err
+		}
+
+		if grace < 0 || grace > MAX_ROTATION_GRACE_PERIOD {
+			return nil, fmt.Errorf("grace period must be between 0 and %s", MAX_ROTATION_GRACE_PERIOD)
+		}
+	}
+
+	old, err := findConfigAuthorization(ctx, uc.configRepo, uc.authorizationRepo, cmd.ConfigId, cmd.KeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only usable keys are rotated
+	now := time.Now()
+	if err := old.Check(now); err != nil {
+		return nil, err
+	}
+
+	expiresAt := old.ExpiresAt()
+	if cmd.ExpiresAt != nil {
+		expiresAt = cmd.ExpiresAt
+	}
+
+	apiKey, err := security.GenerateApiKey()
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := security.NewAuthorization(
+		apiKey,
+		old.Name(),
+		old.ResourceId(),
+		old.Access(),
+		expiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if grace == 0 {
+		err = old.Revoke(now)
+	} else {
+		old.ExpireAt(now.Add(grace))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.authorizationRepo.Save(ctx, auth); err != nil {
+		return nil, err
+	}
+
+	if err := uc.authorizationRepo.Save(ctx, old); err != nil {
+		return nil, err
+	}
+
+	return &RotateApiKeyResponse{
+		ApiKey:   apiKey.Value(),
+		Key:      newApiKey(auth, now),
+		Replaced: newApiKey(old, now),
+	}, nil
+}
@@ -0,0 +1,48 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+// findSchemaVersion returns the given schema version, or the latest one when
+// version is zero.
+func findSchemaVersion(
+	ctx context.Context,
+	versionRepo schema.VersionRepository,
+	schemaId models.Id,
+	version uint,
+) (*schema.Version, error) {
+	if version == 0 {
+		return versionRepo.FindLatest(ctx, schemaId)
+	}
+
+	return versionRepo.FindByVersion(ctx, schemaId, version)
+}
+
+// saveSchemaVersion stores the current schema props as the version following
+// the latest stored one.
+func saveSchemaVersion(
+	ctx context.Context,
+	versionRepo schema.VersionRepository,
+	s *schema.Schema,
+) error {
+	latest, err := versionRepo.FindLatest(ctx, s.Base().Id())
+	if err != nil {
+		if !errors.Is(err, schema.ErrVersionNotFound) {
+			return err
+		}
+
+		latest = nil
+	}
+
+	v, err := schema.NewVersion(s, latest)
+	if err != nil {
+		return err
+	}
+
+	return versionRepo.Save(ctx, v)
+}
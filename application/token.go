@@ -0,0 +1,42 @@
+package application
+
+import (
+	"time"
+
+	"github.com/aboglioli/configd/domain/user"
+)
+
+const (
+	BEARER_TOKEN_TYPE = "Bearer"
+)
+
+// TokenResponse carries the tokens issued on login and refresh. The access
+// token is sent as bearer token, the refresh token exchanged for new tokens
+// before the session expires.
+type TokenResponse struct {
+	Token            string    `json:"auth_token"`
+	TokenType        string    `json:"token_type"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+func issueTokens(
+	tokenIssuer *user.TokenIssuer,
+	s *user.Session,
+	refreshToken user.RefreshToken,
+	now time.Time,
+) (*TokenResponse, error) {
+	token, err := tokenIssuer.Issue(s, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenResponse{
+		Token:            token.Value(),
+		TokenType:        BEARER_TOKEN_TYPE,
+		ExpiresAt:        now.Add(tokenIssuer.AccessTtl()),
+		RefreshToken:     refreshToken.Value(),
+		RefreshExpiresAt: s.ExpiresAt(),
+	}, nil
+}
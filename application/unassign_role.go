@@ -0,0 +1,63 @@
+package application
+
+import (
+	"context"
+
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+type UnassignRoleCommand struct {
+	Username     string `json:"username"`
+	AssignmentId string `json:"assignment_id"`
+}
+
+type UnassignRoleResponse struct {
+	Role RoleAssignment `json:"role"`
+}
+
+type UnassignRole struct {
+	assignmentRepo user.RoleAssignmentRepository
+}
+
+func NewUnassignRole(assignmentRepo user.RoleAssignmentRepository) *UnassignRole {
+	return &UnassignRole{
+		assignmentRepo: assignmentRepo,
+	}
+}
+
+func (uc *UnassignRole) Exec(
+	ctx context.Context,
+	cmd *UnassignRoleCommand,
+) (*UnassignRoleResponse, error) {
+	if err := requirePermission(ctx, user.USER_MANAGE_PERMISSION, user.Resource{}); err != nil {
+		return nil, err
+	}
+
+	username, err := user.NewUsername(cmd.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := models.BuildId(cmd.AssignmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment, err := uc.assignmentRepo.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !assignment.Username().Equals(username) {
+		return nil, user.ErrRoleAssignmentNotFound
+	}
+
+	if err := uc.assignmentRepo.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return &UnassignRoleResponse{
+		Role: newRoleAssignment(assignment),
+	}, nil
+}
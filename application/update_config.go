@@ -2,39 +2,67 @@ package application
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/aboglioli/configd/domain/config"
 	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/events"
 	"github.com/aboglioli/configd/pkg/models"
 )
 
 type UpdateConfigCommand struct {
-	Id     string  `json:"id"`
-	Name   *string `json:"name"`
-	Config *config.ConfigData
+	Id   string  `json:"id"`
+	Name *string `json:"name"`
+	// Either schema_id or schema_id@version
+	SchemaId *string `json:"schema_id"`
+	Config   *config.ConfigData
+	// Order of the keys of Config as written
+	KeyOrder       codec.KeyOrder `json:"-"`
+	ValidationMode *string        `json:"validation_mode"`
+	Author         string         `json:"author"`
+	// Optional optimistic concurrency checks
+	ExpectedVersion   *uint   `json:"expected_version"`
+	ExpectedConfigSum *string `json:"expected_config_sum"`
 }
 
 type UpdateConfigResponse struct {
-	Id          string            `json:"id"`
-	SchemaId    string            `json:"schema_id"`
-	Name        string            `json:"name"`
-	Config      config.ConfigData `json:"config"`
-	ValidSchema bool              `json:"valid_schema"`
-	ConfigSum   string            `json:"config_sum"`
+	Id       string `json:"id"`
+	SchemaId string `json:"schema_id"`
+	// Schema version the config was validated against and whether the
+	// config is pinned to it or tracks the latest one
+	SchemaVersion uint                     `json:"schema_version"`
+	SchemaPinned  bool                     `json:"schema_pinned"`
+	Name          string                   `json:"name"`
+	Config        codec.Ordered            `json:"config"`
+	ValidSchema   bool                     `json:"valid_schema"`
+	Validation    *schema.ValidationResult `json:"validation"`
+	ConfigSum     string                   `json:"config_sum"`
+	Version       uint                     `json:"version"`
 }
 
 type UpdateConfig struct {
-	schemaRepo schema.SchemaRepository
-	configRepo config.ConfigRepository
+	schemaRepo     schema.SchemaRepository
+	versionRepo    schema.VersionRepository
+	configRepo     config.ConfigRepository
+	revisionRepo   config.RevisionRepository
+	eventPublisher events.EventPublisher
 }
 
 func NewUpdateConfig(
 	schemaRepo schema.SchemaRepository,
+	versionRepo schema.VersionRepository,
 	configRepo config.ConfigRepository,
+	revisionRepo config.RevisionRepository,
+	eventPublisher events.EventPublisher,
 ) *UpdateConfig {
 	return &UpdateConfig{
-		configRepo: configRepo,
-		schemaRepo: schemaRepo,
+		configRepo:     configRepo,
+		revisionRepo:   revisionRepo,
+		schemaRepo:     schemaRepo,
+		versionRepo:    versionRepo,
+		eventPublisher: eventPublisher,
 	}
 }
 
@@ -52,40 +80,94 @@ func (uc *UpdateConfig) Exec(
 		return nil, err
 	}
 
+	if err := requirePermission(ctx, user.CONFIG_WRITE_PERMISSION, configResource(c)); err != nil {
+		return nil, err
+	}
+
+	if err := checkExpectedVersion(c.Base(), cmd.ExpectedVersion); err != nil {
+		return nil, err
+	}
+
+	if cmd.ExpectedConfigSum != nil && *cmd.ExpectedConfigSum != c.Config().Hash() {
+		return nil, fmt.Errorf("%w: config_sum %s does not match current config", models.ErrVersionConflict, *cmd.ExpectedConfigSum)
+	}
+
+	// Update parameteres
+	if cmd.SchemaId != nil {
+		schemaId, schemaVersion, err := config.ParseSchemaRef(*cmd.SchemaId)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := c.ChangeSchema(schemaId, schemaVersion); err != nil {
+			return nil, err
+		}
+
+		// Moving a config also writes it under the new schema
+		if err := requirePermission(ctx, user.SCHEMA_READ_PERMISSION, user.SchemaResource(schemaId.Value())); err != nil {
+			return nil, err
+		}
+
+		if err := requirePermission(ctx, user.CONFIG_WRITE_PERMISSION, configResource(c)); err != nil {
+			return nil, err
+		}
+	}
+
 	s, err := uc.schemaRepo.FindById(ctx, c.SchemaId())
 	if err != nil {
 		return nil, err
 	}
 
-	// Update parameteres
+	v, err := findSchemaVersion(ctx, uc.versionRepo, c.SchemaId(), c.SchemaVersion())
+	if err != nil {
+		return nil, err
+	}
+
 	if cmd.Name != nil {
 		name, err := config.NewName(*cmd.Name)
 		if err != nil {
 			return nil, err
 		}
 
-		c.ChangeName(name)
+		if err := c.ChangeName(name); err != nil {
+			return nil, err
+		}
 	}
 
 	if cmd.Config != nil {
-		c.ChangeConfig(*cmd.Config)
+		if err := c.ChangeConfig(*cmd.Config, cmd.KeyOrder); err != nil {
+			return nil, err
+		}
 	}
 
-	if err := uc.configRepo.Save(ctx, c); err != nil {
+	validation, err := validateConfig(s, v, c.Config(), cmd.ValidationMode)
+	if err != nil {
 		return nil, err
 	}
 
-	validSchema := true
-	if err := s.Validate(c.Config()); err != nil {
-		validSchema = false
+	// Save only when something changed
+	if len(c.Base().Events()) > 0 {
+		if err := saveConfig(ctx, uc.configRepo, uc.revisionRepo, c, cmd.Author); err != nil {
+			return nil, err
+		}
+
+		if err := uc.eventPublisher.Publish(c.Base().Events()...); err != nil {
+			return nil, err
+		}
+
+		c.ClearEvents()
 	}
 
 	return &UpdateConfigResponse{
-		Id:          c.Base().Id().Value(),
-		SchemaId:    c.SchemaId().Value(),
-		Name:        c.Name().Value(),
-		Config:      c.Config(),
-		ValidSchema: validSchema,
-		ConfigSum:   c.Config().Hash(),
+		Id:            c.Base().Id().Value(),
+		SchemaId:      c.SchemaId().Value(),
+		SchemaVersion: v.Version(),
+		SchemaPinned:  c.SchemaVersion() > 0,
+		Name:          c.Name().Value(),
+		Config:        codec.Ordered{Value: c.Config(), Order: c.KeyOrder()},
+		ValidSchema:   validation.Valid,
+		Validation:    validation,
+		ConfigSum:     c.Config().Hash(),
+		Version:       c.Base().Version(),
 	}, nil
 }
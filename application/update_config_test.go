@@ -0,0 +1,53 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateConfigChangingSchema(t *testing.T) {
+	d := newDeps()
+	d.createSchema(t, "team-a-app")
+	d.createSchema(t, "team-a-worker")
+	d.createSchema(t, "team-b-app")
+	d.createConfig(t, "team-a-app", "development", 80)
+
+	ctx := roleContext(t, user.EDITOR_ROLE, "schema:team-a-*")
+
+	updateConfig := NewUpdateConfig(d.schemaRepo, d.versionRepo, d.configRepo, d.revisionRepo, d.eventBus)
+
+	type test struct {
+		name     string
+		schemaId string
+		err      error
+	}
+
+	tests := []test{
+		{
+			name:     "schema of another team",
+			schemaId: "team-b-app",
+			err:      ErrForbidden,
+		},
+		{
+			name:     "schema within scope",
+			schemaId: "team-a-worker",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := updateConfig.Exec(ctx, &UpdateConfigCommand{
+				Id:       "development",
+				SchemaId: &test.schemaId,
+			})
+
+			if test.err != nil {
+				assert.Equal(t, test.err, err)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, test.schemaId, res.SchemaId)
+			}
+		})
+	}
+}
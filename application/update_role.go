@@ -0,0 +1,66 @@
+package application
+
+import (
+	"context"
+
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+type UpdateRoleCommand struct {
+	RoleId      string   `json:"role_id"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+type UpdateRoleResponse struct {
+	Role Role `json:"role"`
+}
+
+// UpdateRole replaces the name and permissions of a custom role, changing
+// what its assignments grant.
+type UpdateRole struct {
+	roleRepo user.RoleRepository
+}
+
+func NewUpdateRole(roleRepo user.RoleRepository) *UpdateRole {
+	return &UpdateRole{
+		roleRepo: roleRepo,
+	}
+}
+
+func (uc *UpdateRole) Exec(
+	ctx context.Context,
+	cmd *UpdateRoleCommand,
+) (*UpdateRoleResponse, error) {
+	if err := requirePermission(ctx, user.USER_MANAGE_PERMISSION, user.Resource{}); err != nil {
+		return nil, err
+	}
+
+	id, err := models.BuildId(cmd.RoleId)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := findRole(ctx, uc.roleRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := newPermissions(cmd.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.Update(cmd.Name, permissions); err != nil {
+		return nil, err
+	}
+
+	if err := uc.roleRepo.Save(ctx, r); err != nil {
+		return nil, err
+	}
+
+	return &UpdateRoleResponse{
+		Role: newRole(r),
+	}, nil
+}
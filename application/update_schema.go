@@ -2,32 +2,68 @@ package application
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/aboglioli/configd/domain/config"
 	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/events"
 	"github.com/aboglioli/configd/pkg/models"
 )
 
 type UpdateSchemaCommand struct {
-	Id     string                  `json:"id"`
-	Name   *string                 `json:"name"`
-	Schema *map[string]interface{} `json:"schema"`
+	Id             string                  `json:"id"`
+	Name           *string                 `json:"name"`
+	ValidationMode *string                 `json:"validation_mode"`
+	Schema         *map[string]interface{} `json:"schema"`
+	// Order of the keys of Schema as written
+	KeyOrder codec.KeyOrder `json:"-"`
+	// Optional optimistic concurrency check
+	ExpectedVersion *uint `json:"expected_version"`
+	// Apply props changes even if they are breaking
+	Force bool `json:"force"`
+	// Migrations applied in order to every config tracking the latest
+	// schema version
+	Migrations []MigrationCommand `json:"migrations"`
+	// Report the update and migration results without saving anything
+	DryRun bool   `json:"dry_run"`
+	Author string `json:"author"`
 }
 
 type UpdateSchemaResponse struct {
-	Id     string                 `json:"id"`
-	Name   string                 `json:"name"`
-	Schema map[string]interface{} `json:"schema"`
+	Id             string        `json:"id"`
+	Name           string        `json:"name"`
+	ValidationMode string        `json:"validation_mode"`
+	Schema         codec.Ordered `json:"schema"`
+	Version        uint          `json:"version"`
+	// Only present when props changed
+	Impact  *schema.Impact   `json:"impact,omitempty"`
+	Configs []MigratedConfig `json:"configs,omitempty"`
+	DryRun  bool             `json:"dry_run"`
 }
 
 type UpdateSchema struct {
-	schemaRepo schema.SchemaRepository
+	schemaRepo     schema.SchemaRepository
+	versionRepo    schema.VersionRepository
+	configRepo     config.ConfigRepository
+	revisionRepo   config.RevisionRepository
+	eventPublisher events.EventPublisher
 }
 
 func NewUpdateSchema(
 	schemaRepo schema.SchemaRepository,
+	versionRepo schema.VersionRepository,
+	configRepo config.ConfigRepository,
+	revisionRepo config.RevisionRepository,
+	eventPublisher events.EventPublisher,
 ) *UpdateSchema {
 	return &UpdateSchema{
-		schemaRepo: schemaRepo,
+		schemaRepo:     schemaRepo,
+		versionRepo:    versionRepo,
+		configRepo:     configRepo,
+		revisionRepo:   revisionRepo,
+		eventPublisher: eventPublisher,
 	}
 }
 
@@ -40,11 +76,19 @@ func (uc *UpdateSchema) Exec(
 		return nil, err
 	}
 
+	if err := requirePermission(ctx, user.SCHEMA_WRITE_PERMISSION, user.SchemaResource(id.Value())); err != nil {
+		return nil, err
+	}
+
 	s, err := uc.schemaRepo.FindById(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := checkExpectedVersion(s.Base(), cmd.ExpectedVersion); err != nil {
+		return nil, err
+	}
+
 	// Name
 	if cmd.Name != nil {
 		name, err := schema.NewName(*cmd.Name)
@@ -52,26 +96,169 @@ func (uc *UpdateSchema) Exec(
 			return nil, err
 		}
 
-		s.ChangeName(name)
+		if err := s.ChangeName(name); err != nil {
+			return nil, err
+		}
+	}
+
+	if cmd.ValidationMode != nil {
+		validationMode, err := schema.NewValidationMode(*cmd.ValidationMode)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.ChangeValidationMode(validationMode); err != nil {
+			return nil, err
+		}
+	}
+
+	migrations, err := parseMigrations(cmd.Migrations)
+	if err != nil {
+		return nil, err
 	}
 
+	var configs []*config.Config
+	if cmd.Schema != nil || len(migrations) > 0 {
+		configs, err = uc.configRepo.FindBySchemaId(ctx, s.Base().Id())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Only props changes produce a new schema version
+	propsChanged := false
+	var impact *schema.Impact
 	if cmd.Schema != nil {
 		props, err := schema.PropsFromMap(*cmd.Schema)
 		if err != nil {
 			return nil, err
 		}
 
-		s.ChangeProps(props...)
+		impact = schema.NewImpact(s, configs, migrations, props...)
+		if impact.Breaking && !cmd.Force && !cmd.DryRun {
+			return nil, &BreakingSchemaChangeError{
+				SchemaId: s.Base().Id().Value(),
+				Impact:   impact,
+			}
+		}
+
+		prevEvents := len(s.Base().Events())
+
+		if err := s.ChangeProps(cmd.KeyOrder, props...); err != nil {
+			return nil, err
+		}
+
+		propsChanged = len(s.Base().Events()) > prevEvents
+		if !propsChanged {
+			impact = nil
+		}
 	}
 
-	// Save
-	if err := uc.schemaRepo.Save(ctx, s); err != nil {
-		return nil, err
+	// Configs pinned to a schema version keep their data, migrated ones are
+	// validated against the updated props
+	migrated := make([]*config.Config, 0)
+	results := make([]MigratedConfig, 0)
+	for _, c := range configs {
+		if len(migrations) == 0 || c.SchemaVersion() > 0 {
+			continue
+		}
+
+		data, err := config.Migrate(c.Config(), migrations...)
+		if err != nil {
+			if !cmd.DryRun {
+				return nil, fmt.Errorf("cannot migrate config %s: %w", c.Base().Id().Value(), err)
+			}
+
+			results = append(results, MigratedConfig{
+				Id:      c.Base().Id().Value(),
+				Changes: make([]config.Change, 0),
+				Error:   err.Error(),
+			})
+			continue
+		}
+
+		changes := config.Diff(c.Config(), data)
+		if len(changes) == 0 {
+			continue
+		}
+
+		// Migrating writes the config, as updating it does
+		if err := requirePermission(ctx, user.CONFIG_WRITE_PERMISSION, configResource(c)); err != nil {
+			if !cmd.DryRun {
+				return nil, fmt.Errorf("cannot migrate config %s: %w", c.Base().Id().Value(), err)
+			}
+
+			results = append(results, MigratedConfig{
+				Id:      c.Base().Id().Value(),
+				Changes: changes,
+				Error:   err.Error(),
+			})
+			continue
+		}
+
+		validation := s.Check(data)
+		if !validation.Valid && s.ValidationMode() == schema.STRICT_VALIDATION && !cmd.DryRun {
+			return nil, fmt.Errorf("migrated config %s: %w", c.Base().Id().Value(), validation)
+		}
+
+		if err := c.ChangeConfig(data, config.MigrateKeyOrder(c.KeyOrder(), migrations...)); err != nil {
+			return nil, err
+		}
+
+		migrated = append(migrated, c)
+		results = append(results, MigratedConfig{
+			Id:          c.Base().Id().Value(),
+			Changes:     changes,
+			ValidSchema: validation.Valid,
+			Validation:  validation,
+		})
+	}
+
+	res := &UpdateSchemaResponse{
+		Id:             s.Base().Id().Value(),
+		Name:           s.Name().Value(),
+		ValidationMode: s.ValidationMode().String(),
+		Schema:         codec.Ordered{Value: s.ToMap(), Order: s.KeyOrder()},
+		Version:        s.Base().Version(),
+		Impact:         impact,
+		Configs:        results,
+		DryRun:         cmd.DryRun,
+	}
+
+	if cmd.DryRun {
+		return res, nil
+	}
+
+	// Save only when something changed
+	if len(s.Base().Events()) > 0 {
+		if err := uc.schemaRepo.Save(ctx, s); err != nil {
+			return nil, err
+		}
+
+		if propsChanged {
+			if err := saveSchemaVersion(ctx, uc.versionRepo, s); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := uc.eventPublisher.Publish(s.Base().Events()...); err != nil {
+			return nil, err
+		}
+
+		s.ClearEvents()
+	}
+
+	for _, c := range migrated {
+		if err := saveConfig(ctx, uc.configRepo, uc.revisionRepo, c, cmd.Author); err != nil {
+			return nil, err
+		}
+
+		if err := uc.eventPublisher.Publish(c.Base().Events()...); err != nil {
+			return nil, err
+		}
+
+		c.ClearEvents()
 	}
 
-	return &UpdateSchemaResponse{
-		Id:     s.Base().Id().Value(),
-		Name:   s.Name().Value(),
-		Schema: s.ToMap(),
-	}, nil
+	return res, nil
 }
@@ -0,0 +1,150 @@
+package application
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateSchemaMigrationRequiresConfigWrite(t *testing.T) {
+	d := newDeps()
+	d.createSchema(t, "service")
+	d.createConfig(t, "service", "staging", 80)
+	d.createConfig(t, "service", "development", 80)
+
+	// The schema is writable but only one of its configs
+	ctx := userContext(
+		t,
+		grant(t, user.SCHEMA_WRITE_PERMISSION, "schema:service"),
+		grant(t, user.CONFIG_WRITE_PERMISSION, "config:development"),
+	)
+
+	updateSchema := NewUpdateSchema(d.schemaRepo, d.versionRepo, d.configRepo, d.revisionRepo, d.eventBus)
+
+	cmd := func(dryRun bool) *UpdateSchemaCommand {
+		return &UpdateSchemaCommand{
+			Id: "service",
+			Schema: &map[string]interface{}{
+				"listen_port": map[string]interface{}{
+					"$schema": map[string]interface{}{
+						"type": "integer",
+					},
+				},
+			},
+			Force: true,
+			Migrations: []MigrationCommand{
+				{Op: string(config.RENAME_MIGRATION), Path: "/port", To: "listen_port"},
+			},
+			DryRun: dryRun,
+		}
+	}
+
+	// Dry runs report the configs that cannot be migrated
+	res, err := updateSchema.Exec(ctx, cmd(true))
+	if assert.NoError(t, err) && assert.Len(t, res.Configs, 2) {
+		errs := make(map[string]string)
+		for _, c := range res.Configs {
+			assert.NotEmpty(t, c.Changes)
+			errs[c.Id] = c.Error
+		}
+
+		assert.Equal(t, "", errs["development"])
+		assert.Equal(t, ErrForbidden.Error(), errs["staging"])
+	}
+
+	_, err = updateSchema.Exec(ctx, cmd(false))
+	assert.True(t, errors.Is(err, ErrForbidden))
+
+	// Nothing is saved
+	schemaId, err := models.BuildId("service")
+	utils.Ok(err)
+
+	s, err := d.schemaRepo.FindById(systemContext(), schemaId)
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint(1), s.Base().Version())
+	}
+
+	for _, rawId := range []string{"development", "staging"} {
+		id, err := models.BuildId(rawId)
+		utils.Ok(err)
+
+		c, err := d.configRepo.FindById(systemContext(), id)
+		if assert.NoError(t, err) {
+			assert.Equal(t, config.ConfigData{"port": float64(80)}, c.Config())
+		}
+	}
+}
+
+func TestUpdateSchemaNumbersVersionsByPropsChanges(t *testing.T) {
+	d := newDeps()
+	d.createSchema(t, "service")
+
+	updateSchema := NewUpdateSchema(d.schemaRepo, d.versionRepo, d.configRepo, d.revisionRepo, d.eventBus)
+
+	// Renaming does not make a new version
+	name := "Renamed Service"
+	_, err := updateSchema.Exec(systemContext(), &UpdateSchemaCommand{
+		Id:   "service",
+		Name: &name,
+	})
+	utils.Ok(err)
+
+	_, err = updateSchema.Exec(systemContext(), &UpdateSchemaCommand{
+		Id: "service",
+		Schema: &map[string]interface{}{
+			"port": map[string]interface{}{
+				"$schema": map[string]interface{}{
+					"type": "integer",
+				},
+			},
+			"host": map[string]interface{}{
+				"$schema": map[string]interface{}{
+					"type": "string",
+				},
+			},
+		},
+	})
+	utils.Ok(err)
+
+	res, err := NewGetSchemaVersions(d.schemaRepo, d.versionRepo).Exec(
+		systemContext(),
+		&GetSchemaVersionsCommand{Id: "service"},
+	)
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint(3), res.Version)
+
+		versions := make([]uint, 0, len(res.Versions))
+		for _, v := range res.Versions {
+			versions = append(versions, v.Version)
+		}
+		assert.Equal(t, []uint{1, 2}, versions)
+	}
+
+	createConfig := NewCreateConfig(
+		d.schemaRepo,
+		d.versionRepo,
+		d.configRepo,
+		d.revisionRepo,
+		d.authorizationRepo,
+		d.eventBus,
+	)
+
+	create := func(id, schemaRef string) error {
+		_, err := createConfig.Exec(systemContext(), &CreateConfigCommand{
+			Id:       &id,
+			SchemaId: schemaRef,
+			Name:     id,
+			Config:   config.ConfigData{"port": 80},
+		})
+		return err
+	}
+
+	assert.NoError(t, create("pinned", "service@2"))
+	assert.True(t, errors.Is(create("missing", "service@3"), schema.ErrVersionNotFound))
+}
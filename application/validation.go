@@ -0,0 +1,33 @@
+package application
+
+import (
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/schema"
+)
+
+// validateConfig checks the config against a version of the schema. The
+// validation mode defined by the schema can be overridden per request. In
+// strict mode the returned error is the *schema.ValidationResult itself.
+func validateConfig(
+	s *schema.Schema,
+	v *schema.Version,
+	c config.ConfigData,
+	modeOverride *string,
+) (*schema.ValidationResult, error) {
+	mode := s.ValidationMode()
+	if modeOverride != nil {
+		m, err := schema.NewValidationMode(*modeOverride)
+		if err != nil {
+			return nil, err
+		}
+
+		mode = m
+	}
+
+	validation := v.Check(c)
+	if !validation.Valid && mode == schema.STRICT_VALIDATION {
+		return nil, validation
+	}
+
+	return validation, nil
+}
@@ -0,0 +1,156 @@
+package application
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/security"
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/events"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+type WatchConfigCommand struct {
+	Id     string `json:"id"`
+	ApiKey string `json:"api_key"`
+	Since  string `json:"since"`
+}
+
+type WatchConfigResponse struct {
+	Id        string        `json:"id"`
+	Config    codec.Ordered `json:"config"`
+	ConfigSum string        `json:"config_sum"`
+}
+
+type WatchConfig struct {
+	configRepo        config.ConfigRepository
+	authorizationRepo security.AuthorizationRepository
+	eventSubscriber   events.EventSubscriber
+}
+
+func NewWatchConfig(
+	configRepo config.ConfigRepository,
+	authorizationRepo security.AuthorizationRepository,
+	eventSubscriber events.EventSubscriber,
+) *WatchConfig {
+	return &WatchConfig{
+		configRepo:        configRepo,
+		authorizationRepo: authorizationRepo,
+		eventSubscriber:   eventSubscriber,
+	}
+}
+
+// Exec returns a channel emitting the config every time its hash differs from
+// the last one sent, starting from cmd.Since. The channel is closed when ctx is
+// done or the config is deleted.
+func (uc *WatchConfig) Exec(
+	ctx context.Context,
+	cmd *WatchConfigCommand,
+) (<-chan *WatchConfigResponse, error) {
+	id, err := models.BuildId(cmd.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check API Key
+	if err := authorizeConfigRead(ctx, uc.configRepo, uc.authorizationRepo, cmd.ApiKey, id); err != nil {
+		return nil, err
+	}
+
+	// Subscribe before reading current state to not miss any change. Only the
+	// latest change is kept because each event carries the whole config.
+	var mux sync.Mutex
+	var latest *WatchConfigResponse
+	deleted := false
+	notify := make(chan struct{}, 1)
+
+	unsubscribe := uc.eventSubscriber.Subscribe(
+		func(evt events.Event) error {
+			if evt.AggregateRootId() != id.Value() {
+				return nil
+			}
+
+			mux.Lock()
+			switch payload := evt.Payload().(type) {
+			case config.ConfigConfigChanged:
+				latest = &WatchConfigResponse{
+					Id:        payload.Id,
+					Config:    codec.Ordered{Value: config.ConfigData(payload.Config), Order: payload.KeyOrder},
+					ConfigSum: payload.ConfigSum,
+				}
+			case config.ConfigDeleted:
+				deleted = true
+			}
+			mux.Unlock()
+
+			select {
+			case notify <- struct{}{}:
+			default:
+			}
+
+			return nil
+		},
+		config.ConfigConfigChangedTopic,
+		config.ConfigDeletedTopic,
+	)
+
+	c, err := uc.configRepo.FindById(ctx, id)
+	if err != nil {
+		unsubscribe()
+		return nil, err
+	}
+
+	mux.Lock()
+	if latest == nil {
+		latest = &WatchConfigResponse{
+			Id:        c.Base().Id().Value(),
+			Config:    codec.Ordered{Value: c.Config(), Order: c.KeyOrder()},
+			ConfigSum: c.Config().Hash(),
+		}
+	}
+	mux.Unlock()
+
+	select {
+	case notify <- struct{}{}:
+	default:
+	}
+
+	out := make(chan *WatchConfigResponse)
+
+	go func() {
+		defer close(out)
+		defer unsubscribe()
+
+		lastSum := cmd.Since
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-notify:
+			}
+
+			mux.Lock()
+			current, isDeleted := latest, deleted
+			mux.Unlock()
+
+			if isDeleted {
+				return
+			}
+
+			if current.ConfigSum == lastSum {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case out <- current:
+				lastSum = current.ConfigSum
+			}
+		}
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,184 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/pkg/events"
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const watchTimeout = time.Second
+
+// countingSubscriber counts the subscriptions not unsubscribed yet.
+type countingSubscriber struct {
+	events.EventSubscriber
+
+	mux    sync.Mutex
+	active int
+}
+
+func (s *countingSubscriber) Subscribe(fn events.SubscriptionFunc, topics ...events.Topic) events.UnsubscribeFunc {
+	unsubscribe := s.EventSubscriber.Subscribe(fn, topics...)
+
+	s.mux.Lock()
+	s.active++
+	s.mux.Unlock()
+
+	return func() {
+		unsubscribe()
+
+		s.mux.Lock()
+		s.active--
+		s.mux.Unlock()
+	}
+}
+
+func (s *countingSubscriber) Active() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.active
+}
+
+func receive(t *testing.T, changes <-chan *WatchConfigResponse) (*WatchConfigResponse, bool) {
+	t.Helper()
+
+	select {
+	case res, ok := <-changes:
+		return res, ok
+	case <-time.After(watchTimeout):
+		t.Fatal("watch timed out")
+	}
+
+	return nil, false
+}
+
+func assertBlocked(t *testing.T, changes <-chan *WatchConfigResponse) {
+	t.Helper()
+
+	select {
+	case res, ok := <-changes:
+		t.Fatalf("unexpected watch result %v (open: %t)", res, ok)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestWatchConfig(t *testing.T) {
+	d := newDeps()
+	d.createSchema(t, "service")
+	created := d.createConfig(t, "service", "development", 80)
+
+	subscriber := &countingSubscriber{EventSubscriber: d.eventBus}
+	watchConfig := NewWatchConfig(d.configRepo, d.authorizationRepo, subscriber)
+
+	watch := func(ctx context.Context, since string) <-chan *WatchConfigResponse {
+		changes, err := watchConfig.Exec(ctx, &WatchConfigCommand{
+			Id:     "development",
+			ApiKey: created.ApiKey,
+			Since:  since,
+		})
+		utils.Ok(err)
+
+		return changes
+	}
+
+	t.Run("stale since returns immediately", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		res, ok := receive(t, watch(ctx, "stale"))
+		if assert.True(t, ok) {
+			assert.Equal(t, created.ConfigSum, res.ConfigSum)
+		}
+	})
+
+	t.Run("current since blocks until the next change", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		changes := watch(ctx, created.ConfigSum)
+		assertBlocked(t, changes)
+
+		data := config.ConfigData{"port": 8080}
+		updated := d.updateConfig(t, &UpdateConfigCommand{
+			Id:     "development",
+			Config: &data,
+		})
+
+		res, ok := receive(t, changes)
+		if assert.True(t, ok) {
+			assert.Equal(t, updated.ConfigSum, res.ConfigSum)
+			assert.Equal(t, config.ConfigData{"port": 8080}, res.Config.Value)
+		}
+
+		assertBlocked(t, changes)
+	})
+
+	t.Run("cancelling unsubscribes", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		current := receiveCurrent(t, watch)
+
+		changes := watch(ctx, current)
+		assertBlocked(t, changes)
+
+		cancel()
+
+		_, ok := receive(t, changes)
+		assert.False(t, ok)
+
+		// Later changes are not delivered to the cancelled watch
+		data := config.ConfigData{"port": 9090}
+		d.updateConfig(t, &UpdateConfigCommand{
+			Id:     "development",
+			Config: &data,
+		})
+
+		_, ok = <-changes
+		assert.False(t, ok)
+	})
+
+	t.Run("deleting the config closes the watch", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		changes := watch(ctx, receiveCurrent(t, watch))
+		assertBlocked(t, changes)
+
+		_, err := NewDeleteConfig(d.configRepo, d.revisionRepo, d.eventBus).Exec(
+			systemContext(),
+			&DeleteConfigCommand{
+				Id: "development",
+			},
+		)
+		utils.Ok(err)
+
+		_, ok := receive(t, changes)
+		assert.False(t, ok)
+	})
+
+	// Closed watches leave no subscription behind
+	assert.Eventually(t, func() bool {
+		return subscriber.Active() == 0
+	}, watchTimeout, 10*time.Millisecond)
+}
+
+// receiveCurrent returns the hash of the current config.
+func receiveCurrent(t *testing.T, watch func(context.Context, string) <-chan *WatchConfigResponse) string {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	res, ok := receive(t, watch(ctx, ""))
+	if !ok {
+		t.Fatal("watch closed")
+	}
+
+	return res.ConfigSum
+}
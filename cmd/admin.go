@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/utils"
+)
+
+// createAdmin registers the user with full access given by
+// CONFIGD_ADMIN_USERNAME and CONFIGD_ADMIN_PASSWORD, unless it exists. It is
+// the first user able to register others.
+func createAdmin(ctx context.Context) {
+	rawUsername := os.Getenv("CONFIGD_ADMIN_USERNAME")
+	password := os.Getenv("CONFIGD_ADMIN_PASSWORD")
+	if rawUsername == "" || password == "" {
+		log.Printf("admin: CONFIGD_ADMIN_USERNAME and CONFIGD_ADMIN_PASSWORD not set, no admin created")
+		return
+	}
+
+	deps := dependencies.Get()
+
+	username, err := user.NewUsername(rawUsername)
+	utils.Ok(err)
+
+	if _, err := deps.UserRepository.FindByUsername(ctx, username); err != user.ErrNotFound {
+		utils.Ok(err)
+		return
+	}
+
+	serv := application.NewRegisterUser(deps.UserRepository, deps.RoleAssignmentRepository, deps.EventBus)
+
+	access := string(user.FULL_ACCESS)
+	_, err = serv.Exec(user.NewSystemContext(ctx), &application.RegisterUserCommand{
+		Username: rawUsername,
+		Password: password,
+		Access:   &access,
+	})
+	utils.Ok(err)
+
+	log.Printf("admin: created user %s", rawUsername)
+}
@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getApiKey returns the API key sent in the X-Api-Key header, if any.
+func getApiKey(c *gin.Context) string {
+	apiKeys := c.Request.Header["X-Api-Key"]
+	if len(apiKeys) == 1 {
+		return apiKeys[0]
+	}
+
+	return ""
+}
+
+// getApiKeys returns every API key sent, either repeating the X-Api-Key
+// header or separating keys by commas.
+func getApiKeys(c *gin.Context) []string {
+	apiKeys := make([]string, 0)
+	for _, header := range c.Request.Header["X-Api-Key"] {
+		for _, apiKey := range strings.Split(header, ",") {
+			if apiKey = strings.TrimSpace(apiKey); apiKey != "" {
+				apiKeys = append(apiKeys, apiKey)
+			}
+		}
+	}
+
+	return apiKeys
+}
@@ -0,0 +1,30 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/gin-gonic/gin"
+)
+
+func AssignRole(c *gin.Context) {
+	deps := dependencies.Get()
+
+	serv := application.NewAssignRole(deps.UserRepository, deps.RoleRepository, deps.RoleAssignmentRepository)
+
+	var cmd application.AssignRoleCommand
+	if err := bindBody(c, &cmd); err != nil {
+		return
+	}
+
+	cmd.Username = c.Param("username")
+
+	res, err := serv.Exec(c.Request.Context(), &cmd)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	render(c, http.StatusOK, &res)
+}
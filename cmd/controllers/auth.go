@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"strings"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/gin-gonic/gin"
+)
+
+// Authenticate loads the user of the bearer token sent in the Authorization
+// header, with the permissions granted by their roles, into the request
+// context. Requests without token go on
+// unauthenticated, requests with an invalid one are rejected.
+func Authenticate(c *gin.Context) {
+	if c.GetHeader("Authorization") == "" {
+		c.Next()
+		return
+	}
+
+	token, ok := bearerToken(c)
+	if !ok {
+		handleError(c, application.ErrUnauthorized)
+		c.Abort()
+		return
+	}
+
+	deps := dependencies.Get()
+
+	serv := application.NewAuthenticateUser(
+		deps.UserRepository,
+		deps.SessionRepository,
+		deps.RoleRepository,
+		deps.RoleAssignmentRepository,
+		deps.TokenIssuer,
+	)
+
+	res, err := serv.Exec(c.Request.Context(), &application.AuthenticateUserCommand{
+		Token: token,
+	})
+	if err != nil {
+		handleError(c, err)
+		c.Abort()
+		return
+	}
+
+	c.Request = c.Request.WithContext(user.NewContext(c.Request.Context(), res.User, res.Grants))
+	c.Next()
+}
+
+// bearerToken returns the token sent in the Authorization header.
+func bearerToken(c *gin.Context) (string, bool) {
+	header := c.GetHeader("Authorization")
+
+	token := strings.TrimPrefix(header, "Bearer ")
+	if token == header {
+		return "", false
+	}
+
+	return strings.TrimSpace(token), true
+}
+
+// RequireUser rejects requests not made by an authenticated user. What they
+// are allowed to do is checked by each use case.
+func RequireUser(c *gin.Context) {
+	if _, ok := user.FromContext(c.Request.Context()); !ok {
+		handleError(c, application.ErrUnauthorized)
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
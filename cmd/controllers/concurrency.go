@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parseIfMatch reads the If-Match header, which may carry either an
+// aggregate version or a config_sum. Both are nil when the header is absent
+// or matches anything.
+func parseIfMatch(c *gin.Context) (*uint, *string) {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	value = strings.Trim(strings.TrimPrefix(value, "W/"), `"`)
+	if value == "" || value == "*" {
+		return nil, nil
+	}
+
+	if v, err := strconv.ParseUint(value, 10, 0); err == nil {
+		version := uint(v)
+		return &version, nil
+	}
+
+	return nil, &value
+}
+
+// parseIfMatchVersion is like parseIfMatch but only accepts versions.
+func parseIfMatchVersion(c *gin.Context) (*uint, error) {
+	version, other := parseIfMatch(c)
+	if other != nil {
+		return nil, errors.New("If-Match header must be a version")
+	}
+
+	return version, nil
+}
+
+// setETag exposes the aggregate version so clients can send it back in
+// If-Match.
+func setETag(c *gin.Context, version uint) {
+	c.Header("ETag", strconv.Quote(strconv.FormatUint(uint64(version), 10)))
+}
@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/gin-gonic/gin"
+)
+
+// bindBody decodes the request body into obj according to its Content-Type,
+// defaulting to JSON. Bodies in YAML or TOML are normalized to the values JSON
+// would produce.
+func bindBody(c *gin.Context, obj interface{}) error {
+	_, err := bindDocument(c, obj)
+	return err
+}
+
+// bindDocument is bindBody also returning the order of the keys written in
+// the body, to render configs and schemas the same way.
+func bindDocument(c *gin.Context, obj interface{}) (codec.KeyOrder, error) {
+	order, err := decodeBody(c, obj)
+	if err != nil {
+		handleError(c, err)
+	}
+
+	return order, err
+}
+
+func decodeBody(c *gin.Context, obj interface{}) (codec.KeyOrder, error) {
+	// Bodies were always read as JSON, whatever their Content-Type, and
+	// clients like curl -d send form types by default
+	format, err := codec.FormatFromContentType(c.GetHeader("Content-Type"))
+	if err != nil {
+		format = codec.JSON_FORMAT
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == codec.JSON_FORMAT {
+		if err := json.Unmarshal(body, obj); err != nil {
+			return nil, err
+		}
+	} else {
+		v, err := codec.Decode(format, body)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(b, obj); err != nil {
+			return nil, err
+		}
+	}
+
+	return codec.DecodeKeyOrder(format, body)
+}
+
+// render writes obj in the format preferred by the Accept header.
+func render(c *gin.Context, status int, obj interface{}) {
+	format := codec.FormatFromAccept(c.GetHeader("Accept"))
+	if format == codec.JSON_FORMAT {
+		c.JSON(status, obj)
+		return
+	}
+
+	data, err := codec.Encode(format, obj)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.Data(status, format.ContentType(), data)
+}
@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestKeyOrderRoundTrip(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Authentication is not under test
+	system := func(c *gin.Context) {
+		c.Request = c.Request.WithContext(user.NewSystemContext(c.Request.Context()))
+	}
+
+	r := gin.New()
+	r.POST("/schema", system, CreateSchema)
+	r.GET("/schema/:schema_id", system, GetSchema)
+	r.POST("/config", system, CreateConfig)
+	r.PUT("/config/:config_id", system, UpdateConfig)
+	r.GET("/config/:config_id", GetConfig)
+	r.GET("/config/:config_id/versions/:version", GetConfigVersion)
+
+	send := func(method, path, apiKey, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/yaml")
+		req.Header.Set("Accept", "application/yaml")
+		if apiKey != "" {
+			req.Header.Set("X-Api-Key", apiKey)
+		}
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		return w
+	}
+
+	keyOrder := func(w *httptest.ResponseRecorder, key string) codec.KeyOrder {
+		order, err := codec.DecodeKeyOrder(codec.YAML_FORMAT, w.Body.Bytes())
+		utils.Ok(err)
+
+		return order.Sub(key)
+	}
+
+	// Keys are not sorted
+	w := send(http.MethodPost, "/schema", "", `
+id: ordered-service
+name: Ordered Service
+schema:
+  server:
+    port:
+      $schema:
+        type: integer
+        required: true
+    host:
+      $schema:
+        type: string
+  name:
+    $schema:
+      type: string
+`)
+	if !assert.Equal(t, http.StatusOK, w.Code, w.Body.String()) {
+		return
+	}
+
+	w = send(http.MethodGet, "/schema/ordered-service", "", "")
+	if assert.Equal(t, http.StatusOK, w.Code, w.Body.String()) {
+		// Keys not written, like $schema defaults, go after the written ones
+		assert.Equal(
+			t,
+			codec.KeyOrder{
+				"/server",
+				"/server/port",
+				"/server/port/$schema",
+				"/server/port/$schema/type",
+				"/server/port/$schema/required",
+				"/server/port/$schema/default",
+				"/server/port/$schema/enum",
+				"/server/port/$schema/interval",
+				"/server/port/$schema/regex",
+				"/server/host",
+				"/server/host/$schema",
+				"/server/host/$schema/type",
+				"/server/host/$schema/default",
+				"/server/host/$schema/enum",
+				"/server/host/$schema/interval",
+				"/server/host/$schema/regex",
+				"/server/host/$schema/required",
+				"/name",
+				"/name/$schema",
+				"/name/$schema/type",
+				"/name/$schema/default",
+				"/name/$schema/enum",
+				"/name/$schema/interval",
+				"/name/$schema/regex",
+				"/name/$schema/required",
+			},
+			keyOrder(w, "schema"),
+		)
+	}
+
+	w = send(http.MethodPost, "/config", "", `
+id: ordered-development
+schema_id: ordered-service
+name: Development
+config:
+  server:
+    port: 8080
+    host: localhost
+  name: api
+`)
+	if !assert.Equal(t, http.StatusOK, w.Code, w.Body.String()) {
+		return
+	}
+
+	var created struct {
+		ApiKey string `yaml:"api_key"`
+	}
+	utils.Ok(yaml.Unmarshal(w.Body.Bytes(), &created))
+
+	assert.Equal(t, codec.KeyOrder{"/server", "/server/port", "/server/host", "/name"}, keyOrder(w, "config"))
+
+	w = send(http.MethodGet, "/config/ordered-development", created.ApiKey, "")
+	if assert.Equal(t, http.StatusOK, w.Code, w.Body.String()) {
+		assert.Equal(t, codec.KeyOrder{"/server", "/server/port", "/server/host", "/name"}, keyOrder(w, "config"))
+	}
+
+	// Updates are rendered in their own order, previous versions in theirs
+	w = send(http.MethodPut, "/config/ordered-development", "", `
+config:
+  name: api
+  server:
+    host: localhost
+    port: 9090
+`)
+	if assert.Equal(t, http.StatusOK, w.Code, w.Body.String()) {
+		assert.Equal(t, codec.KeyOrder{"/name", "/server", "/server/host", "/server/port"}, keyOrder(w, "config"))
+	}
+
+	w = send(http.MethodGet, "/config/ordered-development/versions/1", created.ApiKey, "")
+	if assert.Equal(t, http.StatusOK, w.Code, w.Body.String()) {
+		assert.Equal(t, codec.KeyOrder{"/server", "/server/port", "/server/host", "/name"}, keyOrder(w, "config"))
+	}
+}
@@ -1,7 +1,7 @@
 package controllers
 
 import (
-	"context"
+	"io"
 	"net/http"
 
 	"github.com/aboglioli/configd/application"
@@ -12,20 +12,45 @@ import (
 func CreateConfig(c *gin.Context) {
 	deps := dependencies.Get()
 
-	serv := application.NewCreateConfig(deps.SchemaRepository, deps.ConfigRepository, deps.AuthorizationRepository)
+	serv := application.NewCreateConfig(deps.SchemaRepository, deps.SchemaVersionRepository, deps.ConfigRepository, deps.RevisionRepository, deps.AuthorizationRepository, deps.EventBus)
 
 	var cmd application.CreateConfigCommand
-	if err := c.BindJSON(&cmd); err != nil {
-		return
+	if format, ok := c.GetQuery("format"); ok {
+		// Flattened documents are imported from the raw body, the rest of the
+		// command comes from the query
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		if id, ok := c.GetQuery("id"); ok {
+			cmd.Id = &id
+		}
+
+		if mode, ok := c.GetQuery("validation_mode"); ok {
+			cmd.ValidationMode = &mode
+		}
+
+		cmd.SchemaId = c.Query("schema_id")
+		cmd.Name = c.Query("name")
+		cmd.Author = c.Query("author")
+		cmd.Flat = flatCommand(c, format)
+		cmd.Document = string(body)
+	} else {
+		order, err := bindDocument(c, &cmd)
+		if err != nil {
+			return
+		}
+
+		cmd.KeyOrder = order.Sub("config")
 	}
 
-	res, err := serv.Exec(context.Background(), &cmd)
+	res, err := serv.Exec(c.Request.Context(), &cmd)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+		handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, &res)
+	render(c, http.StatusOK, &res)
 }
@@ -1,7 +1,6 @@
 package controllers
 
 import (
-	"context"
 	"net/http"
 
 	"github.com/aboglioli/configd/application"
@@ -12,20 +11,25 @@ import (
 func CreateSchema(c *gin.Context) {
 	deps := dependencies.Get()
 
-	serv := application.NewCreateSchema(deps.SchemaRepository)
+	serv := application.NewCreateSchema(deps.SchemaRepository, deps.SchemaVersionRepository, deps.EventBus)
 
 	var cmd application.CreateSchemaCommand
-	if err := c.BindJSON(&cmd); err != nil {
+	order, err := bindDocument(c, &cmd)
+	if err != nil {
 		return
 	}
 
-	res, err := serv.Exec(context.Background(), &cmd)
+	cmd.KeyOrder = order.Sub("schema")
+
+	if format, ok := c.GetQuery("format"); ok {
+		cmd.Format = format
+	}
+
+	res, err := serv.Exec(c.Request.Context(), &cmd)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+		handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, &res)
+	render(c, http.StatusOK, &res)
 }
@@ -1,7 +1,6 @@
 package controllers
 
 import (
-	"context"
 	"net/http"
 
 	"github.com/aboglioli/configd/application"
@@ -12,19 +11,17 @@ import (
 func DeleteConfig(c *gin.Context) {
 	deps := dependencies.Get()
 
-	serv := application.NewDeleteConfig(deps.ConfigRepository)
+	serv := application.NewDeleteConfig(deps.ConfigRepository, deps.RevisionRepository, deps.EventBus)
 
 	cmd := application.DeleteConfigCommand{
 		Id: c.Param("config_id"),
 	}
 
-	res, err := serv.Exec(context.Background(), &cmd)
+	res, err := serv.Exec(c.Request.Context(), &cmd)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+		handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, &res)
+	render(c, http.StatusOK, &res)
 }
@@ -0,0 +1,27 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/gin-gonic/gin"
+)
+
+func DeleteRole(c *gin.Context) {
+	deps := dependencies.Get()
+
+	serv := application.NewDeleteRole(deps.RoleRepository, deps.RoleAssignmentRepository)
+
+	cmd := application.DeleteRoleCommand{
+		RoleId: c.Param("role_id"),
+	}
+
+	res, err := serv.Exec(c.Request.Context(), &cmd)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	render(c, http.StatusOK, &res)
+}
@@ -1,8 +1,8 @@
 package controllers
 
 import (
-	"context"
 	"net/http"
+	"strconv"
 
 	"github.com/aboglioli/configd/application"
 	"github.com/aboglioli/configd/cmd/dependencies"
@@ -12,19 +12,24 @@ import (
 func DeleteSchema(c *gin.Context) {
 	deps := dependencies.Get()
 
-	serv := application.NewDeleteSchema(deps.SchemaRepository)
+	serv := application.NewDeleteSchema(deps.SchemaRepository, deps.ConfigRepository, deps.RevisionRepository, deps.AuthorizationRepository, deps.EventBus)
+
+	cascade, err := strconv.ParseBool(c.DefaultQuery("cascade", "false"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
 
 	cmd := application.DeleteSchemaCommand{
-		Id: c.Param("schema_id"),
+		Id:      c.Param("schema_id"),
+		Cascade: cascade,
 	}
 
-	res, err := serv.Exec(context.Background(), &cmd)
+	res, err := serv.Exec(c.Request.Context(), &cmd)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+		handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, &res)
+	render(c, http.StatusOK, &res)
 }
@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/gin-gonic/gin"
+)
+
+func DiffConfigVersions(c *gin.Context) {
+	deps := dependencies.Get()
+
+	serv := application.NewDiffConfigVersions(deps.ConfigRepository, deps.RevisionRepository, deps.AuthorizationRepository)
+
+	from, err := parseVersionQuery(c, "from")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	to, err := parseVersionQuery(c, "to")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	cmd := application.DiffConfigVersionsCommand{
+		Id:     c.Param("config_id"),
+		From:   from,
+		To:     to,
+		ApiKey: getApiKey(c),
+	}
+
+	res, err := serv.Exec(c.Request.Context(), &cmd)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	render(c, http.StatusOK, &res)
+}
+
+// parseVersionQuery returns nil when the query parameter is absent.
+func parseVersionQuery(c *gin.Context, key string) (*uint, error) {
+	raw, ok := c.GetQuery(key)
+	if !ok {
+		return nil, nil
+	}
+
+	v, err := strconv.ParseUint(raw, 10, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	version := uint(v)
+	return &version, nil
+}
@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/gin-gonic/gin"
+)
+
+func DiffConfigs(c *gin.Context) {
+	deps := dependencies.Get()
+
+	serv := application.NewDiffConfigs(deps.ConfigRepository, deps.AuthorizationRepository)
+
+	cmd := application.DiffConfigsCommand{
+		A:       c.Query("a"),
+		B:       c.Query("b"),
+		ApiKeys: getApiKeys(c),
+	}
+
+	res, err := serv.Exec(c.Request.Context(), &cmd)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	render(c, http.StatusOK, &res)
+}
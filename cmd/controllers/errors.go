@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+	"github.com/gin-gonic/gin"
+)
+
+func handleError(c *gin.Context, err error) {
+	var validation *schema.ValidationResult
+	if errors.As(err, &validation) {
+		render(c, http.StatusUnprocessableEntity, gin.H{
+			"error":      err.Error(),
+			"validation": validation,
+		})
+		return
+	}
+
+	var inUse *application.SchemaInUseError
+	if errors.As(err, &inUse) {
+		render(c, http.StatusConflict, gin.H{
+			"error":   err.Error(),
+			"configs": inUse.Configs,
+		})
+		return
+	}
+
+	var breaking *application.BreakingSchemaChangeError
+	if errors.As(err, &breaking) {
+		render(c, http.StatusConflict, gin.H{
+			"error":  err.Error(),
+			"impact": breaking.Impact,
+		})
+		return
+	}
+
+	if errors.Is(err, application.ErrUnauthorized) {
+		c.Header("WWW-Authenticate", "Bearer")
+		render(c, http.StatusUnauthorized, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if errors.Is(err, application.ErrForbidden) {
+		render(c, http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if errors.Is(err, models.ErrVersionConflict) || errors.Is(err, user.ErrAlreadyExists) {
+		render(c, http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	render(c, http.StatusBadRequest, gin.H{
+		"error": err.Error(),
+	})
+}
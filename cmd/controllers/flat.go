@@ -0,0 +1,24 @@
+package controllers
+
+import (
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/gin-gonic/gin"
+)
+
+func flatCommand(c *gin.Context, format string) *application.FlatCommand {
+	return &application.FlatCommand{
+		Format:    format,
+		Separator: c.Query("separator"),
+		Prefix:    c.Query("prefix"),
+		Case:      c.Query("case"),
+	}
+}
+
+func flatContentType(format string) string {
+	if format == string(config.PROPERTIES_FLAT_FORMAT) {
+		return "text/x-java-properties; charset=utf-8"
+	}
+
+	return "text/plain; charset=utf-8"
+}
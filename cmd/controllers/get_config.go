@@ -1,8 +1,8 @@
 package controllers
 
 import (
-	"context"
 	"net/http"
+	"strconv"
 
 	"github.com/aboglioli/configd/application"
 	"github.com/aboglioli/configd/cmd/dependencies"
@@ -12,26 +12,36 @@ import (
 func GetConfig(c *gin.Context) {
 	deps := dependencies.Get()
 
-	apiKeys := c.Request.Header["X-Api-Key"]
-	var apiKey string
-	if len(apiKeys) == 1 {
-		apiKey = apiKeys[0]
-	}
+	serv := application.NewGetConfig(deps.SchemaVersionRepository, deps.ConfigRepository, deps.AuthorizationRepository)
 
-	serv := application.NewGetConfig(deps.SchemaRepository, deps.ConfigRepository, deps.AuthorizationRepository)
+	resolved, err := strconv.ParseBool(c.DefaultQuery("resolved", "false"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
 
 	cmd := application.GetConfigCommand{
-		Id:     c.Param("config_id"),
-		ApiKey: apiKey,
+		Id:       c.Param("config_id"),
+		ApiKey:   getApiKey(c),
+		Resolved: resolved,
+	}
+
+	if format, ok := c.GetQuery("format"); ok {
+		cmd.Flat = flatCommand(c, format)
 	}
 
-	res, err := serv.Exec(context.Background(), &cmd)
+	res, err := serv.Exec(c.Request.Context(), &cmd)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+		handleError(c, err)
+		return
+	}
+
+	setETag(c, res.Version)
+
+	if cmd.Flat != nil {
+		c.Data(http.StatusOK, flatContentType(cmd.Flat.Format), []byte(res.Document))
 		return
 	}
 
-	c.JSON(http.StatusOK, &res)
+	render(c, http.StatusOK, &res)
 }
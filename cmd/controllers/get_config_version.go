@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/gin-gonic/gin"
+)
+
+func GetConfigVersion(c *gin.Context) {
+	deps := dependencies.Get()
+
+	serv := application.NewGetConfigVersion(deps.ConfigRepository, deps.RevisionRepository, deps.AuthorizationRepository)
+
+	version, err := strconv.ParseUint(c.Param("version"), 10, 0)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	cmd := application.GetConfigVersionCommand{
+		Id:      c.Param("config_id"),
+		Version: uint(version),
+		ApiKey:  getApiKey(c),
+	}
+
+	res, err := serv.Exec(c.Request.Context(), &cmd)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	render(c, http.StatusOK, &res)
+}
@@ -0,0 +1,28 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/gin-gonic/gin"
+)
+
+func GetConfigVersions(c *gin.Context) {
+	deps := dependencies.Get()
+
+	serv := application.NewGetConfigVersions(deps.ConfigRepository, deps.RevisionRepository, deps.AuthorizationRepository)
+
+	cmd := application.GetConfigVersionsCommand{
+		Id:     c.Param("config_id"),
+		ApiKey: getApiKey(c),
+	}
+
+	res, err := serv.Exec(c.Request.Context(), &cmd)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	render(c, http.StatusOK, &res)
+}
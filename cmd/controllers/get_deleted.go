@@ -0,0 +1,23 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/gin-gonic/gin"
+)
+
+func GetDeleted(c *gin.Context) {
+	deps := dependencies.Get()
+
+	serv := application.NewGetDeleted(deps.SchemaRepository, deps.ConfigRepository)
+
+	res, err := serv.Exec(c.Request.Context(), &application.GetDeletedCommand{})
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	render(c, http.StatusOK, &res)
+}
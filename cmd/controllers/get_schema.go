@@ -1,7 +1,6 @@
 package controllers
 
 import (
-	"context"
 	"net/http"
 
 	"github.com/aboglioli/configd/application"
@@ -15,16 +14,16 @@ func GetSchema(c *gin.Context) {
 	serv := application.NewGetSchema(deps.SchemaRepository)
 
 	cmd := application.GetSchemaCommand{
-		Id: c.Param("schema_id"),
+		Id:     c.Param("schema_id"),
+		Format: c.Query("format"),
 	}
 
-	res, err := serv.Exec(context.Background(), &cmd)
+	res, err := serv.Exec(c.Request.Context(), &cmd)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+		handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, &res)
+	setETag(c, res.Version)
+	render(c, http.StatusOK, &res)
 }
@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/gin-gonic/gin"
+)
+
+func GetSchemaVersion(c *gin.Context) {
+	deps := dependencies.Get()
+
+	serv := application.NewGetSchemaVersion(deps.SchemaRepository, deps.SchemaVersionRepository)
+
+	version, err := strconv.ParseUint(c.Param("version"), 10, 0)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	cmd := application.GetSchemaVersionCommand{
+		Id:      c.Param("schema_id"),
+		Version: uint(version),
+	}
+
+	res, err := serv.Exec(c.Request.Context(), &cmd)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	render(c, http.StatusOK, &res)
+}
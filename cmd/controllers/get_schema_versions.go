@@ -0,0 +1,27 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/gin-gonic/gin"
+)
+
+func GetSchemaVersions(c *gin.Context) {
+	deps := dependencies.Get()
+
+	serv := application.NewGetSchemaVersions(deps.SchemaRepository, deps.SchemaVersionRepository)
+
+	cmd := application.GetSchemaVersionsCommand{
+		Id: c.Param("schema_id"),
+	}
+
+	res, err := serv.Exec(c.Request.Context(), &cmd)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	render(c, http.StatusOK, &res)
+}
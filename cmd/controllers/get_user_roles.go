@@ -0,0 +1,27 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/gin-gonic/gin"
+)
+
+func GetUserRoles(c *gin.Context) {
+	deps := dependencies.Get()
+
+	serv := application.NewGetUserRoles(deps.UserRepository, deps.RoleAssignmentRepository)
+
+	cmd := application.GetUserRolesCommand{
+		Username: c.Param("username"),
+	}
+
+	res, err := serv.Exec(c.Request.Context(), &cmd)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	render(c, http.StatusOK, &res)
+}
@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/gin-gonic/gin"
+)
+
+func ListConfigs(c *gin.Context) {
+	deps := dependencies.Get()
+
+	serv := application.NewListConfigs(deps.SchemaVersionRepository, deps.ConfigRepository)
+
+	cmd := application.ListConfigsCommand{
+		Name:   c.Query("name"),
+		Sort:   c.Query("sort"),
+		Order:  c.Query("order"),
+		Cursor: c.Query("cursor"),
+	}
+
+	if schemaId, ok := c.GetQuery("schema_id"); ok {
+		cmd.SchemaId = &schemaId
+	}
+
+	if rawValid, ok := c.GetQuery("valid"); ok {
+		valid, err := strconv.ParseBool(rawValid)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		cmd.Valid = &valid
+	}
+
+	limit, err := parseLimit(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	cmd.Limit = limit
+
+	res, err := serv.Exec(c.Request.Context(), &cmd)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	render(c, http.StatusOK, &res)
+}
+
+// parseLimit returns zero, meaning the default page size, when absent.
+func parseLimit(c *gin.Context) (int, error) {
+	raw, ok := c.GetQuery("limit")
+	if !ok {
+		return 0, nil
+	}
+
+	return strconv.Atoi(raw)
+}
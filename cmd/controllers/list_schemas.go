@@ -0,0 +1,37 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/gin-gonic/gin"
+)
+
+func ListSchemas(c *gin.Context) {
+	deps := dependencies.Get()
+
+	serv := application.NewListSchemas(deps.SchemaRepository)
+
+	limit, err := parseLimit(c)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	cmd := application.ListSchemasCommand{
+		Name:   c.Query("name"),
+		Sort:   c.Query("sort"),
+		Order:  c.Query("order"),
+		Cursor: c.Query("cursor"),
+		Limit:  limit,
+	}
+
+	res, err := serv.Exec(c.Request.Context(), &cmd)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	render(c, http.StatusOK, &res)
+}
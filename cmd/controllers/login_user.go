@@ -1,7 +1,6 @@
 package controllers
 
 import (
-	"context"
 	"net/http"
 
 	"github.com/aboglioli/configd/application"
@@ -12,20 +11,18 @@ import (
 func LoginUser(c *gin.Context) {
 	deps := dependencies.Get()
 
-	serv := application.NewLoginUser(deps.UserRepository)
+	serv := application.NewLoginUser(deps.UserRepository, deps.SessionRepository, deps.TokenIssuer)
 
 	var cmd application.LoginUserCommand
-	if err := c.BindJSON(&cmd); err != nil {
+	if err := bindBody(c, &cmd); err != nil {
 		return
 	}
 
-	res, err := serv.Exec(context.Background(), &cmd)
+	res, err := serv.Exec(c.Request.Context(), &cmd)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+		handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, &res)
+	render(c, http.StatusOK, &res)
 }
@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/gin-gonic/gin"
+)
+
+func LogoutUser(c *gin.Context) {
+	deps := dependencies.Get()
+
+	serv := application.NewLogoutUser(deps.SessionRepository, deps.TokenIssuer)
+
+	token, _ := bearerToken(c)
+
+	cmd := application.LogoutUserCommand{
+		Token: token,
+	}
+
+	res, err := serv.Exec(c.Request.Context(), &cmd)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	render(c, http.StatusOK, &res)
+}
@@ -0,0 +1,28 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/gin-gonic/gin"
+)
+
+func RefreshToken(c *gin.Context) {
+	deps := dependencies.Get()
+
+	serv := application.NewRefreshToken(deps.UserRepository, deps.SessionRepository, deps.TokenIssuer)
+
+	var cmd application.RefreshTokenCommand
+	if err := bindBody(c, &cmd); err != nil {
+		return
+	}
+
+	res, err := serv.Exec(c.Request.Context(), &cmd)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	render(c, http.StatusOK, &res)
+}
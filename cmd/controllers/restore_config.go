@@ -0,0 +1,27 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/gin-gonic/gin"
+)
+
+func RestoreConfig(c *gin.Context) {
+	deps := dependencies.Get()
+
+	serv := application.NewRestoreConfig(deps.SchemaRepository, deps.ConfigRepository, deps.RevisionRepository, deps.EventBus)
+
+	cmd := application.RestoreConfigCommand{
+		Id: c.Param("config_id"),
+	}
+
+	res, err := serv.Exec(c.Request.Context(), &cmd)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	render(c, http.StatusOK, &res)
+}
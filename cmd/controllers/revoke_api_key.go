@@ -0,0 +1,28 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/gin-gonic/gin"
+)
+
+func RevokeApiKey(c *gin.Context) {
+	deps := dependencies.Get()
+
+	serv := application.NewRevokeApiKey(deps.ConfigRepository, deps.AuthorizationRepository)
+
+	cmd := application.RevokeApiKeyCommand{
+		ConfigId: c.Param("config_id"),
+		KeyId:    c.Param("key_id"),
+	}
+
+	res, err := serv.Exec(c.Request.Context(), &cmd)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	render(c, http.StatusOK, &res)
+}
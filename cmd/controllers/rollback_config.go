@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/gin-gonic/gin"
+)
+
+func RollbackConfig(c *gin.Context) {
+	deps := dependencies.Get()
+
+	serv := application.NewRollbackConfig(deps.SchemaRepository, deps.SchemaVersionRepository, deps.ConfigRepository, deps.RevisionRepository, deps.EventBus)
+
+	version, err := strconv.ParseUint(c.Param("version"), 10, 0)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	// Body is optional
+	var cmd application.RollbackConfigCommand
+	if c.Request.ContentLength > 0 {
+		if err := bindBody(c, &cmd); err != nil {
+			return
+		}
+	}
+
+	cmd.Id = c.Param("config_id")
+	cmd.Version = uint(version)
+
+	res, err := serv.Exec(c.Request.Context(), &cmd)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	render(c, http.StatusOK, &res)
+}
@@ -0,0 +1,34 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/gin-gonic/gin"
+)
+
+func RotateApiKey(c *gin.Context) {
+	deps := dependencies.Get()
+
+	serv := application.NewRotateApiKey(deps.ConfigRepository, deps.AuthorizationRepository)
+
+	// Body is optional
+	var cmd application.RotateApiKeyCommand
+	if c.Request.ContentLength > 0 {
+		if err := bindBody(c, &cmd); err != nil {
+			return
+		}
+	}
+
+	cmd.ConfigId = c.Param("config_id")
+	cmd.KeyId = c.Param("key_id")
+
+	res, err := serv.Exec(c.Request.Context(), &cmd)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	render(c, http.StatusOK, &res)
+}
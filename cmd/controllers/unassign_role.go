@@ -0,0 +1,28 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/gin-gonic/gin"
+)
+
+func UnassignRole(c *gin.Context) {
+	deps := dependencies.Get()
+
+	serv := application.NewUnassignRole(deps.RoleAssignmentRepository)
+
+	cmd := application.UnassignRoleCommand{
+		Username:     c.Param("username"),
+		AssignmentId: c.Param("assignment_id"),
+	}
+
+	res, err := serv.Exec(c.Request.Context(), &cmd)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	render(c, http.StatusOK, &res)
+}
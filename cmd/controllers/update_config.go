@@ -1,7 +1,6 @@
 package controllers
 
 import (
-	"context"
 	"net/http"
 
 	"github.com/aboglioli/configd/application"
@@ -12,22 +11,33 @@ import (
 func UpdateConfig(c *gin.Context) {
 	deps := dependencies.Get()
 
-	serv := application.NewUpdateConfig(deps.SchemaRepository, deps.ConfigRepository)
+	serv := application.NewUpdateConfig(deps.SchemaRepository, deps.SchemaVersionRepository, deps.ConfigRepository, deps.RevisionRepository, deps.EventBus)
 
 	var cmd application.UpdateConfigCommand
-	if err := c.BindJSON(&cmd); err != nil {
+	order, err := bindDocument(c, &cmd)
+	if err != nil {
 		return
 	}
 
+	cmd.KeyOrder = order.Sub("config")
+
 	cmd.Id = c.Param("config_id")
 
-	res, err := serv.Exec(context.Background(), &cmd)
+	// Body values take precedence over If-Match
+	version, configSum := parseIfMatch(c)
+	if cmd.ExpectedVersion == nil {
+		cmd.ExpectedVersion = version
+	}
+	if cmd.ExpectedConfigSum == nil {
+		cmd.ExpectedConfigSum = configSum
+	}
+
+	res, err := serv.Exec(c.Request.Context(), &cmd)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+		handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, &res)
+	setETag(c, res.Version)
+	render(c, http.StatusOK, &res)
 }
@@ -0,0 +1,30 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/gin-gonic/gin"
+)
+
+func UpdateRole(c *gin.Context) {
+	deps := dependencies.Get()
+
+	serv := application.NewUpdateRole(deps.RoleRepository)
+
+	var cmd application.UpdateRoleCommand
+	if err := bindBody(c, &cmd); err != nil {
+		return
+	}
+
+	cmd.RoleId = c.Param("role_id")
+
+	res, err := serv.Exec(c.Request.Context(), &cmd)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	render(c, http.StatusOK, &res)
+}
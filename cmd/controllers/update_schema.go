@@ -1,8 +1,8 @@
 package controllers
 
 import (
-	"context"
 	"net/http"
+	"strconv"
 
 	"github.com/aboglioli/configd/application"
 	"github.com/aboglioli/configd/cmd/dependencies"
@@ -12,22 +12,59 @@ import (
 func UpdateSchema(c *gin.Context) {
 	deps := dependencies.Get()
 
-	serv := application.NewUpdateSchema(deps.SchemaRepository)
+	serv := application.NewUpdateSchema(deps.SchemaRepository, deps.SchemaVersionRepository, deps.ConfigRepository, deps.RevisionRepository, deps.EventBus)
 
 	var cmd application.UpdateSchemaCommand
-	if err := c.BindJSON(&cmd); err != nil {
+	order, err := bindDocument(c, &cmd)
+	if err != nil {
 		return
 	}
 
+	cmd.KeyOrder = order.Sub("schema")
+
 	cmd.Id = c.Param("schema_id")
 
-	res, err := serv.Exec(context.Background(), &cmd)
+	// Body value takes precedence over the query param
+	if !cmd.Force {
+		force, err := strconv.ParseBool(c.DefaultQuery("force", "false"))
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		cmd.Force = force
+	}
+
+	if !cmd.DryRun {
+		dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		cmd.DryRun = dryRun
+	}
+
+	// Body values take precedence over If-Match
+	if cmd.ExpectedVersion == nil {
+		version, err := parseIfMatchVersion(c)
+		if err != nil {
+			handleError(c, err)
+			return
+		}
+
+		cmd.ExpectedVersion = version
+	}
+
+	res, err := serv.Exec(c.Request.Context(), &cmd)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+		handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, &res)
+	// Nothing was saved in a dry run
+	if !res.DryRun {
+		setETag(c, res.Version)
+	}
+	render(c, http.StatusOK, &res)
 }
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	WATCH_DEFAULT_TIMEOUT    = 30 * time.Second
+	WATCH_MAX_TIMEOUT        = 120 * time.Second
+	WATCH_HEARTBEAT_INTERVAL = 15 * time.Second
+)
+
+// WatchConfig streams config changes as Server-Sent Events when the client
+// accepts text/event-stream. Otherwise it long-polls until the config hash
+// differs from ?since= or the ?timeout= (in seconds) expires.
+func WatchConfig(c *gin.Context) {
+	deps := dependencies.Get()
+
+	serv := application.NewWatchConfig(deps.ConfigRepository, deps.AuthorizationRepository, deps.EventBus)
+
+	cmd := application.WatchConfigCommand{
+		Id:     c.Param("config_id"),
+		ApiKey: getApiKey(c),
+		Since:  c.Query("since"),
+	}
+
+	if strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+		streamConfig(c, serv, &cmd)
+	} else {
+		pollConfig(c, serv, &cmd)
+	}
+}
+
+func streamConfig(c *gin.Context, serv *application.WatchConfig, cmd *application.WatchConfigCommand) {
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	changes, err := serv.Exec(ctx, cmd)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	heartbeat := time.NewTicker(WATCH_HEARTBEAT_INTERVAL)
+	defer heartbeat.Stop()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case res, ok := <-changes:
+			if !ok {
+				return false
+			}
+
+			c.SSEvent("config", res)
+		case <-heartbeat.C:
+			c.SSEvent("heartbeat", time.Now().Unix())
+		}
+
+		return true
+	})
+}
+
+func pollConfig(c *gin.Context, serv *application.WatchConfig, cmd *application.WatchConfigCommand) {
+	timeout := WATCH_DEFAULT_TIMEOUT
+	if t, ok := c.GetQuery("timeout"); ok {
+		secs, err := strconv.Atoi(t)
+		if err != nil || secs < 0 {
+			render(c, http.StatusBadRequest, gin.H{
+				"error": "invalid timeout",
+			})
+			return
+		}
+
+		timeout = time.Duration(secs) * time.Second
+		if timeout > WATCH_MAX_TIMEOUT {
+			timeout = WATCH_MAX_TIMEOUT
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+	defer cancel()
+
+	changes, err := serv.Exec(ctx, cmd)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	res, ok := <-changes
+	if !ok {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	render(c, http.StatusOK, &res)
+}
@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPollConfig(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	deps := dependencies.Get()
+	ctx := user.NewSystemContext(context.Background())
+
+	schemaId := "watched-service"
+	_, err := application.NewCreateSchema(
+		deps.SchemaRepository,
+		deps.SchemaVersionRepository,
+		deps.EventBus,
+	).Exec(ctx, &application.CreateSchemaCommand{
+		Id:   &schemaId,
+		Name: schemaId,
+		Schema: map[string]interface{}{
+			"port": map[string]interface{}{
+				"$schema": map[string]interface{}{
+					"type": "integer",
+				},
+			},
+		},
+	})
+	utils.Ok(err)
+
+	configId := "watched-development"
+	created, err := application.NewCreateConfig(
+		deps.SchemaRepository,
+		deps.SchemaVersionRepository,
+		deps.ConfigRepository,
+		deps.RevisionRepository,
+		deps.AuthorizationRepository,
+		deps.EventBus,
+	).Exec(ctx, &application.CreateConfigCommand{
+		Id:       &configId,
+		SchemaId: schemaId,
+		Name:     configId,
+		Config:   config.ConfigData{"port": 80},
+	})
+	utils.Ok(err)
+
+	r := gin.New()
+	r.GET("/config/:config_id/watch", WatchConfig)
+
+	poll := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/config/"+configId+"/watch?"+query, nil)
+		req.Header.Set("X-Api-Key", created.ApiKey)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		return w
+	}
+
+	t.Run("stale since returns the config", func(t *testing.T) {
+		w := poll("since=stale&timeout=1")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var res application.WatchConfigResponse
+		utils.Ok(json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Equal(t, created.ConfigSum, res.ConfigSum)
+	})
+
+	t.Run("current since times out as not modified", func(t *testing.T) {
+		w := poll("since=" + created.ConfigSum + "&timeout=1")
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+	})
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		w := poll("since=" + created.ConfigSum + "&timeout=soon")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+}
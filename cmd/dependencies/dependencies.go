@@ -1,32 +1,101 @@
 package dependencies
 
 import (
+	"fmt"
+	"os"
 	"sync"
 
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/domain/security"
+	"github.com/aboglioli/configd/domain/user"
 	"github.com/aboglioli/configd/infrastructure"
+	"github.com/aboglioli/configd/pkg/utils"
+)
+
+const (
+	INMEM_DATABASE    = "inmem"
+	SQLITE_DATABASE   = "sqlite"
+	POSTGRES_DATABASE = "postgres"
+
+	DEFAULT_SQLITE_PATH = "configd.db"
 )
 
 var once sync.Once
 var deps *Dependencies
 
 type Dependencies struct {
-	EventBus                *infrastructure.InMemEventBus
-	SchemaRepository        *infrastructure.InMemSchemaRepository
-	ConfigRepository        *infrastructure.InMemConfigRepository
-	AuthorizationRepository *infrastructure.InMemAuthorizationRepository
-	UserRepository          *infrastructure.InMemUserRepository
+	EventBus                 *infrastructure.InMemEventBus
+	SchemaRepository         schema.SchemaRepository
+	SchemaVersionRepository  schema.VersionRepository
+	ConfigRepository         config.ConfigRepository
+	RevisionRepository       config.RevisionRepository
+	AuthorizationRepository  security.AuthorizationRepository
+	UserRepository           user.UserRepository
+	SessionRepository        user.SessionRepository
+	RoleRepository           user.RoleRepository
+	RoleAssignmentRepository user.RoleAssignmentRepository
+	TokenIssuer              *user.TokenIssuer
 }
 
+// Get builds dependencies once. The repository backend is selected with the
+// CONFIGD_DATABASE environment variable (inmem by default).
 func Get() *Dependencies {
 	once.Do(func() {
 		deps = &Dependencies{
-			EventBus:                infrastructure.NewInMemEventBus(),
-			SchemaRepository:        infrastructure.NewInMemSchemaRepository(),
-			ConfigRepository:        infrastructure.NewInMemConfigRepository(),
-			AuthorizationRepository: infrastructure.NewInMemAuthorizationRepository(),
-			UserRepository:          infrastructure.NewInMemUserRepository(),
+			EventBus:    infrastructure.NewInMemEventBus(),
+			TokenIssuer: tokenIssuer(),
+		}
+
+		switch database := getEnv("CONFIGD_DATABASE", INMEM_DATABASE); database {
+		case INMEM_DATABASE:
+			deps.SchemaRepository = infrastructure.NewInMemSchemaRepository()
+			deps.SchemaVersionRepository = infrastructure.NewInMemSchemaVersionRepository()
+			deps.ConfigRepository = infrastructure.NewInMemConfigRepository()
+			deps.RevisionRepository = infrastructure.NewInMemRevisionRepository()
+			deps.AuthorizationRepository = infrastructure.NewInMemAuthorizationRepository()
+			deps.UserRepository = infrastructure.NewInMemUserRepository()
+			deps.SessionRepository = infrastructure.NewInMemSessionRepository()
+			deps.RoleRepository = infrastructure.NewInMemRoleRepository()
+			deps.RoleAssignmentRepository = infrastructure.NewInMemRoleAssignmentRepository()
+		case SQLITE_DATABASE:
+			db, err := infrastructure.OpenSqlite(getEnv("CONFIGD_SQLITE_PATH", DEFAULT_SQLITE_PATH))
+			utils.Ok(err)
+
+			deps.SchemaRepository = infrastructure.NewSqliteSchemaRepository(db)
+			deps.SchemaVersionRepository = infrastructure.NewSqliteSchemaVersionRepository(db)
+			deps.ConfigRepository = infrastructure.NewSqliteConfigRepository(db)
+			deps.RevisionRepository = infrastructure.NewSqliteRevisionRepository(db)
+			deps.AuthorizationRepository = infrastructure.NewSqliteAuthorizationRepository(db)
+			deps.UserRepository = infrastructure.NewSqliteUserRepository(db)
+			deps.SessionRepository = infrastructure.NewSqliteSessionRepository(db)
+			deps.RoleRepository = infrastructure.NewSqliteRoleRepository(db)
+			deps.RoleAssignmentRepository = infrastructure.NewSqliteRoleAssignmentRepository(db)
+		case POSTGRES_DATABASE:
+			db, err := infrastructure.OpenPostgres(os.Getenv("CONFIGD_POSTGRES_URL"))
+			utils.Ok(err)
+
+			deps.SchemaRepository = infrastructure.NewPostgresSchemaRepository(db)
+			deps.SchemaVersionRepository = infrastructure.NewPostgresSchemaVersionRepository(db)
+			deps.ConfigRepository = infrastructure.NewPostgresConfigRepository(db)
+			deps.RevisionRepository = infrastructure.NewPostgresRevisionRepository(db)
+			deps.AuthorizationRepository = infrastructure.NewPostgresAuthorizationRepository(db)
+			deps.UserRepository = infrastructure.NewPostgresUserRepository(db)
+			deps.SessionRepository = infrastructure.NewPostgresSessionRepository(db)
+			deps.RoleRepository = infrastructure.NewPostgresRoleRepository(db)
+			deps.RoleAssignmentRepository = infrastructure.NewPostgresRoleAssignmentRepository(db)
+		default:
+			panic(fmt.Sprintf("invalid database %s", database))
 		}
 	})
 
 	return deps
 }
+
+func getEnv(key, def string) string {
+	if v, ok := os.LookupEnv(key); ok && v != "" {
+		return v
+	}
+
+	return def
+}
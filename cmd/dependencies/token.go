@@ -0,0 +1,118 @@
+package dependencies
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/utils"
+)
+
+const (
+	DEFAULT_SIGNING_KEY_ID = "default"
+	DEFAULT_ACCESS_TTL     = 15 * time.Minute
+	DEFAULT_REFRESH_TTL    = 7 * 24 * time.Hour
+)
+
+// tokenIssuer builds the access token issuer from the environment:
+//
+//   - CONFIGD_JWT_SIGNING_KEY signs tokens, given as kid:algorithm:path to the
+//     HS256 secret or the RS256/EdDSA PEM private key.
+//   - CONFIGD_JWT_SECRET signs tokens with HS256 when no signing key is set.
+//   - CONFIGD_JWT_VERIFY_KEYS lists comma separated keys, in the same format,
+//     still accepted to verify tokens after rotating the signing key.
+//   - CONFIGD_JWT_ACCESS_TTL and CONFIGD_JWT_REFRESH_TTL set how long access
+//     tokens and sessions last.
+//
+// Without signing key a random secret is generated, tokens do not survive
+// restarts.
+func tokenIssuer() *user.TokenIssuer {
+	var signingKey *user.SigningKey
+
+	if spec := os.Getenv("CONFIGD_JWT_SIGNING_KEY"); spec != "" {
+		k, err := parseSigningKey(spec)
+		utils.Ok(err)
+
+		signingKey = k
+	} else if secret := os.Getenv("CONFIGD_JWT_SECRET"); secret != "" {
+		k, err := user.NewSigningKey(DEFAULT_SIGNING_KEY_ID, user.HS256_ALGORITHM, []byte(secret))
+		utils.Ok(err)
+
+		signingKey = k
+	} else {
+		log.Printf("token: CONFIGD_JWT_SIGNING_KEY and CONFIGD_JWT_SECRET not set, using a random secret")
+
+		secret := make([]byte, user.MIN_HMAC_SECRET_LENGTH)
+		_, err := rand.Read(secret)
+		utils.Ok(err)
+
+		k, err := user.NewSigningKey(DEFAULT_SIGNING_KEY_ID, user.HS256_ALGORITHM, secret)
+		utils.Ok(err)
+
+		signingKey = k
+	}
+
+	verifyKeys := make([]*user.SigningKey, 0)
+	for _, spec := range strings.Split(os.Getenv("CONFIGD_JWT_VERIFY_KEYS"), ",") {
+		if spec = strings.TrimSpace(spec); spec == "" {
+			continue
+		}
+
+		k, err := parseSigningKey(spec)
+		utils.Ok(err)
+
+		verifyKeys = append(verifyKeys, k)
+	}
+
+	issuer, err := user.NewTokenIssuer(
+		signingKey,
+		verifyKeys,
+		durationFromEnv("CONFIGD_JWT_ACCESS_TTL", DEFAULT_ACCESS_TTL),
+		durationFromEnv("CONFIGD_JWT_REFRESH_TTL", DEFAULT_REFRESH_TTL),
+	)
+	utils.Ok(err)
+
+	return issuer
+}
+
+// parseSigningKey reads a key given as kid:algorithm:path.
+func parseSigningKey(spec string) (*user.SigningKey, error) {
+	parts := strings.SplitN(spec, ":", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid signing key %s, expected kid:algorithm:path", spec)
+	}
+
+	algorithm, err := user.NewSigningAlgorithm(parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	material, err := ioutil.ReadFile(parts[2])
+	if err != nil {
+		return nil, err
+	}
+
+	// Secrets written with a trailing newline
+	if algorithm == user.HS256_ALGORITHM {
+		material = []byte(strings.TrimSpace(string(material)))
+	}
+
+	return user.NewSigningKey(parts[0], algorithm, material)
+}
+
+func durationFromEnv(key string, def time.Duration) time.Duration {
+	v := getEnv(key, "")
+	if v == "" {
+		return def
+	}
+
+	d, err := time.ParseDuration(v)
+	utils.Ok(err)
+
+	return d
+}
@@ -1,28 +1,68 @@
 package main
 
 import (
+	"context"
+
 	"github.com/aboglioli/configd/cmd/controllers"
 	"github.com/gin-gonic/gin"
 )
 
 func main() {
 	s := gin.Default()
+	s.Use(controllers.Authenticate)
+
+	startPurge(context.Background())
+	createAdmin(context.Background())
+
+	// Configs are also read by services with their API keys, other endpoints
+	// require users. Their roles are checked by each use case
+	auth := controllers.RequireUser
 
 	// Schema
-	s.GET("/schema/:schema_id", controllers.GetSchema)
-	s.POST("/schema", controllers.CreateSchema)
-	s.PUT("/schema/:schema_id", controllers.UpdateSchema)
-	s.DELETE("/schema/:schema_id", controllers.DeleteSchema)
+	s.GET("/schema", auth, controllers.ListSchemas)
+	s.GET("/schema/:schema_id", auth, controllers.GetSchema)
+	s.POST("/schema", auth, controllers.CreateSchema)
+	s.PUT("/schema/:schema_id", auth, controllers.UpdateSchema)
+	s.DELETE("/schema/:schema_id", auth, controllers.DeleteSchema)
+	s.POST("/schema/:schema_id/restore", auth, controllers.RestoreSchema)
+	s.GET("/schema/:schema_id/versions", auth, controllers.GetSchemaVersions)
+	s.GET("/schema/:schema_id/versions/:version", auth, controllers.GetSchemaVersion)
 
 	// Config
+	s.GET("/config", auth, controllers.ListConfigs)
+	s.GET("/config/diff", controllers.DiffConfigs)
 	s.GET("/config/:config_id", controllers.GetConfig)
-	s.POST("/config", controllers.CreateConfig)
-	s.PUT("/config/:config_id", controllers.UpdateConfig)
-	s.DELETE("/config/:config_id", controllers.DeleteConfig)
+	s.GET("/config/:config_id/watch", controllers.WatchConfig)
+	s.GET("/config/:config_id/versions", controllers.GetConfigVersions)
+	s.GET("/config/:config_id/versions/:version", controllers.GetConfigVersion)
+	s.POST("/config/:config_id/versions/:version/rollback", auth, controllers.RollbackConfig)
+	s.GET("/config/:config_id/diff", controllers.DiffConfigVersions)
+	s.POST("/config", auth, controllers.CreateConfig)
+	s.PUT("/config/:config_id", auth, controllers.UpdateConfig)
+	s.DELETE("/config/:config_id", auth, controllers.DeleteConfig)
+	s.POST("/config/:config_id/restore", auth, controllers.RestoreConfig)
+	s.GET("/config/:config_id/keys", auth, controllers.GetApiKeys)
+	s.POST("/config/:config_id/keys", auth, controllers.CreateApiKey)
+	s.DELETE("/config/:config_id/keys/:key_id", auth, controllers.RevokeApiKey)
+	s.POST("/config/:config_id/keys/:key_id/rotate", auth, controllers.RotateApiKey)
+
+	// Soft-deleted configs and schemas
+	s.GET("/deleted", auth, controllers.GetDeleted)
 
 	// User
 	s.POST("/login", controllers.LoginUser)
-	s.POST("/user", controllers.RegisterUser)
+	s.POST("/logout", auth, controllers.LogoutUser)
+	s.POST("/token/refresh", controllers.RefreshToken)
+	s.POST("/user", auth, controllers.RegisterUser)
+	s.GET("/user/:username/roles", auth, controllers.GetUserRoles)
+	s.POST("/user/:username/roles", auth, controllers.AssignRole)
+	s.DELETE("/user/:username/roles/:assignment_id", auth, controllers.UnassignRole)
+
+	// Role
+	s.GET("/role", auth, controllers.ListRoles)
+	s.POST("/role", auth, controllers.CreateRole)
+	s.PUT("/role/:role_id", auth, controllers.UpdateRole)
+	s.DELETE("/role/:role_id", auth, controllers.DeleteRole)
 
 	s.Run(":8080")
 }
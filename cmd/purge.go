@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/aboglioli/configd/application"
+	"github.com/aboglioli/configd/cmd/dependencies"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/utils"
+)
+
+const (
+	DEFAULT_PURGE_INTERVAL  = time.Hour
+	DEFAULT_PURGE_RETENTION = 30 * 24 * time.Hour
+)
+
+// startPurge periodically removes soft-deleted configs and schemas once they
+// are older than the retention window (CONFIGD_PURGE_RETENTION).
+func startPurge(ctx context.Context) {
+	interval := durationFromEnv("CONFIGD_PURGE_INTERVAL", DEFAULT_PURGE_INTERVAL)
+	retention := durationFromEnv("CONFIGD_PURGE_RETENTION", DEFAULT_PURGE_RETENTION)
+
+	deps := dependencies.Get()
+
+	serv := application.NewPurgeDeleted(
+		deps.SchemaRepository,
+		deps.SchemaVersionRepository,
+		deps.ConfigRepository,
+		deps.RevisionRepository,
+		deps.AuthorizationRepository,
+	)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			res, err := serv.Exec(user.NewSystemContext(ctx), &application.PurgeDeletedCommand{
+				Retention: retention,
+			})
+			if err != nil {
+				log.Printf("purge: %s", err)
+			} else if len(res.Configs) > 0 || len(res.Schemas) > 0 {
+				log.Printf("purge: removed configs %v and schemas %v", res.Configs, res.Schemas)
+			}
+
+			// Sessions logged out or expired no longer authenticate anyone
+			if err := deps.SessionRepository.DeleteInactive(ctx, time.Now()); err != nil {
+				log.Printf("purge: %s", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func durationFromEnv(key string, def time.Duration) time.Duration {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def
+	}
+
+	d, err := time.ParseDuration(v)
+	utils.Ok(err)
+
+	return d
+}
@@ -2,7 +2,9 @@ package config
 
 import (
 	"errors"
+	"fmt"
 
+	"github.com/aboglioli/configd/pkg/codec"
 	"github.com/aboglioli/configd/pkg/events"
 	"github.com/aboglioli/configd/pkg/models"
 )
@@ -11,41 +13,50 @@ type Config struct {
 	agg *models.AggregateRoot
 
 	schemaId models.Id
-	name     Name
-	config   ConfigData
+	// Pinned schema version, zero tracks the latest one
+	schemaVersion uint
+	name          Name
+	config        ConfigData
+	// Order of the keys as written, to render them the same way
+	keyOrder codec.KeyOrder
 }
 
 func BuildConfig(
-	id models.Id,
+	agg *models.AggregateRoot,
 	schemaId models.Id,
+	schemaVersion uint,
 	name Name,
 	config ConfigData,
+	keyOrder codec.KeyOrder,
 ) (*Config, error) {
 	if len(config) == 0 {
 		return nil, errors.New("empty configuration")
 	}
 
-	agg, err := models.NewAggregateRoot(id)
-	if err != nil {
-		return nil, err
-	}
-
 	return &Config{
-		agg:      agg,
-		schemaId: schemaId,
-		name:     name,
-		config:   config,
+		agg:           agg,
+		schemaId:      schemaId,
+		schemaVersion: schemaVersion,
+		name:          name,
+		config:        config,
+		keyOrder:      keyOrder,
 	}, nil
-
 }
 
 func NewConfig(
 	id models.Id,
 	schemaId models.Id,
+	schemaVersion uint,
 	name Name,
 	config ConfigData,
+	keyOrder codec.KeyOrder,
 ) (*Config, error) {
-	c, err := BuildConfig(id, schemaId, name, config)
+	agg, err := models.NewAggregateRoot(id)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := BuildConfig(agg, schemaId, schemaVersion, name, config, keyOrder)
 	if err != nil {
 		return nil, err
 	}
@@ -54,11 +65,12 @@ func NewConfig(
 		c.agg.Id().Value(),
 		ConfigCreatedTopic,
 		ConfigCreated{
-			Id:        c.agg.Id().Value(),
-			SchemaId:  c.schemaId.Value(),
-			Name:      c.name.Value(),
-			Config:    c.config,
-			ConfigSum: c.config.Hash(),
+			Id:            c.agg.Id().Value(),
+			SchemaId:      c.schemaId.Value(),
+			SchemaVersion: c.schemaVersion,
+			Name:          c.name.Value(),
+			Config:        c.config,
+			ConfigSum:     c.config.Hash(),
 		},
 	)
 	if err != nil {
@@ -74,15 +86,56 @@ func (c *Config) Base() models.ReadOnlyAggregateRoot {
 	return c.agg
 }
 
+func (c *Config) ClearEvents() {
+	c.agg.ClearEvents()
+}
+
 func (c *Config) SchemaId() models.Id {
 	return c.schemaId
 }
 
+func (c *Config) SchemaVersion() uint {
+	return c.schemaVersion
+}
+
+// ChangeSchema moves the config to another schema or schema version. Version
+// zero tracks the latest one.
+func (c *Config) ChangeSchema(schemaId models.Id, schemaVersion uint) error {
+	if c.schemaId.Equals(schemaId) && c.schemaVersion == schemaVersion {
+		return nil
+	}
+
+	c.schemaId = schemaId
+	c.schemaVersion = schemaVersion
+	c.agg.Update()
+
+	event, err := events.NewEvent(
+		c.agg.Id().Value(),
+		ConfigSchemaChangedTopic,
+		ConfigSchemaChanged{
+			Id:            c.agg.Id().Value(),
+			SchemaId:      c.schemaId.Value(),
+			SchemaVersion: c.schemaVersion,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	c.agg.RecordEvent(event)
+
+	return nil
+}
+
 func (c *Config) Name() Name {
 	return c.name
 }
 
 func (c *Config) ChangeName(name Name) error {
+	if c.name.Equals(name) {
+		return nil
+	}
+
 	c.name = name
 	c.agg.Update()
 
@@ -107,8 +160,21 @@ func (c *Config) Config() ConfigData {
 	return c.config
 }
 
-func (c *Config) ChangeConfig(config ConfigData) error {
+func (c *Config) KeyOrder() codec.KeyOrder {
+	return c.keyOrder
+}
+
+// ChangeConfig replaces the config data along with the order of its keys.
+// Reordering keys alone is not a change.
+func (c *Config) ChangeConfig(config ConfigData, keyOrder codec.KeyOrder) error {
+	if c.config.Hash() == config.Hash() {
+		return nil
+	}
+
+	diff := Diff(c.config, config)
+
 	c.config = config
+	c.keyOrder = keyOrder
 	c.agg.Update()
 
 	event, err := events.NewEvent(
@@ -117,7 +183,51 @@ func (c *Config) ChangeConfig(config ConfigData) error {
 		ConfigConfigChanged{
 			Id:        c.agg.Id().Value(),
 			Config:    c.config,
+			KeyOrder:  c.keyOrder,
 			ConfigSum: c.config.Hash(),
+			Diff:      diff,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	c.agg.RecordEvent(event)
+
+	return nil
+}
+
+func (c *Config) Delete() error {
+	if err := c.agg.Delete(); err != nil {
+		return err
+	}
+
+	event, err := events.NewEvent(
+		c.agg.Id().Value(),
+		ConfigDeletedTopic,
+		ConfigDeleted{
+			Id: c.agg.Id().Value(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	c.agg.RecordEvent(event)
+
+	return nil
+}
+
+func (c *Config) Restore() error {
+	if err := c.agg.Restore(); err != nil {
+		return err
+	}
+
+	event, err := events.NewEvent(
+		c.agg.Id().Value(),
+		ConfigRestoredTopic,
+		ConfigRestored{
+			Id: c.agg.Id().Value(),
 		},
 	)
 	if err != nil {
@@ -128,3 +238,16 @@ func (c *Config) ChangeConfig(config ConfigData) error {
 
 	return nil
 }
+
+// Rollback restores the data of a previous revision as a new version.
+func (c *Config) Rollback(revision *Revision) error {
+	if !revision.ConfigId().Equals(c.agg.Id()) {
+		return errors.New("revision belongs to another config")
+	}
+
+	if revision.Version() >= c.agg.Version() {
+		return fmt.Errorf("cannot rollback to version %d from version %d", revision.Version(), c.agg.Version())
+	}
+
+	return c.ChangeConfig(revision.Config(), revision.KeyOrder())
+}
@@ -11,9 +11,16 @@ var (
 	ErrNotFound = errors.New("config not found")
 )
 
+// ConfigRepository finders skip soft-deleted configs except for the
+// FindDeleted* ones.
 type ConfigRepository interface {
 	FindById(ctx context.Context, id models.Id) (*Config, error)
 	FindBySchemaId(ctx context.Context, schemaId models.Id) ([]*Config, error)
+	FindDeletedById(ctx context.Context, id models.Id) (*Config, error)
+	FindDeleted(ctx context.Context) ([]*Config, error)
+	// Find returns up to Pagination.Limit configs after the cursor.
+	Find(ctx context.Context, criteria Criteria) ([]*Config, error)
 	Save(ctx context.Context, config *Config) error
+	// Delete removes the config permanently.
 	Delete(ctx context.Context, id models.Id) error
 }
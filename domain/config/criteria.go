@@ -0,0 +1,13 @@
+package config
+
+import (
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+// Criteria filters configs. Zero values match everything.
+type Criteria struct {
+	SchemaId *models.Id
+	// Case-insensitive substring of the name
+	Name       string
+	Pagination models.Pagination
+}
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/aboglioli/configd/pkg/utils"
+)
+
+type ChangeOp string
+
+const (
+	ADDED_CHANGE    ChangeOp = "added"
+	REMOVED_CHANGE  ChangeOp = "removed"
+	MODIFIED_CHANGE ChangeOp = "modified"
+)
+
+// Change is a difference between two configs for the value located at Path,
+// a JSON pointer.
+type Change struct {
+	Path string      `json:"path"`
+	Op   ChangeOp    `json:"op"`
+	From interface{} `json:"from,omitempty"`
+	To   interface{} `json:"to,omitempty"`
+}
+
+// Diff returns the structural changes needed to go from one config to the
+// other. Objects are compared by key and arrays by index.
+func Diff(from, to ConfigData) []Change {
+	return diffObjects("", from, to)
+}
+
+func diffObjects(path string, from, to map[string]interface{}) []Change {
+	keys := make([]string, 0, len(from)+len(to))
+	for k := range from {
+		keys = append(keys, k)
+	}
+	for k := range to {
+		if _, ok := from[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	changes := make([]Change, 0)
+	for _, k := range keys {
+		fromValue, inFrom := from[k]
+		toValue, inTo := to[k]
+
+		keyPath := utils.JoinPointer(path, k)
+
+		switch {
+		case !inTo:
+			changes = append(changes, Change{Path: keyPath, Op: REMOVED_CHANGE, From: fromValue})
+		case !inFrom:
+			changes = append(changes, Change{Path: keyPath, Op: ADDED_CHANGE, To: toValue})
+		default:
+			changes = append(changes, diffValues(keyPath, fromValue, toValue)...)
+		}
+	}
+
+	return changes
+}
+
+func diffArrays(path string, from, to []interface{}) []Change {
+	changes := make([]Change, 0)
+
+	for i := 0; i < len(from) || i < len(to); i++ {
+		itemPath := utils.JoinPointer(path, i)
+
+		switch {
+		case i >= len(to):
+			changes = append(changes, Change{Path: itemPath, Op: REMOVED_CHANGE, From: from[i]})
+		case i >= len(from):
+			changes = append(changes, Change{Path: itemPath, Op: ADDED_CHANGE, To: to[i]})
+		default:
+			changes = append(changes, diffValues(itemPath, from[i], to[i])...)
+		}
+	}
+
+	return changes
+}
+
+func diffValues(path string, from, to interface{}) []Change {
+	fromObj, fromIsObj := toObject(from)
+	toObj, toIsObj := toObject(to)
+	if fromIsObj && toIsObj {
+		return diffObjects(path, fromObj, toObj)
+	}
+
+	fromArr, fromIsArr := from.([]interface{})
+	toArr, toIsArr := to.([]interface{})
+	if fromIsArr && toIsArr {
+		return diffArrays(path, fromArr, toArr)
+	}
+
+	if equalValues(from, to) {
+		return nil
+	}
+
+	return []Change{{Path: path, Op: MODIFIED_CHANGE, From: from, To: to}}
+}
+
+func toObject(v interface{}) (map[string]interface{}, bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return v, true
+	case ConfigData:
+		return v, true
+	}
+
+	return nil, false
+}
+
+// equalValues compares scalars. Numbers decoded from JSON are float64 while
+// configs built in code may hold integers.
+func equalValues(a, b interface{}) bool {
+	if an, ok := toNumber(a); ok {
+		if bn, ok := toNumber(b); ok {
+			return an == bn
+		}
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func toNumber(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/aboglioli/configd/pkg/models"
+	"github.com/aboglioli/configd/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	type test struct {
+		name     string
+		from     ConfigData
+		to       ConfigData
+		expected []Change
+	}
+
+	tests := []test{
+		{
+			name:     "equal",
+			from:     ConfigData{"port": 8080, "host": "localhost"},
+			to:       ConfigData{"port": float64(8080), "host": "localhost"},
+			expected: []Change{},
+		},
+		{
+			name: "added, removed and modified keys",
+			from: ConfigData{"port": 8080, "host": "localhost", "debug": true},
+			to:   ConfigData{"port": 9090, "host": "localhost", "timeout": 30},
+			expected: []Change{
+				{Path: "/debug", Op: REMOVED_CHANGE, From: true},
+				{Path: "/port", Op: MODIFIED_CHANGE, From: 8080, To: 9090},
+				{Path: "/timeout", Op: ADDED_CHANGE, To: 30},
+			},
+		},
+		{
+			name: "nested objects",
+			from: ConfigData{
+				"db": map[string]interface{}{
+					"host": "localhost",
+					"pool": map[string]interface{}{"size": 10},
+				},
+			},
+			to: ConfigData{
+				"db": map[string]interface{}{
+					"host": "db.internal",
+					"pool": map[string]interface{}{"size": 10, "idle": 2},
+				},
+			},
+			expected: []Change{
+				{Path: "/db/host", Op: MODIFIED_CHANGE, From: "localhost", To: "db.internal"},
+				{Path: "/db/pool/idle", Op: ADDED_CHANGE, To: 2},
+			},
+		},
+		{
+			name: "array elements",
+			from: ConfigData{"envs": []interface{}{"dev", "stg", "prod"}},
+			to:   ConfigData{"envs": []interface{}{"dev", "qa"}},
+			expected: []Change{
+				{Path: "/envs/1", Op: MODIFIED_CHANGE, From: "stg", To: "qa"},
+				{Path: "/envs/2", Op: REMOVED_CHANGE, From: "prod"},
+			},
+		},
+		{
+			name: "objects inside arrays",
+			from: ConfigData{"users": []interface{}{map[string]interface{}{"name": "admin"}}},
+			to: ConfigData{"users": []interface{}{
+				map[string]interface{}{"name": "root"},
+				map[string]interface{}{"name": "guest"},
+			}},
+			expected: []Change{
+				{Path: "/users/0/name", Op: MODIFIED_CHANGE, From: "admin", To: "root"},
+				{Path: "/users/1", Op: ADDED_CHANGE, To: map[string]interface{}{"name": "guest"}},
+			},
+		},
+		{
+			name: "type change",
+			from: ConfigData{"value": map[string]interface{}{"a": 1}},
+			to:   ConfigData{"value": []interface{}{1}},
+			expected: []Change{
+				{
+					Path: "/value",
+					Op:   MODIFIED_CHANGE,
+					From: map[string]interface{}{"a": 1},
+					To:   []interface{}{1},
+				},
+			},
+		},
+		{
+			name: "escaped keys",
+			from: ConfigData{"a/b": 1},
+			to:   ConfigData{"a/b": 2},
+			expected: []Change{
+				{Path: "/a~1b", Op: MODIFIED_CHANGE, From: 1, To: 2},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Diff(test.from, test.to))
+		})
+	}
+}
+
+func TestChangeConfigRecordsDiff(t *testing.T) {
+	id, err := models.BuildId("config")
+	utils.Ok(err)
+
+	name, err := NewName("Config")
+	utils.Ok(err)
+
+	c, err := NewConfig(id, id, 0, name, ConfigData{"port": 8080}, nil)
+	utils.Ok(err)
+	c.ClearEvents()
+
+	utils.Ok(c.ChangeConfig(ConfigData{"port": 9090}, nil))
+
+	if assert.Len(t, c.Base().Events(), 1) {
+		payload, ok := c.Base().Events()[0].Payload().(ConfigConfigChanged)
+		if assert.True(t, ok) {
+			assert.Equal(t, []Change{
+				{Path: "/port", Op: MODIFIED_CHANGE, From: 8080, To: 9090},
+			}, payload.Diff)
+		}
+	}
+}
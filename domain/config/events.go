@@ -7,15 +7,19 @@ import (
 var (
 	ConfigCreatedTopic       = events.NewTopic("config", "created")
 	ConfigNameChangedTopic   = events.NewTopic("config", "name_changed")
+	ConfigSchemaChangedTopic = events.NewTopic("config", "schema_changed")
 	ConfigConfigChangedTopic = events.NewTopic("config", "config_changed")
+	ConfigDeletedTopic       = events.NewTopic("config", "deleted")
+	ConfigRestoredTopic      = events.NewTopic("config", "restored")
 )
 
 type ConfigCreated struct {
-	Id        string                 `json:"id"`
-	SchemaId  string                 `json:"schema_id"`
-	Name      string                 `json:"name"`
-	Config    map[string]interface{} `json:"config"`
-	ConfigSum string                 `json:"config_sum"`
+	Id            string                 `json:"id"`
+	SchemaId      string                 `json:"schema_id"`
+	SchemaVersion uint                   `json:"schema_version"`
+	Name          string                 `json:"name"`
+	Config        map[string]interface{} `json:"config"`
+	ConfigSum     string                 `json:"config_sum"`
 }
 
 type ConfigNameChanged struct {
@@ -23,8 +27,24 @@ type ConfigNameChanged struct {
 	Name string `json:"name"`
 }
 
+type ConfigSchemaChanged struct {
+	Id            string `json:"id"`
+	SchemaId      string `json:"schema_id"`
+	SchemaVersion uint   `json:"schema_version"`
+}
+
 type ConfigConfigChanged struct {
 	Id        string                 `json:"id"`
 	Config    map[string]interface{} `json:"config"`
+	KeyOrder  []string               `json:"key_order"`
 	ConfigSum string                 `json:"config_sum"`
+	Diff      []Change               `json:"diff"`
+}
+
+type ConfigDeleted struct {
+	Id string `json:"id"`
+}
+
+type ConfigRestored struct {
+	Id string `json:"id"`
 }
@@ -0,0 +1,355 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aboglioli/configd/domain/props"
+)
+
+// FlatFormat is a format of key/value pairs, one per line.
+type FlatFormat string
+
+const (
+	// KEY=value, values taken literally as in docker --env-file
+	ENV_FLAT_FORMAT FlatFormat = "env"
+	// KEY="value", double quoted values with escapes
+	DOTENV_FLAT_FORMAT FlatFormat = "dotenv"
+	// Java properties, key=value with \uXXXX escapes
+	PROPERTIES_FLAT_FORMAT FlatFormat = "properties"
+)
+
+func NewFlatFormat(format string) (FlatFormat, error) {
+	switch format {
+	case string(ENV_FLAT_FORMAT):
+		return ENV_FLAT_FORMAT, nil
+	case string(DOTENV_FLAT_FORMAT):
+		return DOTENV_FLAT_FORMAT, nil
+	case string(PROPERTIES_FLAT_FORMAT):
+		return PROPERTIES_FLAT_FORMAT, nil
+	}
+
+	return "", fmt.Errorf("invalid flat format %s", format)
+}
+
+func (f FlatFormat) String() string {
+	return string(f)
+}
+
+func (f FlatFormat) isEnv() bool {
+	return f == ENV_FLAT_FORMAT || f == DOTENV_FLAT_FORMAT
+}
+
+type KeyCase string
+
+const (
+	UPPER_KEY_CASE KeyCase = "upper"
+	LOWER_KEY_CASE KeyCase = "lower"
+	KEEP_KEY_CASE  KeyCase = "keep"
+)
+
+func NewKeyCase(keyCase string) (KeyCase, error) {
+	switch keyCase {
+	case string(UPPER_KEY_CASE):
+		return UPPER_KEY_CASE, nil
+	case string(LOWER_KEY_CASE):
+		return LOWER_KEY_CASE, nil
+	case string(KEEP_KEY_CASE):
+		return KEEP_KEY_CASE, nil
+	}
+
+	return "", fmt.Errorf("invalid key case %s", keyCase)
+}
+
+// FlatOptions describe how config keys are flattened: the path of each value
+// is joined with the separator, after the prefix. Array elements are keyed by
+// their index, so ["a", "b"] in hosts becomes HOSTS_0=a and HOSTS_1=b.
+type FlatOptions struct {
+	Format    FlatFormat
+	Separator string
+	Prefix    string
+	Case      KeyCase
+}
+
+// NewFlatOptions validates options, empty ones taking the format defaults:
+// upper case keys separated by "_" for env formats, keys kept as they are and
+// separated by "." for properties.
+func NewFlatOptions(format, separator, prefix, keyCase string) (FlatOptions, error) {
+	f, err := NewFlatFormat(format)
+	if err != nil {
+		return FlatOptions{}, err
+	}
+
+	opts := FlatOptions{
+		Format:    f,
+		Separator: separator,
+		Prefix:    prefix,
+		Case:      KEEP_KEY_CASE,
+	}
+
+	if f.isEnv() {
+		if opts.Separator == "" {
+			opts.Separator = "_"
+		}
+
+		opts.Case = UPPER_KEY_CASE
+	} else if opts.Separator == "" {
+		opts.Separator = "."
+	}
+
+	if keyCase != "" {
+		opts.Case, err = NewKeyCase(keyCase)
+		if err != nil {
+			return FlatOptions{}, err
+		}
+	}
+
+	if f.isEnv() {
+		if !isEnvName(opts.Separator) {
+			return FlatOptions{}, fmt.Errorf("separator %s is not valid in environment variable names", opts.Separator)
+		}
+
+		if opts.Prefix != "" && !isEnvName(opts.Prefix) {
+			return FlatOptions{}, fmt.Errorf("prefix %s is not valid in environment variable names", opts.Prefix)
+		}
+	}
+
+	return opts, nil
+}
+
+// key builds the flat key of a value path. Characters not allowed in
+// environment variable names are replaced with "_" in env formats.
+func (opts FlatOptions) key(path []string) string {
+	keys := path
+	if opts.Prefix != "" {
+		keys = append([]string{opts.Prefix}, path...)
+	}
+
+	key := strings.Join(keys, opts.Separator)
+
+	switch opts.Case {
+	case UPPER_KEY_CASE:
+		key = strings.ToUpper(key)
+	case LOWER_KEY_CASE:
+		key = strings.ToLower(key)
+	}
+
+	if opts.Format.isEnv() {
+		key = strings.Map(func(r rune) rune {
+			if isEnvRune(r) {
+				return r
+			}
+
+			return '_'
+		}, key)
+	}
+
+	return key
+}
+
+func isEnvRune(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+func isEnvName(s string) bool {
+	for _, r := range s {
+		if !isEnvRune(r) {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+// Flatten renders the config as key/value lines sorted by key. Nulls, empty
+// objects and empty arrays have no representation and are left out.
+func Flatten(c ConfigData, opts FlatOptions) (string, error) {
+	vars := make(map[string]string)
+	if err := flattenValue(vars, opts, nil, map[string]interface{}(c)); err != nil {
+		return "", err
+	}
+
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		line, err := opts.Format.encodeLine(k, vars[k])
+		if err != nil {
+			return "", err
+		}
+
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	return b.String(), nil
+}
+
+func flattenValue(vars map[string]string, opts FlatOptions, path []string, v interface{}) error {
+	var value string
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if err := flattenValue(vars, opts, appendKey(path, k), e); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case []interface{}:
+		for i, e := range v {
+			if err := flattenValue(vars, opts, appendKey(path, strconv.Itoa(i)), e); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case nil:
+		return nil
+	case string:
+		value = v
+	case float64:
+		value = strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		value = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case int, int32, int64, bool:
+		value = fmt.Sprint(v)
+	default:
+		return fmt.Errorf("%s: unsupported value %v", joinKeys(path), v)
+	}
+
+	key := opts.key(path)
+	if _, ok := vars[key]; ok {
+		return fmt.Errorf("%s: key %s is used by another value", joinKeys(path), key)
+	}
+
+	vars[key] = value
+
+	return nil
+}
+
+// Unflatten builds a config from key/value lines. Values are looked up by the
+// keys props would be flattened into and converted to the prop types, so
+// unknown keys are ignored.
+func Unflatten(doc string, ps map[string]*props.Prop, opts FlatOptions) (ConfigData, error) {
+	vars, err := opts.Format.parse(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := unflattenObject(vars, ps, opts, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(obj) == 0 {
+		return nil, errors.New("document does not have values for any prop")
+	}
+
+	return ConfigData(obj), nil
+}
+
+func unflattenObject(
+	vars map[string]string,
+	ps map[string]*props.Prop,
+	opts FlatOptions,
+	path []string,
+) (map[string]interface{}, error) {
+	obj := make(map[string]interface{})
+
+	for name, p := range ps {
+		propPath := appendKey(path, name)
+
+		if !p.IsArray() {
+			v, ok, err := unflattenValue(vars, p, opts, propPath)
+			if err != nil {
+				return nil, err
+			}
+
+			if ok {
+				obj[name] = v
+			}
+
+			continue
+		}
+
+		arr := make([]interface{}, 0)
+		for i := 0; ; i++ {
+			v, ok, err := unflattenValue(vars, p, opts, appendKey(propPath, strconv.Itoa(i)))
+			if err != nil {
+				return nil, err
+			}
+
+			if !ok {
+				break
+			}
+
+			arr = append(arr, v)
+		}
+
+		if len(arr) > 0 {
+			obj[name] = arr
+		}
+	}
+
+	return obj, nil
+}
+
+func unflattenValue(
+	vars map[string]string,
+	p *props.Prop,
+	opts FlatOptions,
+	path []string,
+) (interface{}, bool, error) {
+	if p.Type() == props.OBJECT {
+		obj, err := unflattenObject(vars, p.Props(), opts, path)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return obj, len(obj) > 0, nil
+	}
+
+	key := opts.key(path)
+
+	raw, ok := vars[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	var (
+		v   interface{}
+		err error
+	)
+
+	// Numbers are decoded as float64, like JSON numbers
+	switch p.Type() {
+	case props.INT:
+		var i int64
+		i, err = strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+		v = float64(i)
+	case props.FLOAT:
+		v, err = strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	case props.BOOL:
+		v, err = strconv.ParseBool(strings.TrimSpace(raw))
+	default:
+		v = raw
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %s is not a valid %s", key, raw, p.Type())
+	}
+
+	return v, true, nil
+}
+
+func appendKey(path []string, key string) []string {
+	return append(append(make([]string, 0, len(path)+1), path...), key)
+}
@@ -0,0 +1,289 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+func (f FlatFormat) encodeLine(key, value string) (string, error) {
+	switch f {
+	case ENV_FLAT_FORMAT:
+		if strings.ContainsAny(value, "\r\n") {
+			return "", fmt.Errorf("%s: env format cannot represent multi-line values", key)
+		}
+
+		return key + "=" + value, nil
+	case DOTENV_FLAT_FORMAT:
+		return key + "=" + quoteDotenv(value), nil
+	}
+
+	return escapeProperty(key, true) + "=" + escapeProperty(value, false), nil
+}
+
+func quoteDotenv(s string) string {
+	var b strings.Builder
+
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '\\', '"', '$':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+
+	return b.String()
+}
+
+// escapeProperty escapes keys and values as java.util.Properties does,
+// non-ASCII characters included.
+func escapeProperty(s string, isKey bool) string {
+	var b strings.Builder
+
+	for i, r := range s {
+		switch {
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\f':
+			b.WriteString(`\f`)
+		case r == ' ' && (isKey || i == 0):
+			b.WriteString(`\ `)
+		case (r == '=' || r == ':' || r == '#' || r == '!') && (isKey || i == 0):
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			for _, u := range utf16.Encode([]rune{r}) {
+				fmt.Fprintf(&b, `\u%04X`, u)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// parse reads key/value lines. Blank lines and comments are skipped and, in
+// env formats, a leading export is ignored. Repeated keys keep the last value.
+func (f FlatFormat) parse(doc string) (map[string]string, error) {
+	if f == PROPERTIES_FLAT_FORMAT {
+		return parseProperties(doc)
+	}
+
+	vars := make(map[string]string)
+
+	for i, line := range strings.Split(doc, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		trimmed = strings.TrimPrefix(trimmed, "export ")
+
+		sep := strings.Index(trimmed, "=")
+		if sep <= 0 {
+			return nil, fmt.Errorf("line %d: expected KEY=value", i+1)
+		}
+
+		key := strings.TrimSpace(trimmed[:sep])
+		value := trimmed[sep+1:]
+
+		if f == DOTENV_FLAT_FORMAT {
+			var err error
+			value, err = unquoteDotenv(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+		}
+
+		vars[key] = value
+	}
+
+	return vars, nil
+}
+
+// unquoteDotenv reads double quoted values with escapes, single quoted values
+// literally and unquoted values up to a comment.
+func unquoteDotenv(s string) (string, error) {
+	s = strings.TrimSpace(s)
+
+	if strings.HasPrefix(s, "'") {
+		end := strings.Index(s[1:], "'")
+		if end < 0 {
+			return "", fmt.Errorf("unterminated quoted value")
+		}
+
+		return s[1 : end+1], nil
+	}
+
+	if !strings.HasPrefix(s, `"`) {
+		if i := strings.Index(s, " #"); i >= 0 {
+			s = s[:i]
+		}
+
+		return strings.TrimSpace(s), nil
+	}
+
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			return b.String(), nil
+		case '\\':
+			if i+1 == len(s) {
+				return "", fmt.Errorf("unterminated quoted value")
+			}
+
+			i++
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(s[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return "", fmt.Errorf("unterminated quoted value")
+}
+
+func parseProperties(doc string) (map[string]string, error) {
+	vars := make(map[string]string)
+
+	lines := strings.Split(strings.ReplaceAll(doc, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimLeft(lines[i], " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+
+		// Lines ending with an odd number of backslashes continue in the
+		// next one, without its leading whitespace
+		for continues(line) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + strings.TrimLeft(lines[i], " \t\f")
+		}
+
+		keyEnd := len(line)
+		for j := 0; j < len(line); j++ {
+			if line[j] == '\\' {
+				j++
+				continue
+			}
+
+			if strings.IndexByte("=: \t\f", line[j]) >= 0 {
+				keyEnd = j
+				break
+			}
+		}
+
+		rest := strings.TrimLeft(line[keyEnd:], " \t\f")
+		if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+			rest = strings.TrimLeft(rest[1:], " \t\f")
+		}
+
+		key, err := unescapeProperty(line[:keyEnd])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		value, err := unescapeProperty(rest)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		vars[key] = value
+	}
+
+	return vars, nil
+}
+
+func continues(line string) bool {
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		n++
+	}
+
+	return n%2 == 1
+}
+
+func unescapeProperty(s string) (string, error) {
+	var (
+		b     strings.Builder
+		units []uint16
+	)
+
+	flush := func() {
+		if len(units) > 0 {
+			b.WriteString(string(utf16.Decode(units)))
+			units = nil
+		}
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			flush()
+			b.WriteByte(s[i])
+			continue
+		}
+
+		i++
+		if s[i] == 'u' {
+			if i+5 > len(s) {
+				return "", fmt.Errorf("invalid unicode escape")
+			}
+
+			u, err := strconv.ParseUint(s[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", fmt.Errorf("invalid unicode escape")
+			}
+
+			// Kept until the next character to join surrogate pairs
+			units = append(units, uint16(u))
+			i += 4
+			continue
+		}
+
+		flush()
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'f':
+			b.WriteByte('\f')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	flush()
+
+	return b.String(), nil
+}
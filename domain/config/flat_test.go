@@ -0,0 +1,319 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/aboglioli/configd/domain/props"
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFlatOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    string
+		separator string
+		prefix    string
+		keyCase   string
+		expected  FlatOptions
+		err       bool
+	}{
+		{
+			name:     "env defaults",
+			format:   "env",
+			expected: FlatOptions{Format: ENV_FLAT_FORMAT, Separator: "_", Case: UPPER_KEY_CASE},
+		},
+		{
+			name:     "properties defaults",
+			format:   "properties",
+			expected: FlatOptions{Format: PROPERTIES_FLAT_FORMAT, Separator: ".", Case: KEEP_KEY_CASE},
+		},
+		{
+			name:      "custom",
+			format:    "dotenv",
+			separator: "__",
+			prefix:    "internal",
+			keyCase:   "lower",
+			expected:  FlatOptions{Format: DOTENV_FLAT_FORMAT, Separator: "__", Prefix: "internal", Case: LOWER_KEY_CASE},
+		},
+		{name: "unknown format", format: "ini", err: true},
+		{name: "unknown case", format: "env", keyCase: "camel", err: true},
+		{name: "invalid env separator", format: "env", separator: ".", err: true},
+		{name: "invalid env prefix", format: "dotenv", prefix: "my-app", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts, err := NewFlatOptions(test.format, test.separator, test.prefix, test.keyCase)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.expected, opts)
+			}
+		})
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	data := ConfigData{
+		"port":    float64(8080),
+		"ratio":   0.5,
+		"debug":   true,
+		"message": "hello \"world\"\nbye",
+		"hosts":   []interface{}{"a", "b"},
+		"empty":   map[string]interface{}{},
+		"token":   nil,
+		"service": map[string]interface{}{
+			"url":   "http://service",
+			"zones": []interface{}{map[string]interface{}{"name": "eu"}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		config   ConfigData
+		opts     FlatOptions
+		expected string
+		err      bool
+	}{
+		{
+			name:   "dotenv",
+			config: data,
+			opts:   FlatOptions{Format: DOTENV_FLAT_FORMAT, Separator: "_", Prefix: "internal", Case: UPPER_KEY_CASE},
+			expected: `INTERNAL_DEBUG="true"
+INTERNAL_HOSTS_0="a"
+INTERNAL_HOSTS_1="b"
+INTERNAL_MESSAGE="hello \"world\"\nbye"
+INTERNAL_PORT="8080"
+INTERNAL_RATIO="0.5"
+INTERNAL_SERVICE_URL="http://service"
+INTERNAL_SERVICE_ZONES_0_NAME="eu"
+`,
+		},
+		{
+			name: "env",
+			config: ConfigData{
+				"port":     float64(8080),
+				"api-host": "http://service?a=b",
+			},
+			opts: FlatOptions{Format: ENV_FLAT_FORMAT, Separator: "__", Case: UPPER_KEY_CASE},
+			expected: `API_HOST=http://service?a=b
+PORT=8080
+`,
+		},
+		{
+			name:   "env multi-line value",
+			config: ConfigData{"message": "a\nb"},
+			opts:   FlatOptions{Format: ENV_FLAT_FORMAT, Separator: "_", Case: UPPER_KEY_CASE},
+			err:    true,
+		},
+		{
+			name: "properties",
+			config: ConfigData{
+				"greeting": " hola señor",
+				"db":       map[string]interface{}{"url": "jdbc:postgres://db"},
+				"my key":   "a=b",
+			},
+			opts: FlatOptions{Format: PROPERTIES_FLAT_FORMAT, Separator: ".", Case: KEEP_KEY_CASE},
+			expected: `db.url=jdbc:postgres://db
+greeting=\ hola se\u00F1or
+my\ key=a=b
+`,
+		},
+		{
+			name: "colliding keys",
+			config: ConfigData{
+				"service_url": "a",
+				"service":     map[string]interface{}{"url": "b"},
+			},
+			opts: FlatOptions{Format: ENV_FLAT_FORMAT, Separator: "_", Case: UPPER_KEY_CASE},
+			err:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc, err := Flatten(test.config, test.opts)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.expected, doc)
+			}
+		})
+	}
+}
+
+func TestUnflatten(t *testing.T) {
+	port, err := props.NewInteger("port")
+	utils.Ok(err)
+
+	ratio, err := props.NewFloat("ratio")
+	utils.Ok(err)
+
+	debug, err := props.NewBool("debug")
+	utils.Ok(err)
+
+	hosts, err := props.NewString("hosts", props.WithArray())
+	utils.Ok(err)
+
+	url, err := props.NewString("url")
+	utils.Ok(err)
+
+	name, err := props.NewString("name")
+	utils.Ok(err)
+
+	zones, err := props.NewObject("zones", props.WithArray(), props.WithProps(name))
+	utils.Ok(err)
+
+	service, err := props.NewObject("service", props.WithProps(url, zones))
+	utils.Ok(err)
+
+	ps := map[string]*props.Prop{
+		"port":    port,
+		"ratio":   ratio,
+		"debug":   debug,
+		"hosts":   hosts,
+		"service": service,
+	}
+
+	tests := []struct {
+		name     string
+		doc      string
+		opts     FlatOptions
+		expected ConfigData
+		err      bool
+	}{
+		{
+			name: "env",
+			doc: `
+# Comment
+export APP_PORT=8080
+APP_RATIO=0.5
+APP_DEBUG=true
+APP_HOSTS_0=a
+APP_HOSTS_1=b
+APP_SERVICE_URL=http://service?a=b
+APP_SERVICE_ZONES_0_NAME=eu
+PATH=/usr/bin
+`,
+			opts: FlatOptions{Format: ENV_FLAT_FORMAT, Separator: "_", Prefix: "app", Case: UPPER_KEY_CASE},
+			expected: ConfigData{
+				"port":  float64(8080),
+				"ratio": 0.5,
+				"debug": true,
+				"hosts": []interface{}{"a", "b"},
+				"service": map[string]interface{}{
+					"url":   "http://service?a=b",
+					"zones": []interface{}{map[string]interface{}{"name": "eu"}},
+				},
+			},
+		},
+		{
+			name: "dotenv",
+			doc: `PORT="8080"
+SERVICE_URL='http://service # not a comment'
+HOSTS_0=a # comment
+HOSTS_1="b\"c\nd"
+`,
+			opts: FlatOptions{Format: DOTENV_FLAT_FORMAT, Separator: "_", Case: UPPER_KEY_CASE},
+			expected: ConfigData{
+				"port":  float64(8080),
+				"hosts": []interface{}{"a", "b\"c\nd"},
+				"service": map[string]interface{}{
+					"url": "http://service # not a comment",
+				},
+			},
+		},
+		{
+			name: "properties",
+			doc: `! Comment
+port = 8080
+service.url: http://service
+hosts.0 señor
+hosts.1=multi \
+    line
+`,
+			opts: FlatOptions{Format: PROPERTIES_FLAT_FORMAT, Separator: ".", Case: KEEP_KEY_CASE},
+			expected: ConfigData{
+				"port":  float64(8080),
+				"hosts": []interface{}{"señor", "multi line"},
+				"service": map[string]interface{}{
+					"url": "http://service",
+				},
+			},
+		},
+		{
+			name: "invalid integer",
+			doc:  "PORT=80.5",
+			opts: FlatOptions{Format: ENV_FLAT_FORMAT, Separator: "_", Case: UPPER_KEY_CASE},
+			err:  true,
+		},
+		{
+			name: "invalid line",
+			doc:  "PORT",
+			opts: FlatOptions{Format: ENV_FLAT_FORMAT, Separator: "_", Case: UPPER_KEY_CASE},
+			err:  true,
+		},
+		{
+			name: "unterminated quote",
+			doc:  `PORT="8080`,
+			opts: FlatOptions{Format: DOTENV_FLAT_FORMAT, Separator: "_", Case: UPPER_KEY_CASE},
+			err:  true,
+		},
+		{
+			name: "no values",
+			doc:  "PATH=/usr/bin",
+			opts: FlatOptions{Format: ENV_FLAT_FORMAT, Separator: "_", Case: UPPER_KEY_CASE},
+			err:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := Unflatten(test.doc, ps, test.opts)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.expected, c)
+			}
+		})
+	}
+}
+
+func TestFlattenUnflatten(t *testing.T) {
+	port, err := props.NewInteger("port")
+	utils.Ok(err)
+
+	message, err := props.NewString("message")
+	utils.Ok(err)
+
+	ps := map[string]*props.Prop{"port": port, "message": message}
+	data := ConfigData{"port": float64(80), "message": " a=b\n\"c\" $HOME \\ ñ 😀"}
+
+	for _, format := range []string{"dotenv", "properties"} {
+		t.Run(format, func(t *testing.T) {
+			opts, err := NewFlatOptions(format, "", "", "")
+			utils.Ok(err)
+
+			doc, err := Flatten(data, opts)
+			utils.Ok(err)
+
+			c, err := Unflatten(doc, ps, opts)
+			if assert.NoError(t, err) {
+				assert.Equal(t, data, c)
+			}
+		})
+	}
+}
@@ -0,0 +1,345 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aboglioli/configd/domain/props"
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/utils"
+)
+
+type MigrationOp string
+
+const (
+	RENAME_MIGRATION      MigrationOp = "rename"
+	MOVE_MIGRATION        MigrationOp = "move"
+	SET_DEFAULT_MIGRATION MigrationOp = "set_default"
+	CONVERT_MIGRATION     MigrationOp = "convert"
+	DELETE_MIGRATION      MigrationOp = "delete"
+)
+
+// Migration is a declarative change applied to the value located at a JSON
+// pointer. Migrations over missing values are no-ops, except set_default
+// which adds the value.
+type Migration struct {
+	op   MigrationOp
+	path []string
+	// Destination key for rename, destination pointer for move
+	to    []string
+	value interface{}
+	t     props.PropType
+}
+
+// NewMigration validates a migration. Depending on op, to is the new key
+// name (rename) or the destination pointer (move), value is the default to
+// set (set_default) and t the target type (convert).
+func NewMigration(op MigrationOp, path, to string, value interface{}, t string) (*Migration, error) {
+	keys, err := utils.SplitPointer(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("migration path cannot be the whole config")
+	}
+
+	m := &Migration{
+		op:   op,
+		path: keys,
+	}
+
+	switch op {
+	case RENAME_MIGRATION:
+		if to == "" {
+			return nil, errors.New("rename requires the new key name")
+		}
+
+		m.to = append(append([]string{}, keys[:len(keys)-1]...), to)
+	case MOVE_MIGRATION:
+		m.to, err = utils.SplitPointer(to)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(m.to) == 0 {
+			return nil, errors.New("move requires the destination path")
+		}
+
+		if isPrefix(keys, m.to) {
+			return nil, errors.New("cannot move a value into itself")
+		}
+	case SET_DEFAULT_MIGRATION:
+		if value == nil {
+			return nil, errors.New("set_default requires a value")
+		}
+
+		m.value = value
+	case CONVERT_MIGRATION:
+		pt, err := props.NewPropType(t)
+		if err != nil {
+			return nil, err
+		}
+
+		if pt == props.OBJECT {
+			return nil, errors.New("cannot convert values into objects")
+		}
+
+		m.t = pt
+	case DELETE_MIGRATION:
+	default:
+		return nil, fmt.Errorf("invalid migration %s", op)
+	}
+
+	return m, nil
+}
+
+func (m *Migration) Op() MigrationOp {
+	return m.op
+}
+
+// Migrate applies migrations in order over a copy of the config.
+func Migrate(c ConfigData, migrations ...*Migration) (ConfigData, error) {
+	data := copyObject(c)
+
+	for _, m := range migrations {
+		if err := m.apply(data); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", m.op, joinKeys(m.path), err)
+		}
+	}
+
+	return data, nil
+}
+
+// MigrateKeyOrder keeps renamed and moved keys in the position they were
+// written.
+func MigrateKeyOrder(order codec.KeyOrder, migrations ...*Migration) codec.KeyOrder {
+	if order == nil {
+		return nil
+	}
+
+	migrated := make(codec.KeyOrder, len(order))
+	copy(migrated, order)
+
+	for _, m := range migrations {
+		if m.op != RENAME_MIGRATION && m.op != MOVE_MIGRATION {
+			continue
+		}
+
+		from, to := joinKeys(m.path), joinKeys(m.to)
+		for i, path := range migrated {
+			if path == from || strings.HasPrefix(path, from+"/") {
+				migrated[i] = to + strings.TrimPrefix(path, from)
+			}
+		}
+	}
+
+	return migrated
+}
+
+func (m *Migration) apply(data map[string]interface{}) error {
+	parent, key, found, err := lookup(data, m.path, m.op == SET_DEFAULT_MIGRATION)
+	if err != nil {
+		return err
+	}
+
+	if !found && m.op != SET_DEFAULT_MIGRATION {
+		return nil
+	}
+
+	switch m.op {
+	case RENAME_MIGRATION, MOVE_MIGRATION:
+		destParent, destKey, exists, err := lookup(data, m.to, true)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return fmt.Errorf("destination %s already exists", joinKeys(m.to))
+		}
+
+		destParent[destKey] = parent[key]
+		delete(parent, key)
+	case SET_DEFAULT_MIGRATION:
+		if !found {
+			parent[key] = m.value
+		}
+	case CONVERT_MIGRATION:
+		v, err := convert(parent[key], m.t)
+		if err != nil {
+			return err
+		}
+
+		parent[key] = v
+	case DELETE_MIGRATION:
+		delete(parent, key)
+	}
+
+	return nil
+}
+
+// lookup returns the object holding the last key of path and whether the key
+// exists in it. Missing intermediate objects are created when create is true.
+func lookup(data map[string]interface{}, path []string, create bool) (map[string]interface{}, string, bool, error) {
+	obj := data
+	for i, k := range path[:len(path)-1] {
+		v, ok := obj[k]
+		if !ok {
+			if !create {
+				return nil, "", false, nil
+			}
+
+			v = make(map[string]interface{})
+			obj[k] = v
+		}
+
+		next, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, "", false, fmt.Errorf("%s is not an object", joinKeys(path[:i+1]))
+		}
+
+		obj = next
+	}
+
+	key := path[len(path)-1]
+	_, found := obj[key]
+
+	return obj, key, found, nil
+}
+
+func convert(v interface{}, t props.PropType) (interface{}, error) {
+	// Arrays are converted element by element
+	if arr, ok := v.([]interface{}); ok {
+		converted := make([]interface{}, 0, len(arr))
+		for _, e := range arr {
+			c, err := convert(e, t)
+			if err != nil {
+				return nil, err
+			}
+
+			converted = append(converted, c)
+		}
+
+		return converted, nil
+	}
+
+	switch t {
+	case props.STRING:
+		switch v := v.(type) {
+		case string:
+			return v, nil
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		case int:
+			return strconv.Itoa(v), nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		}
+	case props.INT:
+		switch v := v.(type) {
+		case string:
+			i, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not an integer", v)
+			}
+
+			return float64(i), nil
+		case float64:
+			if v != float64(int64(v)) {
+				return nil, fmt.Errorf("%v is not an integer", v)
+			}
+
+			return v, nil
+		case int:
+			return float64(v), nil
+		case bool:
+			if v {
+				return float64(1), nil
+			}
+
+			return float64(0), nil
+		}
+	case props.FLOAT:
+		switch v := v.(type) {
+		case string:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a float", v)
+			}
+
+			return f, nil
+		case float64:
+			return v, nil
+		case int:
+			return float64(v), nil
+		}
+	case props.BOOL:
+		switch v := v.(type) {
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a boolean", v)
+			}
+
+			return b, nil
+		case bool:
+			return v, nil
+		case float64:
+			return v != 0, nil
+		case int:
+			return v != 0, nil
+		}
+	}
+
+	return nil, fmt.Errorf("cannot convert %v to %s", v, t)
+}
+
+func copyObject(obj map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		copied[k] = copyValue(v)
+	}
+
+	return copied
+}
+
+func copyValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return copyObject(v)
+	case []interface{}:
+		copied := make([]interface{}, 0, len(v))
+		for _, e := range v {
+			copied = append(copied, copyValue(e))
+		}
+
+		return copied
+	}
+
+	return v
+}
+
+func joinKeys(keys []string) string {
+	path := ""
+	for _, k := range keys {
+		path = utils.JoinPointer(path, k)
+	}
+
+	return path
+}
+
+func isPrefix(prefix, keys []string) bool {
+	if len(prefix) > len(keys) {
+		return false
+	}
+
+	for i := range prefix {
+		if prefix[i] != keys[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,208 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMigration(t *testing.T) {
+	tests := []struct {
+		name  string
+		op    MigrationOp
+		path  string
+		to    string
+		value interface{}
+		t     string
+		err   bool
+	}{
+		{name: "rename", op: RENAME_MIGRATION, path: "/port", to: "listen_port"},
+		{name: "rename without key", op: RENAME_MIGRATION, path: "/port", err: true},
+		{name: "move", op: MOVE_MIGRATION, path: "/host", to: "/server/host"},
+		{name: "move into itself", op: MOVE_MIGRATION, path: "/server", to: "/server/old", err: true},
+		{name: "set default without value", op: SET_DEFAULT_MIGRATION, path: "/env", err: true},
+		{name: "convert to object", op: CONVERT_MIGRATION, path: "/port", t: "object", err: true},
+		{name: "convert to unknown type", op: CONVERT_MIGRATION, path: "/port", t: "date", err: true},
+		{name: "whole config", op: DELETE_MIGRATION, path: "", err: true},
+		{name: "invalid pointer", op: DELETE_MIGRATION, path: "port", err: true},
+		{name: "unknown op", op: "copy", path: "/port", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewMigration(test.op, test.path, test.to, test.value, test.t)
+
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	type migration struct {
+		op    MigrationOp
+		path  string
+		to    string
+		value interface{}
+		t     string
+	}
+
+	tests := []struct {
+		name       string
+		config     ConfigData
+		migrations []migration
+		expected   ConfigData
+		err        bool
+	}{
+		{
+			name:   "rename",
+			config: ConfigData{"port": float64(80), "db": map[string]interface{}{"pass": "x"}},
+			migrations: []migration{
+				{op: RENAME_MIGRATION, path: "/port", to: "listen_port"},
+				{op: RENAME_MIGRATION, path: "/db/pass", to: "password"},
+			},
+			expected: ConfigData{"listen_port": float64(80), "db": map[string]interface{}{"password": "x"}},
+		},
+		{
+			name:   "move into a new object",
+			config: ConfigData{"host": "localhost", "port": float64(80)},
+			migrations: []migration{
+				{op: MOVE_MIGRATION, path: "/host", to: "/server/host"},
+				{op: MOVE_MIGRATION, path: "/port", to: "/server/port"},
+			},
+			expected: ConfigData{"server": map[string]interface{}{"host": "localhost", "port": float64(80)}},
+		},
+		{
+			name:   "move over existing value",
+			config: ConfigData{"host": "localhost", "server": map[string]interface{}{"host": "remote"}},
+			migrations: []migration{
+				{op: MOVE_MIGRATION, path: "/host", to: "/server/host"},
+			},
+			err: true,
+		},
+		{
+			name:   "move through a non object",
+			config: ConfigData{"host": "localhost", "server": "remote"},
+			migrations: []migration{
+				{op: MOVE_MIGRATION, path: "/host", to: "/server/host"},
+			},
+			err: true,
+		},
+		{
+			name:   "set default only when missing",
+			config: ConfigData{"env": "prod"},
+			migrations: []migration{
+				{op: SET_DEFAULT_MIGRATION, path: "/env", value: "dev"},
+				{op: SET_DEFAULT_MIGRATION, path: "/log/level", value: "info"},
+			},
+			expected: ConfigData{"env": "prod", "log": map[string]interface{}{"level": "info"}},
+		},
+		{
+			name: "convert",
+			config: ConfigData{
+				"port":    "8080",
+				"ratio":   "0.5",
+				"debug":   "true",
+				"version": float64(2),
+				"ids":     []interface{}{"1", "2"},
+			},
+			migrations: []migration{
+				{op: CONVERT_MIGRATION, path: "/port", t: "integer"},
+				{op: CONVERT_MIGRATION, path: "/ratio", t: "float"},
+				{op: CONVERT_MIGRATION, path: "/debug", t: "bool"},
+				{op: CONVERT_MIGRATION, path: "/version", t: "string"},
+				{op: CONVERT_MIGRATION, path: "/ids", t: "integer"},
+			},
+			expected: ConfigData{
+				"port":    float64(8080),
+				"ratio":   0.5,
+				"debug":   true,
+				"version": "2",
+				"ids":     []interface{}{float64(1), float64(2)},
+			},
+		},
+		{
+			name:   "invalid conversion",
+			config: ConfigData{"port": "http"},
+			migrations: []migration{
+				{op: CONVERT_MIGRATION, path: "/port", t: "integer"},
+			},
+			err: true,
+		},
+		{
+			name:   "delete",
+			config: ConfigData{"port": float64(80), "legacy": true},
+			migrations: []migration{
+				{op: DELETE_MIGRATION, path: "/legacy"},
+			},
+			expected: ConfigData{"port": float64(80)},
+		},
+		{
+			name:   "missing paths are skipped",
+			config: ConfigData{"port": float64(80)},
+			migrations: []migration{
+				{op: RENAME_MIGRATION, path: "/host", to: "hostname"},
+				{op: MOVE_MIGRATION, path: "/db/user", to: "/user"},
+				{op: CONVERT_MIGRATION, path: "/debug", t: "bool"},
+				{op: DELETE_MIGRATION, path: "/legacy"},
+			},
+			expected: ConfigData{"port": float64(80)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			migrations := make([]*Migration, 0, len(test.migrations))
+			for _, m := range test.migrations {
+				migration, err := NewMigration(m.op, m.path, m.to, m.value, m.t)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				migrations = append(migrations, migration)
+			}
+
+			original := copyObject(test.config)
+
+			migrated, err := Migrate(test.config, migrations...)
+
+			// The original config is never modified
+			assert.Equal(t, ConfigData(original), test.config)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.expected, migrated)
+			}
+		})
+	}
+}
+
+func TestMigrateKeyOrder(t *testing.T) {
+	rename, err := NewMigration(RENAME_MIGRATION, "/db", "database", nil, "")
+	utils.Ok(err)
+
+	move, err := NewMigration(MOVE_MIGRATION, "/port", "/server/port", nil, "")
+	utils.Ok(err)
+
+	remove, err := NewMigration(DELETE_MIGRATION, "/debug", "", nil, "")
+	utils.Ok(err)
+
+	order := codec.KeyOrder{"/port", "/db", "/db/user", "/db/pass", "/debug", "/dbs"}
+
+	assert.Equal(
+		t,
+		codec.KeyOrder{"/server/port", "/database", "/database/user", "/database/pass", "/debug", "/dbs"},
+		MigrateKeyOrder(order, move, rename, remove),
+	)
+	assert.Equal(t, codec.KeyOrder{"/port", "/db", "/db/user", "/db/pass", "/debug", "/dbs"}, order)
+	assert.Nil(t, MigrateKeyOrder(nil, rename))
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+// Revision is an immutable snapshot of the config data stored for a given
+// config version.
+type Revision struct {
+	configId  models.Id
+	version   uint
+	config    ConfigData
+	keyOrder  codec.KeyOrder
+	author    string
+	createdAt time.Time
+}
+
+func BuildRevision(
+	configId models.Id,
+	version uint,
+	config ConfigData,
+	keyOrder codec.KeyOrder,
+	author string,
+	createdAt time.Time,
+) (*Revision, error) {
+	if version == 0 {
+		return nil, errors.New("invalid revision version")
+	}
+
+	if len(config) == 0 {
+		return nil, errors.New("empty configuration")
+	}
+
+	return &Revision{
+		configId:  configId,
+		version:   version,
+		config:    config,
+		keyOrder:  keyOrder,
+		author:    author,
+		createdAt: createdAt,
+	}, nil
+}
+
+// NewRevision takes a snapshot of the current config data.
+func NewRevision(c *Config, author string) (*Revision, error) {
+	return BuildRevision(
+		c.Base().Id(),
+		c.Base().Version(),
+		c.Config(),
+		c.KeyOrder(),
+		author,
+		c.Base().UpdatedAt(),
+	)
+}
+
+func (r *Revision) ConfigId() models.Id {
+	return r.configId
+}
+
+func (r *Revision) Version() uint {
+	return r.version
+}
+
+func (r *Revision) Config() ConfigData {
+	return r.config
+}
+
+func (r *Revision) KeyOrder() codec.KeyOrder {
+	return r.keyOrder
+}
+
+func (r *Revision) ConfigSum() string {
+	return r.config.Hash()
+}
+
+func (r *Revision) Author() string {
+	return r.author
+}
+
+func (r *Revision) CreatedAt() time.Time {
+	return r.createdAt
+}
@@ -0,0 +1,22 @@
+package config
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var (
+	ErrRevisionNotFound = errors.New("config revision not found")
+)
+
+type RevisionRepository interface {
+	// FindByConfigId returns every revision of a config ordered by version.
+	FindByConfigId(ctx context.Context, configId models.Id) ([]*Revision, error)
+	FindByVersion(ctx context.Context, configId models.Id, version uint) (*Revision, error)
+	// Save stores a new revision. Revisions are immutable: saving an existing
+	// version is a no-op.
+	Save(ctx context.Context, revision *Revision) error
+	DeleteByConfigId(ctx context.Context, configId models.Id) error
+}
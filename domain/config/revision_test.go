@@ -0,0 +1,56 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/aboglioli/configd/pkg/models"
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollback(t *testing.T) {
+	id, err := models.BuildId("config")
+	utils.Ok(err)
+
+	schemaId, err := models.BuildId("schema")
+	utils.Ok(err)
+
+	name, err := NewName("Config")
+	utils.Ok(err)
+
+	c, err := NewConfig(id, schemaId, 0, name, ConfigData{"port": 8080}, nil)
+	utils.Ok(err)
+
+	first, err := NewRevision(c, "admin")
+	utils.Ok(err)
+	assert.Equal(t, uint(1), first.Version())
+	assert.Equal(t, c.Config().Hash(), first.ConfigSum())
+
+	// Rolling back to the current version is not allowed
+	assert.Error(t, c.Rollback(first))
+
+	// Next instance, as loaded from a repository
+	agg, err := models.BuildAggregateRoot(id, c.Base().CreatedAt(), c.Base().UpdatedAt(), nil, 1)
+	utils.Ok(err)
+	c, err = BuildConfig(agg, schemaId, 0, name, ConfigData{"port": 8080}, nil)
+	utils.Ok(err)
+
+	utils.Ok(c.ChangeConfig(ConfigData{"port": 9090}, nil))
+	assert.Equal(t, uint(2), c.Base().Version())
+
+	agg, err = models.BuildAggregateRoot(id, c.Base().CreatedAt(), c.Base().UpdatedAt(), nil, 2)
+	utils.Ok(err)
+	c, err = BuildConfig(agg, schemaId, 0, name, ConfigData{"port": 9090}, nil)
+	utils.Ok(err)
+
+	if assert.NoError(t, c.Rollback(first)) {
+		assert.Equal(t, ConfigData{"port": 8080}, c.Config())
+		assert.Equal(t, uint(3), c.Base().Version())
+	}
+
+	otherId, err := models.BuildId("other")
+	utils.Ok(err)
+	other, err := BuildRevision(otherId, 1, ConfigData{"port": 1}, nil, "", c.Base().CreatedAt())
+	utils.Ok(err)
+	assert.Error(t, c.Rollback(other))
+}
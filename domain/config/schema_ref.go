@@ -0,0 +1,31 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+// ParseSchemaRef parses a schema reference written as schema_id or
+// schema_id@version. Version zero means the latest schema version.
+func ParseSchemaRef(ref string) (models.Id, uint, error) {
+	at := strings.Index(ref, "@")
+	if at < 0 {
+		id, err := models.BuildId(ref)
+		return id, 0, err
+	}
+
+	id, err := models.BuildId(ref[:at])
+	if err != nil {
+		return models.Id{}, 0, err
+	}
+
+	version, err := strconv.ParseUint(ref[at+1:], 10, 0)
+	if err != nil || version == 0 {
+		return models.Id{}, 0, fmt.Errorf("invalid schema version in %s", ref)
+	}
+
+	return id, uint(version), nil
+}
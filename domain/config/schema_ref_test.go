@@ -0,0 +1,94 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/aboglioli/configd/pkg/models"
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSchemaRef(t *testing.T) {
+	type test struct {
+		name    string
+		ref     string
+		id      string
+		version uint
+		err     bool
+	}
+
+	tests := []test{
+		{
+			name: "latest version",
+			ref:  "my-schema",
+			id:   "my-schema",
+		},
+		{
+			name:    "pinned version",
+			ref:     "my-schema@3",
+			id:      "my-schema",
+			version: 3,
+		},
+		{
+			name: "version zero",
+			ref:  "my-schema@0",
+			err:  true,
+		},
+		{
+			name: "invalid version",
+			ref:  "my-schema@latest",
+			err:  true,
+		},
+		{
+			name: "empty version",
+			ref:  "my-schema@",
+			err:  true,
+		},
+		{
+			name: "invalid id",
+			ref:  "@2",
+			err:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			id, version, err := ParseSchemaRef(test.ref)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.id, id.Value())
+				assert.Equal(t, test.version, version)
+			}
+		})
+	}
+}
+
+func TestChangeSchema(t *testing.T) {
+	id, err := models.BuildId("config")
+	utils.Ok(err)
+
+	schemaId, err := models.BuildId("schema")
+	utils.Ok(err)
+
+	name, err := NewName("Config")
+	utils.Ok(err)
+
+	c, err := NewConfig(id, schemaId, 0, name, ConfigData{"port": 8080}, nil)
+	utils.Ok(err)
+	c.ClearEvents()
+
+	// Same schema and version is a no-op
+	assert.NoError(t, c.ChangeSchema(schemaId, 0))
+	assert.Empty(t, c.Base().Events())
+
+	if assert.NoError(t, c.ChangeSchema(schemaId, 2)) {
+		assert.Equal(t, uint(2), c.SchemaVersion())
+		assert.Len(t, c.Base().Events(), 1)
+		assert.Equal(t, ConfigSchemaChangedTopic, c.Base().Events()[0].Topic())
+	}
+}
@@ -0,0 +1,215 @@
+package props
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/aboglioli/configd/pkg/utils"
+)
+
+type ChangeKind string
+
+const (
+	ADDED_PROP_CHANGE   ChangeKind = "added_prop"
+	REMOVED_PROP_CHANGE ChangeKind = "removed_prop"
+	TYPE_CHANGE         ChangeKind = "type"
+	REQUIRED_CHANGE     ChangeKind = "required"
+	ENUM_CHANGE         ChangeKind = "enum"
+	INTERVAL_CHANGE     ChangeKind = "interval"
+	REGEX_CHANGE        ChangeKind = "regex"
+	DEFAULT_CHANGE      ChangeKind = "default"
+)
+
+// PropChange is a difference between two versions of the prop located at
+// Path, a JSON pointer. Breaking changes may turn valid configs into invalid
+// ones.
+type PropChange struct {
+	Path     string     `json:"path"`
+	Kind     ChangeKind `json:"kind"`
+	Breaking bool       `json:"breaking"`
+	Message  string     `json:"message"`
+}
+
+// CompareProps classifies the changes needed to go from one prop tree to the
+// other. Changes are sorted by path.
+func CompareProps(from, to map[string]*Prop) []PropChange {
+	return compareObjects("", from, to)
+}
+
+func compareObjects(path string, from, to map[string]*Prop) []PropChange {
+	keys := make([]string, 0, len(from)+len(to))
+	for k := range from {
+		keys = append(keys, k)
+	}
+	for k := range to {
+		if _, ok := from[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	changes := make([]PropChange, 0)
+	for _, k := range keys {
+		fromProp, inFrom := from[k]
+		toProp, inTo := to[k]
+
+		keyPath := utils.JoinPointer(path, k)
+
+		switch {
+		case !inTo:
+			changes = append(changes, PropChange{
+				Path:     keyPath,
+				Kind:     REMOVED_PROP_CHANGE,
+				Breaking: true,
+				Message:  fmt.Sprintf("prop %s was removed", k),
+			})
+		case !inFrom:
+			// Configs lacking a new optional prop are still valid
+			changes = append(changes, PropChange{
+				Path:     keyPath,
+				Kind:     ADDED_PROP_CHANGE,
+				Breaking: toProp.IsRequired(),
+				Message:  fmt.Sprintf("prop %s was added", k),
+			})
+		default:
+			changes = append(changes, compareProps(keyPath, fromProp, toProp)...)
+		}
+	}
+
+	return changes
+}
+
+func compareProps(path string, from, to *Prop) []PropChange {
+	change := func(kind ChangeKind, breaking bool, format string, args ...interface{}) PropChange {
+		return PropChange{
+			Path:     path,
+			Kind:     kind,
+			Breaking: breaking,
+			Message:  fmt.Sprintf(format, args...),
+		}
+	}
+
+	// Nothing else is comparable between different types
+	if from.Type() != to.Type() || from.IsArray() != to.IsArray() {
+		return []PropChange{
+			change(TYPE_CHANGE, true, "type changed from %s to %s", describeType(from), describeType(to)),
+		}
+	}
+
+	changes := make([]PropChange, 0)
+
+	if from.IsRequired() != to.IsRequired() {
+		if to.IsRequired() {
+			changes = append(changes, change(REQUIRED_CHANGE, true, "prop became required"))
+		} else {
+			changes = append(changes, change(REQUIRED_CHANGE, false, "prop became optional"))
+		}
+	}
+
+	if c, ok := compareEnums(from.Enum(), to.Enum()); ok {
+		changes = append(changes, change(ENUM_CHANGE, c, "enum changed from %v to %v", from.Enum(), to.Enum()))
+	}
+
+	if c, ok := compareIntervals(from.Interval(), to.Interval()); ok {
+		changes = append(changes, change(
+			INTERVAL_CHANGE,
+			c,
+			"interval changed from %s to %s",
+			describeInterval(from.Interval()),
+			describeInterval(to.Interval()),
+		))
+	}
+
+	if from.Regex() != to.Regex() {
+		// Removing the regex accepts every previous value
+		changes = append(changes, change(
+			REGEX_CHANGE,
+			to.Regex() != "",
+			"regex changed from %q to %q",
+			from.Regex(),
+			to.Regex(),
+		))
+	}
+
+	if !reflect.DeepEqual(from.Default(), to.Default()) {
+		changes = append(changes, change(DEFAULT_CHANGE, false, "default changed from %v to %v", from.Default(), to.Default()))
+	}
+
+	if from.Type() == OBJECT {
+		changes = append(changes, compareObjects(path, from.Props(), to.Props())...)
+	}
+
+	return changes
+}
+
+// compareEnums reports whether enums differ and, if so, whether the new one
+// rejects any previously allowed value. An empty enum allows every value.
+func compareEnums(from, to []interface{}) (breaking bool, changed bool) {
+	contains := func(values []interface{}, v interface{}) bool {
+		for _, value := range values {
+			if reflect.DeepEqual(value, v) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	subset := func(a, b []interface{}) bool {
+		for _, v := range a {
+			if !contains(b, v) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if len(to) == 0 {
+		return false, len(from) > 0
+	}
+
+	if len(from) == 0 {
+		return true, true
+	}
+
+	narrowed := !subset(from, to)
+	widened := !subset(to, from)
+
+	return narrowed, narrowed || widened
+}
+
+// compareIntervals reports whether intervals differ and, if so, whether the
+// new one is narrower on any side. A nil interval allows every value.
+func compareIntervals(from, to *Interval) (breaking bool, changed bool) {
+	if to == nil {
+		return false, from != nil
+	}
+
+	if from == nil {
+		return true, true
+	}
+
+	if *from == *to {
+		return false, false
+	}
+
+	return to.Min() > from.Min() || to.Max() < from.Max(), true
+}
+
+func describeType(p *Prop) string {
+	if p.IsArray() {
+		return fmt.Sprintf("array of %s", p.Type())
+	}
+
+	return string(p.Type())
+}
+
+func describeInterval(i *Interval) string {
+	if i == nil {
+		return "none"
+	}
+
+	return fmt.Sprintf("[%v, %v]", i.Min(), i.Max())
+}
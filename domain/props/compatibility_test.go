@@ -0,0 +1,151 @@
+package props
+
+import (
+	"testing"
+
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareProps(t *testing.T) {
+	prop := func(p *Prop, err error) *Prop {
+		utils.Ok(err)
+		return p
+	}
+
+	tests := []struct {
+		name     string
+		from     []*Prop
+		to       []*Prop
+		expected []PropChange
+	}{
+		{
+			name:     "same props",
+			from:     []*Prop{prop(NewInteger("port", WithInterval(80, 9000)))},
+			to:       []*Prop{prop(NewInteger("port", WithInterval(80, 9000)))},
+			expected: []PropChange{},
+		},
+		{
+			name: "new optional and required props",
+			from: []*Prop{prop(NewInteger("port"))},
+			to: []*Prop{
+				prop(NewInteger("port")),
+				prop(NewString("host", WithRequired())),
+				prop(NewString("env")),
+			},
+			expected: []PropChange{
+				{Path: "/env", Kind: ADDED_PROP_CHANGE, Breaking: false},
+				{Path: "/host", Kind: ADDED_PROP_CHANGE, Breaking: true},
+			},
+		},
+		{
+			name: "removed prop",
+			from: []*Prop{prop(NewInteger("port")), prop(NewString("host"))},
+			to:   []*Prop{prop(NewInteger("port"))},
+			expected: []PropChange{
+				{Path: "/host", Kind: REMOVED_PROP_CHANGE, Breaking: true},
+			},
+		},
+		{
+			name: "type and array changes",
+			from: []*Prop{prop(NewInteger("port")), prop(NewString("hosts"))},
+			to:   []*Prop{prop(NewString("port")), prop(NewString("hosts", WithArray()))},
+			expected: []PropChange{
+				{Path: "/hosts", Kind: TYPE_CHANGE, Breaking: true},
+				{Path: "/port", Kind: TYPE_CHANGE, Breaking: true},
+			},
+		},
+		{
+			name: "required and optional",
+			from: []*Prop{prop(NewInteger("port")), prop(NewString("host", WithRequired()))},
+			to:   []*Prop{prop(NewInteger("port", WithRequired())), prop(NewString("host"))},
+			expected: []PropChange{
+				{Path: "/host", Kind: REQUIRED_CHANGE, Breaking: false},
+				{Path: "/port", Kind: REQUIRED_CHANGE, Breaking: true},
+			},
+		},
+		{
+			name: "narrowed and widened enums",
+			from: []*Prop{
+				prop(NewString("env", WithEnum("dev", "prod"))),
+				prop(NewString("level", WithEnum("info", "error"))),
+				prop(NewString("region")),
+			},
+			to: []*Prop{
+				prop(NewString("env", WithEnum("prod"))),
+				prop(NewString("level", WithEnum("info", "error", "debug"))),
+				prop(NewString("region", WithEnum("eu"))),
+			},
+			expected: []PropChange{
+				{Path: "/env", Kind: ENUM_CHANGE, Breaking: true},
+				{Path: "/level", Kind: ENUM_CHANGE, Breaking: false},
+				{Path: "/region", Kind: ENUM_CHANGE, Breaking: true},
+			},
+		},
+		{
+			name: "narrowed and widened intervals",
+			from: []*Prop{
+				prop(NewInteger("port", WithInterval(80, 9000))),
+				prop(NewInteger("workers", WithInterval(1, 8))),
+				prop(NewFloat("ratio")),
+			},
+			to: []*Prop{
+				prop(NewInteger("port", WithInterval(1024, 9000))),
+				prop(NewInteger("workers", WithInterval(1, 16))),
+				prop(NewFloat("ratio", WithInterval(0, 1))),
+			},
+			expected: []PropChange{
+				{Path: "/port", Kind: INTERVAL_CHANGE, Breaking: true},
+				{Path: "/ratio", Kind: INTERVAL_CHANGE, Breaking: true},
+				{Path: "/workers", Kind: INTERVAL_CHANGE, Breaking: false},
+			},
+		},
+		{
+			name: "new and removed regex",
+			from: []*Prop{prop(NewString("host")), prop(NewString("path", WithRegex("^/")))},
+			to:   []*Prop{prop(NewString("host", WithRegex("^[a-z]+$"))), prop(NewString("path"))},
+			expected: []PropChange{
+				{Path: "/host", Kind: REGEX_CHANGE, Breaking: true},
+				{Path: "/path", Kind: REGEX_CHANGE, Breaking: false},
+			},
+		},
+		{
+			name: "nested changes and default",
+			from: []*Prop{
+				prop(NewObject("db", WithProps(prop(NewInteger("port", WithDefault(5432)))))),
+			},
+			to: []*Prop{
+				prop(NewObject("db", WithProps(
+					prop(NewInteger("port", WithDefault(5433))),
+					prop(NewString("user", WithRequired())),
+				))),
+			},
+			expected: []PropChange{
+				{Path: "/db/port", Kind: DEFAULT_CHANGE, Breaking: false},
+				{Path: "/db/user", Kind: ADDED_PROP_CHANGE, Breaking: true},
+			},
+		},
+	}
+
+	toMap := func(ps []*Prop) map[string]*Prop {
+		m := make(map[string]*Prop)
+		for _, p := range ps {
+			m[p.Name()] = p
+		}
+		return m
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changes := CompareProps(toMap(test.from), toMap(test.to))
+
+			// Messages are descriptive only
+			for i := range changes {
+				assert.NotEmpty(t, changes[i].Message)
+				changes[i].Message = ""
+			}
+
+			assert.Equal(t, test.expected, changes)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package props
 import (
 	"errors"
 	"fmt"
+	"regexp"
 )
 
 type Option func(p *Prop) error
@@ -99,7 +100,13 @@ func WithRegex(regex string) Option {
 			return fmt.Errorf("%s cannot have regex", p.t)
 		}
 
+		re, err := regexp.Compile(regex)
+		if err != nil {
+			return fmt.Errorf("invalid regex %s: %s", regex, err.Error())
+		}
+
 		p.regex = regex
+		p.compiledRegex = re
 		return nil
 	}
 }
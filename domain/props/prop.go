@@ -4,6 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/aboglioli/configd/pkg/utils"
 )
 
 type Prop struct {
@@ -16,6 +20,9 @@ type Prop struct {
 	interval *Interval
 	props    map[string]*Prop
 	array    bool
+
+	// Compiled once when the prop is built
+	compiledRegex *regexp.Regexp
 }
 
 func newValue(name string, t PropType, opts ...Option) (*Prop, error) {
@@ -122,52 +129,63 @@ func (p *Prop) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&d)
 }
 
+// Validate returns the first violation found for v.
 func (p *Prop) Validate(v interface{}) error {
-	return p.validateWithArray(v, true)
+	if violations := p.Check("", v); len(violations) > 0 {
+		return violations[0]
+	}
+
+	return nil
 }
 
-func (p *Prop) validateWithArray(v interface{}, validateArray bool) error {
+// Check returns every violation found for v, which is located at path.
+func (p *Prop) Check(path string, v interface{}) []Violation {
+	return p.checkWithArray(path, v, true)
+}
+
+func (p *Prop) checkWithArray(path string, v interface{}, checkArray bool) []Violation {
+	violation := func(rule Rule, format string, args ...interface{}) []Violation {
+		return []Violation{{
+			Path:    path,
+			Rule:    rule,
+			Value:   v,
+			Message: fmt.Sprintf(format, args...),
+		}}
+	}
+
 	// Required
 	if p.IsRequired() && v == nil {
-		return errors.New("value is required")
+		return violation(REQUIRED_RULE, "value is required")
 	}
 
-	// Validate array elements
-	if validateArray && p.IsArray() {
+	// Check array elements
+	if checkArray && p.IsArray() {
 		arr, ok := v.([]interface{})
 		if !ok {
-			return fmt.Errorf("%v is not an array", v)
+			return violation(TYPE_RULE, "%v is not an array", v)
 		}
 
+		violations := make([]Violation, 0)
 		for i, v := range arr {
-			if err := p.validateWithArray(v, false); err != nil {
-				return fmt.Errorf("array item %d: %s", i, err.Error())
-			}
+			violations = append(violations, p.checkWithArray(utils.JoinPointer(path, i), v, false)...)
 		}
 
-		return nil
+		return violations
 	}
 
-	// Check for enum values
-	if len(p.Enum()) > 0 {
-		isInEnum := false
-		for _, e := range p.Enum() {
-			if v == e {
-				isInEnum = true
-				break
-			}
+	switch p.Type() {
+	case STRING:
+		s, ok := v.(string)
+		if !ok {
+			return violation(TYPE_RULE, "%v is not a string", v)
 		}
 
-		if !isInEnum {
-			return fmt.Errorf("%v is not in enum values %v", v, p.Enum())
+		if !p.isInEnum(v) {
+			return violation(ENUM_RULE, "%v is not in enum values %v", v, p.Enum())
 		}
-	}
 
-	switch p.Type() {
-	case STRING:
-		_, ok := v.(string)
-		if !ok {
-			return fmt.Errorf("%v is not a string", v)
+		if p.compiledRegex != nil && !p.compiledRegex.MatchString(s) {
+			return violation(REGEX_RULE, "%v does not match regex %s", v, p.regex)
 		}
 	case INT:
 		i, okInt := v.(int)
@@ -187,19 +205,25 @@ func (p *Prop) validateWithArray(v interface{}, validateArray bool) error {
 			} else if okFloat64 {
 				i = int(f64)
 			} else {
-				return fmt.Errorf("%v is not an integer", v)
+				return violation(TYPE_RULE, "%v is not an integer", v)
 			}
 		}
 
+		// Enum values for integers are stored as int
+		if !p.isInEnum(i) {
+			return violation(ENUM_RULE, "%v is not in enum values %v", v, p.Enum())
+		}
+
 		if p.Interval() != nil {
 			interval := p.Interval()
 
-			if i < int(interval.Min()) {
-				return fmt.Errorf("%v is lesser than the minimum value in interval", v)
+			// Compared as floats, bounds may exceed the int range
+			if float64(i) < interval.Min() {
+				return violation(INTERVAL_RULE, "%v is lesser than the minimum value in interval", v)
 			}
 
-			if i > int(interval.Max()) {
-				return fmt.Errorf("%v is greater than the maximum value in interval", v)
+			if float64(i) > interval.Max() {
+				return violation(INTERVAL_RULE, "%v is greater than the maximum value in interval", v)
 			}
 		}
 	case FLOAT:
@@ -210,47 +234,106 @@ func (p *Prop) validateWithArray(v interface{}, validateArray bool) error {
 			if okFloat32 {
 				f = float64(f32)
 			} else {
-				return fmt.Errorf("%v is not a float", v)
+				return violation(TYPE_RULE, "%v is not a float", v)
 			}
 		}
 
+		if !p.isInEnum(f) {
+			return violation(ENUM_RULE, "%v is not in enum values %v", v, p.Enum())
+		}
+
 		if p.Interval() != nil {
 			interval := p.Interval()
 
 			if f < float64(interval.Min()) {
-				return fmt.Errorf("%v is lesser than the minimum value in interval", v)
+				return violation(INTERVAL_RULE, "%v is lesser than the minimum value in interval", v)
 			}
 
 			if f > float64(interval.Max()) {
-				return fmt.Errorf("%v is greater than the maximum value in interval", v)
+				return violation(INTERVAL_RULE, "%v is greater than the maximum value in interval", v)
 			}
 		}
 	case BOOL:
 		_, ok := v.(bool)
 		if !ok {
-			return fmt.Errorf("%v is not a boolean", v)
+			return violation(TYPE_RULE, "%v is not a boolean", v)
+		}
+
+		if !p.isInEnum(v) {
+			return violation(ENUM_RULE, "%v is not in enum values %v", v, p.Enum())
 		}
 	case OBJECT:
 		obj, ok := v.(map[string]interface{})
 		if !ok {
-			return fmt.Errorf("%v is not an object", v)
+			return violation(TYPE_RULE, "%v is not an object", v)
 		}
 
 		if len(p.Props()) == 0 {
-			return fmt.Errorf("%v does not have subprops", v)
+			return violation(TYPE_RULE, "%v does not have subprops", v)
 		}
 
-		for k, p := range p.Props() {
-			v, ok := obj[k]
-			if !ok {
-				return fmt.Errorf("missing prop for key %s", k)
-			}
+		return CheckObject(path, p.Props(), obj)
+	}
+
+	return nil
+}
+
+func (p *Prop) isInEnum(v interface{}) bool {
+	if len(p.Enum()) == 0 {
+		return true
+	}
 
-			if err := p.validateWithArray(v, true); err != nil {
-				return fmt.Errorf("key %s: %s", k, err.Error())
+	for _, e := range p.Enum() {
+		if v == e {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckObject checks every key of obj, located at path, against props and
+// reports missing required keys and unknown keys. Violations are sorted by key.
+func CheckObject(path string, props map[string]*Prop, obj map[string]interface{}) []Violation {
+	keys := make([]string, 0, len(props)+len(obj))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	for k := range obj {
+		if _, ok := props[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	violations := make([]Violation, 0)
+	for _, k := range keys {
+		p, isProp := props[k]
+		v, isValue := obj[k]
+
+		switch {
+		case !isProp:
+			violations = append(violations, Violation{
+				Path:    utils.JoinPointer(path, k),
+				Rule:    UNKNOWN_KEY_RULE,
+				Value:   v,
+				Message: fmt.Sprintf("unknown key %s", k),
+			})
+		case !isValue:
+			// Absent optional props are satisfied, their default is used
+			if !p.IsRequired() {
+				continue
 			}
+
+			violations = append(violations, Violation{
+				Path:    utils.JoinPointer(path, k),
+				Rule:    REQUIRED_RULE,
+				Message: fmt.Sprintf("missing prop for key %s", k),
+			})
+		default:
+			violations = append(violations, p.Check(utils.JoinPointer(path, k), v)...)
 		}
 	}
 
-	return nil
+	return violations
 }
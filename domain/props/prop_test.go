@@ -87,6 +87,13 @@ func TestBuildProps(t *testing.T) {
 			},
 			err: true,
 		},
+		{
+			name: "invalid regex",
+			makeProp: func() (*Prop, error) {
+				return NewString("version", WithRegex("v[0-9+"))
+			},
+			err: true,
+		},
 		{
 			name: "non-numeric with interval",
 			makeProp: func() (*Prop, error) {
@@ -261,6 +268,39 @@ func TestValidateValue(t *testing.T) {
 			},
 			err: true,
 		},
+		{
+			name: "string not matching regex",
+			makeProp: func(t *test) *Prop {
+				version, err := NewString("version", WithRegex("^v[0-9]+$"))
+				utils.Ok(err)
+
+				return version
+			},
+			value: "version1",
+			err:   true,
+		},
+		{
+			name: "array item not matching regex",
+			makeProp: func(t *test) *Prop {
+				versions, err := NewString("versions", WithArray(), WithRegex("^v[0-9]+$"))
+				utils.Ok(err)
+
+				return versions
+			},
+			value: []interface{}{"v1", "v2.1"},
+			err:   true,
+		},
+		{
+			name: "string matching regex",
+			makeProp: func(t *test) *Prop {
+				version, err := NewString("version", WithRegex("v[0-9]+"))
+				utils.Ok(err)
+
+				return version
+			},
+			value: "v12",
+			err:   false,
+		},
 		{
 			name: "valid object with sub props",
 			makeProp: func(t *test) *Prop {
@@ -0,0 +1,79 @@
+package props
+
+// ResolveObject returns a copy of obj where missing keys are filled with the
+// defaults defined by props, recursively through objects and arrays.
+func ResolveObject(props map[string]*Prop, obj map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		res[k] = v
+	}
+
+	for k, p := range props {
+		v, ok := res[k]
+		if !ok {
+			if def, ok := p.resolvedDefault(); ok {
+				res[k] = def
+			}
+
+			continue
+		}
+
+		res[k] = p.resolve(v)
+	}
+
+	return res
+}
+
+func (p *Prop) resolve(v interface{}) interface{} {
+	if !p.IsArray() {
+		return p.resolveItem(v)
+	}
+
+	arr, ok := v.([]interface{})
+	if !ok {
+		return v
+	}
+
+	res := make([]interface{}, len(arr))
+	for i, item := range arr {
+		res[i] = p.resolveItem(item)
+	}
+
+	return res
+}
+
+func (p *Prop) resolveItem(v interface{}) interface{} {
+	if p.Type() != OBJECT {
+		return v
+	}
+
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+
+	return ResolveObject(p.Props(), obj)
+}
+
+// resolvedDefault returns the value used when the prop is missing. Objects
+// are built from the defaults of their subprops.
+func (p *Prop) resolvedDefault() (interface{}, bool) {
+	if p.IsArray() {
+		return nil, false
+	}
+
+	if p.Type() == OBJECT {
+		obj := ResolveObject(p.Props(), make(map[string]interface{}))
+		if len(obj) == 0 {
+			return nil, false
+		}
+
+		return obj, true
+	}
+
+	if p.def == nil {
+		return nil, false
+	}
+
+	return p.def, true
+}
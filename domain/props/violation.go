@@ -0,0 +1,33 @@
+package props
+
+import (
+	"fmt"
+)
+
+type Rule string
+
+const (
+	TYPE_RULE        Rule = "type"
+	ENUM_RULE        Rule = "enum"
+	INTERVAL_RULE    Rule = "interval"
+	REGEX_RULE       Rule = "regex"
+	REQUIRED_RULE    Rule = "required"
+	UNKNOWN_KEY_RULE Rule = "unknown_key"
+)
+
+// Violation is a failed rule for the value located at Path, a JSON pointer
+// relative to the validated document.
+type Violation struct {
+	Path    string      `json:"path"`
+	Rule    Rule        `json:"rule"`
+	Value   interface{} `json:"value"`
+	Message string      `json:"message"`
+}
+
+func (v Violation) Error() string {
+	if v.Path == "" {
+		return v.Message
+	}
+
+	return fmt.Sprintf("path %s: %s", v.Path, v.Message)
+}
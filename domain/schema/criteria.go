@@ -0,0 +1,12 @@
+package schema
+
+import (
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+// Criteria filters schemas. Zero values match everything.
+type Criteria struct {
+	// Case-insensitive substring of the name
+	Name       string
+	Pagination models.Pagination
+}
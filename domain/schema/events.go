@@ -8,12 +8,17 @@ var (
 	SchemaCreatedTopic      = events.NewTopic("schema", "created")
 	SchemaNameChangedTopic  = events.NewTopic("schema", "name_changed")
 	SchemaPropsChangedTopic = events.NewTopic("schema", "props_changed")
+	SchemaDeletedTopic      = events.NewTopic("schema", "deleted")
+	SchemaRestoredTopic     = events.NewTopic("schema", "restored")
+
+	SchemaValidationModeChangedTopic = events.NewTopic("schema", "validation_mode_changed")
 )
 
 type SchemaCreated struct {
-	Id    string                 `json:"id"`
-	Name  string                 `json:"name"`
-	Props map[string]interface{} `json:"props"`
+	Id             string                 `json:"id"`
+	Name           string                 `json:"name"`
+	ValidationMode string                 `json:"validation_mode"`
+	Props          map[string]interface{} `json:"props"`
 }
 
 type SchemaNameChanged struct {
@@ -21,7 +26,20 @@ type SchemaNameChanged struct {
 	Name string `json:"name"`
 }
 
+type SchemaValidationModeChanged struct {
+	Id             string `json:"id"`
+	ValidationMode string `json:"validation_mode"`
+}
+
 type SchemaPropsChanged struct {
 	Id    string                 `json:"id"`
 	Props map[string]interface{} `json:"props"`
 }
+
+type SchemaDeleted struct {
+	Id string `json:"id"`
+}
+
+type SchemaRestored struct {
+	Id string `json:"id"`
+}
@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"fmt"
+
+	"github.com/aboglioli/configd/domain/props"
+	"github.com/aboglioli/configd/pkg/codec"
+)
+
+// Format is the document format describing schema props.
+type Format string
+
+const (
+	// Props described with $schema keys, see PropsFromMap
+	CONFIGD_FORMAT Format = "configd"
+	// JSON Schema draft 2020-12, see PropsFromJsonSchema
+	JSON_SCHEMA_FORMAT Format = "jsonschema"
+)
+
+// NewFormat parses a format, the configd one being the default.
+func NewFormat(format string) (Format, error) {
+	switch format {
+	case "", string(CONFIGD_FORMAT):
+		return CONFIGD_FORMAT, nil
+	case string(JSON_SCHEMA_FORMAT):
+		return JSON_SCHEMA_FORMAT, nil
+	}
+
+	return "", fmt.Errorf("invalid schema format %s", format)
+}
+
+func (f Format) String() string {
+	return string(f)
+}
+
+// Parse converts a document in this format into props.
+func (f Format) Parse(doc map[string]interface{}) ([]*props.Prop, error) {
+	if f == JSON_SCHEMA_FORMAT {
+		return PropsFromJsonSchema(doc)
+	}
+
+	return PropsFromMap(doc)
+}
+
+// Export describes the schema props in this format, rendering keys in the
+// order they were written.
+func (f Format) Export(s *Schema) codec.Ordered {
+	if f == JSON_SCHEMA_FORMAT {
+		return codec.Ordered{Value: s.ToJsonSchema()}
+	}
+
+	return codec.Ordered{Value: s.ToMap(), Order: s.KeyOrder()}
+}
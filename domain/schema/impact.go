@@ -0,0 +1,86 @@
+package schema
+
+import (
+	"sort"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/props"
+)
+
+// InvalidConfig is a config that would stop passing validation, or that
+// cannot be migrated.
+type InvalidConfig struct {
+	Id         string            `json:"id"`
+	Violations []props.Violation `json:"violations"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// Impact reports how replacing the schema props affects its configs.
+type Impact struct {
+	Changes        []props.PropChange `json:"changes"`
+	InvalidConfigs []InvalidConfig    `json:"invalid_configs"`
+	Breaking       bool               `json:"breaking"`
+}
+
+// NewImpact compares the current schema props with the new ones and
+// re-validates configs, after applying migrations, against them. Only configs
+// valid today and invalid with the new props are reported, besides configs
+// failing to migrate. Configs pinned to a schema version are not affected and
+// are skipped.
+func NewImpact(
+	s *Schema,
+	configs []*config.Config,
+	migrations []*config.Migration,
+	ps ...*props.Prop,
+) *Impact {
+	next := make(map[string]*props.Prop)
+	for _, p := range ps {
+		next[p.Name()] = p
+	}
+
+	impact := &Impact{
+		Changes:        props.CompareProps(s.props, next),
+		InvalidConfigs: make([]InvalidConfig, 0),
+	}
+
+	for _, c := range configs {
+		if c.SchemaVersion() > 0 {
+			continue
+		}
+
+		data, err := config.Migrate(c.Config(), migrations...)
+		if err != nil {
+			impact.InvalidConfigs = append(impact.InvalidConfigs, InvalidConfig{
+				Id:         c.Base().Id().Value(),
+				Violations: make([]props.Violation, 0),
+				Error:      err.Error(),
+			})
+			continue
+		}
+
+		if len(props.CheckObject("", s.props, c.Config())) > 0 {
+			continue
+		}
+
+		if violations := props.CheckObject("", next, data); len(violations) > 0 {
+			impact.InvalidConfigs = append(impact.InvalidConfigs, InvalidConfig{
+				Id:         c.Base().Id().Value(),
+				Violations: violations,
+			})
+		}
+	}
+
+	sort.Slice(impact.InvalidConfigs, func(i, j int) bool {
+		return impact.InvalidConfigs[i].Id < impact.InvalidConfigs[j].Id
+	})
+
+	impact.Breaking = len(impact.InvalidConfigs) > 0
+	for _, change := range impact.Changes {
+		if change.Breaking {
+			impact.Breaking = true
+			break
+		}
+	}
+
+	return impact
+}
@@ -0,0 +1,114 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/props"
+	"github.com/aboglioli/configd/pkg/models"
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImpact(t *testing.T) {
+	port, err := props.NewInteger("port", props.WithInterval(80, 9000))
+	utils.Ok(err)
+
+	id, err := models.BuildId("my-schema")
+	utils.Ok(err)
+
+	name, err := NewName("My Schema")
+	utils.Ok(err)
+
+	s, err := NewSchema(id, name, WARN_VALIDATION, nil, port)
+	utils.Ok(err)
+
+	newConfig := func(id string, schemaVersion uint, data config.ConfigData) *config.Config {
+		configId, err := models.BuildId(id)
+		utils.Ok(err)
+
+		configName, err := config.NewName(id)
+		utils.Ok(err)
+
+		c, err := config.NewConfig(configId, s.Base().Id(), schemaVersion, configName, data, nil)
+		utils.Ok(err)
+
+		return c
+	}
+
+	configs := []*config.Config{
+		newConfig("low-port", 0, config.ConfigData{"port": 80}),
+		newConfig("high-port", 0, config.ConfigData{"port": 8080}),
+		newConfig("pinned", 1, config.ConfigData{"port": 80}),
+		// Already invalid
+		newConfig("invalid", 0, config.ConfigData{"port": "80"}),
+	}
+
+	t.Run("compatible", func(t *testing.T) {
+		widened, err := props.NewInteger("port", props.WithInterval(1, 9000))
+		utils.Ok(err)
+
+		impact := NewImpact(s, configs, nil, widened)
+		assert.False(t, impact.Breaking)
+		assert.Len(t, impact.Changes, 1)
+		assert.Empty(t, impact.InvalidConfigs)
+	})
+
+	t.Run("breaking", func(t *testing.T) {
+		narrowed, err := props.NewInteger("port", props.WithInterval(1024, 9000))
+		utils.Ok(err)
+
+		impact := NewImpact(s, configs, nil, narrowed)
+		assert.True(t, impact.Breaking)
+		if assert.Len(t, impact.InvalidConfigs, 1) {
+			assert.Equal(t, "low-port", impact.InvalidConfigs[0].Id)
+			assert.Equal(t, props.INTERVAL_RULE, impact.InvalidConfigs[0].Violations[0].Rule)
+		}
+	})
+
+	t.Run("migrated configs", func(t *testing.T) {
+		renamed, err := props.NewInteger("listen_port", props.WithRequired())
+		utils.Ok(err)
+
+		rename, err := config.NewMigration(config.RENAME_MIGRATION, "/port", "listen_port", nil, "")
+		utils.Ok(err)
+
+		impact := NewImpact(s, configs, []*config.Migration{rename}, renamed)
+		assert.True(t, impact.Breaking)
+		assert.Empty(t, impact.InvalidConfigs)
+
+		// Without the migration every valid config misses the new prop
+		impact = NewImpact(s, configs, nil, renamed)
+		assert.Len(t, impact.InvalidConfigs, 2)
+
+		convert, err := config.NewMigration(config.CONVERT_MIGRATION, "/port", "", nil, "bool")
+		utils.Ok(err)
+
+		// Failing migrations are reported even for already invalid configs
+		impact = NewImpact(s, configs, []*config.Migration{convert}, port)
+		if assert.Len(t, impact.InvalidConfigs, 3) {
+			assert.Equal(t, "high-port", impact.InvalidConfigs[0].Id)
+			assert.Empty(t, impact.InvalidConfigs[0].Error)
+			assert.Equal(t, "invalid", impact.InvalidConfigs[1].Id)
+			assert.NotEmpty(t, impact.InvalidConfigs[1].Error)
+		}
+	})
+
+	t.Run("breaking without affected configs", func(t *testing.T) {
+		host, err := props.NewString("host")
+		utils.Ok(err)
+
+		regex, err := props.NewString("host", props.WithRegex("^[a-z]+$"))
+		utils.Ok(err)
+
+		impact := NewImpact(s, nil, nil, port, host)
+		assert.False(t, impact.Breaking)
+
+		impact = NewImpact(s, nil, nil, port, regex)
+		assert.False(t, impact.Breaking)
+
+		impact = NewImpact(s, nil, nil, regex)
+		assert.True(t, impact.Breaking)
+		assert.Empty(t, impact.InvalidConfigs)
+	})
+}
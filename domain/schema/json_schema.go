@@ -0,0 +1,346 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/aboglioli/configd/domain/props"
+)
+
+const (
+	JSON_SCHEMA_DIALECT = "https://json-schema.org/draft/2020-12/schema"
+)
+
+// Keywords changing validation in ways props cannot represent. Annotations
+// like title or description are ignored instead.
+var unsupportedJsonSchemaKeywords = []string{
+	"$ref",
+	"$dynamicRef",
+	"allOf",
+	"anyOf",
+	"oneOf",
+	"not",
+	"if",
+	"then",
+	"else",
+	"exclusiveMinimum",
+	"exclusiveMaximum",
+	"multipleOf",
+	"patternProperties",
+	"prefixItems",
+}
+
+// PropsFromJsonSchema converts a JSON Schema (draft 2020-12) document
+// describing an object into props. Objects never accept unknown keys,
+// whatever additionalProperties says.
+func PropsFromJsonSchema(doc map[string]interface{}) ([]*props.Prop, error) {
+	if len(doc) == 0 {
+		return nil, errors.New("empty schema")
+	}
+
+	if t, _ := jsonSchemaType(doc); t != "object" {
+		return nil, errors.New("JSON Schema root must be an object")
+	}
+
+	ps, err := parseJsonSchemaObject("", doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ps) == 0 {
+		return nil, errors.New("JSON Schema root does not have properties")
+	}
+
+	return ps, nil
+}
+
+func parseJsonSchemaObject(path string, doc map[string]interface{}) ([]*props.Prop, error) {
+	if err := checkJsonSchemaKeywords(path, doc); err != nil {
+		return nil, err
+	}
+
+	required := make(map[string]bool)
+	if raw, ok := doc["required"]; ok {
+		names, ok := raw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s: required must be an array", jsonSchemaPath(path))
+		}
+
+		for _, name := range names {
+			name, ok := name.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: required must contain property names", jsonSchemaPath(path))
+			}
+
+			required[name] = true
+		}
+	}
+
+	properties := make(map[string]interface{})
+	if raw, ok := doc["properties"]; ok {
+		properties, ok = raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s: properties must be an object", jsonSchemaPath(path))
+		}
+	}
+
+	for name := range required {
+		if _, ok := properties[name]; !ok {
+			return nil, fmt.Errorf("%s: required property %s is not defined", jsonSchemaPath(path), name)
+		}
+	}
+
+	ps := make([]*props.Prop, 0, len(properties))
+	for name, raw := range properties {
+		propDoc, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s: invalid property", jsonSchemaPath(path+"/"+name))
+		}
+
+		p, err := parseJsonSchemaProp(path+"/"+name, name, propDoc, required[name])
+		if err != nil {
+			return nil, err
+		}
+
+		ps = append(ps, p)
+	}
+
+	return ps, nil
+}
+
+func parseJsonSchemaProp(path, name string, doc map[string]interface{}, required bool) (*props.Prop, error) {
+	if err := checkJsonSchemaKeywords(path, doc); err != nil {
+		return nil, err
+	}
+
+	opts := make([]props.Option, 0)
+	if required {
+		opts = append(opts, props.WithRequired())
+	}
+
+	t, err := jsonSchemaType(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", jsonSchemaPath(path), err)
+	}
+
+	// Arrays are described by their items
+	if t == "array" {
+		items, ok := doc["items"].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s: arrays must define items", jsonSchemaPath(path))
+		}
+
+		if err := checkJsonSchemaKeywords(path, items); err != nil {
+			return nil, err
+		}
+
+		t, err = jsonSchemaType(items)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", jsonSchemaPath(path), err)
+		}
+
+		if t == "array" {
+			return nil, fmt.Errorf("%s: nested arrays are not supported", jsonSchemaPath(path))
+		}
+
+		doc = items
+		opts = append(opts, props.WithArray())
+	}
+
+	if t == "object" {
+		// Objects are always required, optional ones would change on export
+		if !required {
+			return nil, fmt.Errorf("%s: objects must be required", jsonSchemaPath(path))
+		}
+
+		subProps, err := parseJsonSchemaObject(path, doc)
+		if err != nil {
+			return nil, err
+		}
+
+		return props.NewObject(name, append(opts, props.WithProps(subProps...))...)
+	}
+
+	if def, ok := doc["default"]; ok {
+		opts = append(opts, props.WithDefault(def))
+	}
+
+	if raw, ok := doc["enum"]; ok {
+		enum, ok := raw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s: enum must be an array", jsonSchemaPath(path))
+		}
+
+		opts = append(opts, props.WithEnum(enum...))
+	}
+
+	if raw, ok := doc["pattern"]; ok {
+		pattern, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: pattern must be a string", jsonSchemaPath(path))
+		}
+
+		opts = append(opts, props.WithRegex(pattern))
+	}
+
+	min, hasMin := doc["minimum"].(float64)
+	max, hasMax := doc["maximum"].(float64)
+	if hasMin || hasMax {
+		// Open sides are kept as the largest finite values
+		if !hasMin {
+			min = -math.MaxFloat64
+		}
+		if !hasMax {
+			max = math.MaxFloat64
+		}
+
+		opts = append(opts, props.WithInterval(min, max))
+	}
+
+	var p *props.Prop
+	switch t {
+	case "string":
+		p, err = props.NewString(name, opts...)
+	case "integer":
+		p, err = props.NewInteger(name, opts...)
+	case "number":
+		p, err = props.NewFloat(name, opts...)
+	case "boolean":
+		p, err = props.NewBool(name, opts...)
+	default:
+		return nil, fmt.Errorf("%s: unsupported type %s", jsonSchemaPath(path), t)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", jsonSchemaPath(path), err)
+	}
+
+	return p, nil
+}
+
+// jsonSchemaType returns the type of a JSON Schema. Props cannot be null, so
+// nullable types, like ["string", "null"], are not supported.
+func jsonSchemaType(doc map[string]interface{}) (string, error) {
+	switch t := doc["type"].(type) {
+	case string:
+		if t == "null" {
+			break
+		}
+
+		return t, nil
+	case []interface{}:
+		if len(t) != 1 {
+			break
+		}
+
+		if v, ok := t[0].(string); ok && v != "null" {
+			return v, nil
+		}
+	case nil:
+		return "", errors.New("type is required")
+	}
+
+	return "", fmt.Errorf("unsupported type %v", doc["type"])
+}
+
+func checkJsonSchemaKeywords(path string, doc map[string]interface{}) error {
+	for _, keyword := range unsupportedJsonSchemaKeywords {
+		if _, ok := doc[keyword]; ok {
+			return fmt.Errorf("%s: unsupported keyword %s", jsonSchemaPath(path), keyword)
+		}
+	}
+
+	return nil
+}
+
+func jsonSchemaPath(path string) string {
+	if path == "" {
+		return "root"
+	}
+
+	return path
+}
+
+// ToJsonSchema exports the schema as a JSON Schema (draft 2020-12) document.
+func (s *Schema) ToJsonSchema() map[string]interface{} {
+	doc := propsToJsonSchema(s.props)
+	doc["$schema"] = JSON_SCHEMA_DIALECT
+	doc["title"] = s.name.Value()
+
+	return doc
+}
+
+func propsToJsonSchema(ps map[string]*props.Prop) map[string]interface{} {
+	properties := make(map[string]interface{})
+	required := make([]string, 0)
+
+	for name, p := range ps {
+		if p.IsRequired() {
+			required = append(required, name)
+		}
+
+		var doc map[string]interface{}
+		if p.Type() == props.OBJECT {
+			doc = propsToJsonSchema(p.Props())
+		} else {
+			doc = map[string]interface{}{
+				"type": jsonSchemaTypeOf(p.Type()),
+			}
+
+			if p.Default() != nil {
+				doc["default"] = p.Default()
+			}
+
+			if len(p.Enum()) > 0 {
+				doc["enum"] = p.Enum()
+			}
+
+			if p.Regex() != "" {
+				doc["pattern"] = p.Regex()
+			}
+
+			if i := p.Interval(); i != nil {
+				if i.Min() > -math.MaxFloat64 {
+					doc["minimum"] = i.Min()
+				}
+				if i.Max() < math.MaxFloat64 {
+					doc["maximum"] = i.Max()
+				}
+			}
+		}
+
+		if p.IsArray() {
+			doc = map[string]interface{}{
+				"type":  "array",
+				"items": doc,
+			}
+		}
+
+		properties[name] = doc
+	}
+
+	sort.Strings(required)
+
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+func jsonSchemaTypeOf(t props.PropType) string {
+	switch t {
+	case props.INT:
+		return "integer"
+	case props.FLOAT:
+		return "number"
+	case props.BOOL:
+		return "boolean"
+	case props.OBJECT:
+		return "object"
+	}
+
+	return "string"
+}
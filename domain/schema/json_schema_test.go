@@ -0,0 +1,347 @@
+package schema
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/props"
+	"github.com/aboglioli/configd/pkg/models"
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPropsFromJsonSchema(t *testing.T) {
+	type test struct {
+		name     string
+		json     string
+		expected func() []*props.Prop
+		err      bool
+	}
+
+	tests := []test{
+		{
+			name: "valid",
+			json: `
+				{
+				  "$schema": "https://json-schema.org/draft/2020-12/schema",
+				  "title": "Service",
+				  "type": "object",
+				  "required": ["url", "db"],
+				  "properties": {
+					"url": { "type": "string", "pattern": "^https?://", "description": "Public URL" },
+					"env": { "type": "string", "enum": ["dev", "prod"], "default": "dev" },
+					"port": { "type": "integer", "minimum": 80, "maximum": 9000, "default": 8080 },
+					"workers": { "type": "integer", "minimum": 1 },
+					"ratio": { "type": ["number"] },
+					"debug": { "type": "boolean" },
+					"hosts": { "type": "array", "items": { "type": "string" } },
+					"db": {
+					  "type": "object",
+					  "required": ["user"],
+					  "properties": {
+						"user": { "type": "string" }
+					  }
+					}
+				  }
+				}
+			`,
+			expected: func() []*props.Prop {
+				url, err := props.NewString("url", props.WithRequired(), props.WithRegex("^https?://"))
+				utils.Ok(err)
+
+				env, err := props.NewString("env", props.WithDefault("dev"), props.WithEnum("dev", "prod"))
+				utils.Ok(err)
+
+				port, err := props.NewInteger("port", props.WithDefault(8080), props.WithInterval(80, 9000))
+				utils.Ok(err)
+
+				workers, err := props.NewInteger("workers", props.WithInterval(1, math.MaxFloat64))
+				utils.Ok(err)
+
+				ratio, err := props.NewFloat("ratio")
+				utils.Ok(err)
+
+				debug, err := props.NewBool("debug")
+				utils.Ok(err)
+
+				hosts, err := props.NewString("hosts", props.WithArray())
+				utils.Ok(err)
+
+				user, err := props.NewString("user", props.WithRequired())
+				utils.Ok(err)
+
+				db, err := props.NewObject("db", props.WithProps(user))
+				utils.Ok(err)
+
+				return []*props.Prop{url, env, port, workers, ratio, debug, hosts, db}
+			},
+		},
+		{
+			name: "array of objects",
+			json: `
+				{
+				  "type": "object",
+				  "required": ["services"],
+				  "properties": {
+					"services": {
+					  "type": "array",
+					  "items": {
+						"type": "object",
+						"properties": { "name": { "type": "string" } }
+					  }
+					}
+				  }
+				}
+			`,
+			expected: func() []*props.Prop {
+				name, err := props.NewString("name")
+				utils.Ok(err)
+
+				services, err := props.NewObject("services", props.WithArray(), props.WithProps(name))
+				utils.Ok(err)
+
+				return []*props.Prop{services}
+			},
+		},
+		{
+			name: "root is not an object",
+			json: `{ "type": "string" }`,
+			err:  true,
+		},
+		{
+			name: "undefined required property",
+			json: `{ "type": "object", "required": ["port"], "properties": { "host": { "type": "string" } } }`,
+			err:  true,
+		},
+		{
+			name: "missing type",
+			json: `{ "type": "object", "properties": { "host": {} } }`,
+			err:  true,
+		},
+		{
+			name: "unsupported keyword",
+			json: `{ "type": "object", "properties": { "host": { "anyOf": [{ "type": "string" }] } } }`,
+			err:  true,
+		},
+		{
+			name: "nested arrays",
+			json: `{ "type": "object", "properties": { "m": { "type": "array", "items": { "type": "array", "items": { "type": "integer" } } } } }`,
+			err:  true,
+		},
+		{
+			name: "nullable type",
+			json: `{ "type": "object", "properties": { "ratio": { "type": ["number", "null"] } } }`,
+			err:  true,
+		},
+		{
+			name: "optional object",
+			json: `{ "type": "object", "properties": { "db": { "type": "object", "properties": {} } } }`,
+			err:  true,
+		},
+		{
+			name: "optional array of objects",
+			json: `{ "type": "object", "properties": { "dbs": { "type": "array", "items": { "type": "object", "properties": {} } } } }`,
+			err:  true,
+		},
+		{
+			name: "invalid default",
+			json: `{ "type": "object", "properties": { "port": { "type": "integer", "default": "80" } } }`,
+			err:  true,
+		},
+	}
+
+	toMap := func(ps []*props.Prop) map[string]*props.Prop {
+		m := make(map[string]*props.Prop)
+		for _, p := range ps {
+			m[p.Name()] = p
+		}
+		return m
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var doc map[string]interface{}
+			utils.Ok(json.Unmarshal([]byte(test.json), &doc))
+
+			ps, err := PropsFromJsonSchema(doc)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, propsToMap(toMap(test.expected())), propsToMap(toMap(ps)))
+			}
+		})
+	}
+}
+
+func TestToJsonSchema(t *testing.T) {
+	port, err := props.NewInteger("port", props.WithRequired(), props.WithDefault(8080), props.WithInterval(80, 9000))
+	utils.Ok(err)
+
+	workers, err := props.NewInteger("workers", props.WithInterval(1, math.MaxFloat64))
+	utils.Ok(err)
+
+	hosts, err := props.NewString("hosts", props.WithArray(), props.WithRegex("^[a-z]+$"))
+	utils.Ok(err)
+
+	user, err := props.NewString("user")
+	utils.Ok(err)
+
+	db, err := props.NewObject("db", props.WithProps(user))
+	utils.Ok(err)
+
+	id, err := models.BuildId("service")
+	utils.Ok(err)
+
+	name, err := NewName("Service")
+	utils.Ok(err)
+
+	s, err := NewSchema(id, name, WARN_VALIDATION, nil, port, workers, hosts, db)
+	utils.Ok(err)
+
+	doc := s.ToJsonSchema()
+
+	b, err := json.Marshal(doc)
+	utils.Ok(err)
+
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title": "Service",
+		"type": "object",
+		"additionalProperties": false,
+		"required": ["db", "port"],
+		"properties": {
+			"port": { "type": "integer", "default": 8080, "minimum": 80, "maximum": 9000 },
+			"workers": { "type": "integer", "minimum": 1 },
+			"hosts": { "type": "array", "items": { "type": "string", "pattern": "^[a-z]+$" } },
+			"db": {
+				"type": "object",
+				"additionalProperties": false,
+				"required": [],
+				"properties": { "user": { "type": "string" } }
+			}
+		}
+	}`, string(b))
+
+	// Exported documents are imported back into the same props
+	var imported map[string]interface{}
+	utils.Ok(json.Unmarshal(b, &imported))
+
+	ps, err := PropsFromJsonSchema(imported)
+	if assert.NoError(t, err) {
+		s2, err := NewSchema(id, name, WARN_VALIDATION, nil, ps...)
+		utils.Ok(err)
+
+		assert.Equal(t, s.ToMap(), s2.ToMap())
+		assert.True(t, s2.Check(config.ConfigData{"port": 80, "db": map[string]interface{}{}, "workers": 1e12}).Valid)
+	}
+}
+
+func TestJsonSchemaRoundTrip(t *testing.T) {
+	type test struct {
+		name string
+		json string
+		err  bool
+	}
+
+	tests := []test{
+		{
+			name: "required objects",
+			json: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"title": "Service",
+				"type": "object",
+				"additionalProperties": false,
+				"required": ["db", "replicas"],
+				"properties": {
+					"db": {
+						"type": "object",
+						"additionalProperties": false,
+						"required": ["pool"],
+						"properties": {
+							"host": { "type": "string" },
+							"pool": {
+								"type": "object",
+								"additionalProperties": false,
+								"required": [],
+								"properties": { "size": { "type": "integer" } }
+							}
+						}
+					},
+					"replicas": {
+						"type": "array",
+						"items": {
+							"type": "object",
+							"additionalProperties": false,
+							"required": [],
+							"properties": { "host": { "type": "string" } }
+						}
+					}
+				}
+			}`,
+		},
+		{
+			name: "optional nested object",
+			json: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"title": "Service",
+				"type": "object",
+				"additionalProperties": false,
+				"required": ["db"],
+				"properties": {
+					"db": {
+						"type": "object",
+						"additionalProperties": false,
+						"required": [],
+						"properties": {
+							"pool": {
+								"type": "object",
+								"additionalProperties": false,
+								"required": [],
+								"properties": { "size": { "type": "integer" } }
+							}
+						}
+					}
+				}
+			}`,
+			err: true,
+		},
+	}
+
+	id, err := models.BuildId("service")
+	utils.Ok(err)
+
+	name, err := NewName("Service")
+	utils.Ok(err)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var doc map[string]interface{}
+			utils.Ok(json.Unmarshal([]byte(test.json), &doc))
+
+			ps, err := PropsFromJsonSchema(doc)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			s, err := NewSchema(id, name, WARN_VALIDATION, nil, ps...)
+			utils.Ok(err)
+
+			b, err := json.Marshal(s.ToJsonSchema())
+			utils.Ok(err)
+
+			assert.JSONEq(t, test.json, string(b))
+		})
+	}
+}
@@ -376,6 +376,21 @@ func TestPropsFromMap(t *testing.T) {
 				return []*props.Prop{env}
 			},
 		},
+		{
+			name: "invalid regex",
+			m: map[string]interface{}{
+				"version": map[string]interface{}{
+					"$schema": map[string]interface{}{
+						"type":  "string",
+						"regex": "v[0-9+",
+					},
+				},
+			},
+			expected: func(t *test) []*props.Prop {
+				return nil
+			},
+			err: true,
+		},
 	}
 
 	for _, test := range tests {
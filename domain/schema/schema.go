@@ -2,10 +2,11 @@ package schema
 
 import (
 	"errors"
-	"fmt"
+	"reflect"
 
 	"github.com/aboglioli/configd/domain/config"
 	"github.com/aboglioli/configd/domain/props"
+	"github.com/aboglioli/configd/pkg/codec"
 	"github.com/aboglioli/configd/pkg/events"
 	"github.com/aboglioli/configd/pkg/models"
 )
@@ -13,13 +14,18 @@ import (
 type Schema struct {
 	agg *models.AggregateRoot
 
-	name  Name
-	props map[string]*props.Prop
+	name           Name
+	validationMode ValidationMode
+	props          map[string]*props.Prop
+	// Order of the keys of the props as written, to render them the same way
+	keyOrder codec.KeyOrder
 }
 
 func BuildSchema(
-	id models.Id,
+	agg *models.AggregateRoot,
 	name Name,
+	validationMode ValidationMode,
+	keyOrder codec.KeyOrder,
 	ps ...*props.Prop,
 ) (*Schema, error) {
 	if len(ps) == 0 {
@@ -31,20 +37,28 @@ func BuildSchema(
 		psMap[p.Name()] = p
 	}
 
+	return &Schema{
+		agg:            agg,
+		name:           name,
+		validationMode: validationMode,
+		props:          psMap,
+		keyOrder:       keyOrder,
+	}, nil
+}
+
+func NewSchema(
+	id models.Id,
+	name Name,
+	validationMode ValidationMode,
+	keyOrder codec.KeyOrder,
+	ps ...*props.Prop,
+) (*Schema, error) {
 	agg, err := models.NewAggregateRoot(id)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Schema{
-		agg:   agg,
-		name:  name,
-		props: psMap,
-	}, nil
-}
-
-func NewSchema(id models.Id, name Name, ps ...*props.Prop) (*Schema, error) {
-	s, err := BuildSchema(id, name, ps...)
+	s, err := BuildSchema(agg, name, validationMode, keyOrder, ps...)
 	if err != nil {
 		return nil, err
 	}
@@ -53,9 +67,10 @@ func NewSchema(id models.Id, name Name, ps ...*props.Prop) (*Schema, error) {
 		s.agg.Id().Value(),
 		SchemaCreatedTopic,
 		SchemaCreated{
-			Id:    s.agg.Id().Value(),
-			Name:  s.name.Value(),
-			Props: s.ToMap(),
+			Id:             s.agg.Id().Value(),
+			Name:           s.name.Value(),
+			ValidationMode: s.validationMode.String(),
+			Props:          s.ToMap(),
 		},
 	)
 	if err != nil {
@@ -71,11 +86,19 @@ func (s *Schema) Base() models.ReadOnlyAggregateRoot {
 	return s.agg
 }
 
+func (s *Schema) ClearEvents() {
+	s.agg.ClearEvents()
+}
+
 func (s *Schema) Name() Name {
 	return s.name
 }
 
 func (s *Schema) ChangeName(name Name) error {
+	if s.name.Equals(name) {
+		return nil
+	}
+
 	s.name = name
 	s.agg.Update()
 
@@ -96,17 +119,57 @@ func (s *Schema) ChangeName(name Name) error {
 	return nil
 }
 
+func (s *Schema) ValidationMode() ValidationMode {
+	return s.validationMode
+}
+
+func (s *Schema) ChangeValidationMode(validationMode ValidationMode) error {
+	if s.validationMode == validationMode {
+		return nil
+	}
+
+	s.validationMode = validationMode
+	s.agg.Update()
+
+	event, err := events.NewEvent(
+		s.agg.Id().Value(),
+		SchemaValidationModeChangedTopic,
+		SchemaValidationModeChanged{
+			Id:             s.agg.Id().Value(),
+			ValidationMode: s.validationMode.String(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	s.agg.RecordEvent(event)
+
+	return nil
+}
+
 func (s *Schema) Props() map[string]*props.Prop {
 	return s.props
 }
 
-func (s *Schema) ChangeProps(ps ...*props.Prop) error {
+func (s *Schema) KeyOrder() codec.KeyOrder {
+	return s.keyOrder
+}
+
+// ChangeProps replaces the props along with the order of their keys.
+// Reordering keys alone is not a change.
+func (s *Schema) ChangeProps(keyOrder codec.KeyOrder, ps ...*props.Prop) error {
 	psMap := make(map[string]*props.Prop)
 	for _, p := range ps {
 		psMap[p.Name()] = p
 	}
 
+	if reflect.DeepEqual(propsToMap(s.props), propsToMap(psMap)) {
+		return nil
+	}
+
 	s.props = psMap
+	s.keyOrder = keyOrder
 	s.agg.Update()
 
 	event, err := events.NewEvent(
@@ -126,21 +189,67 @@ func (s *Schema) ChangeProps(ps ...*props.Prop) error {
 	return nil
 }
 
-func (s *Schema) Validate(c config.ConfigData) error {
-	for k, p := range s.props {
-		entry, ok := c[k]
-		if !ok {
-			return fmt.Errorf("prop %s not found in config", k)
-		}
+func (s *Schema) Delete() error {
+	if err := s.agg.Delete(); err != nil {
+		return err
+	}
 
-		if err := p.Validate(entry); err != nil {
-			return fmt.Errorf("path %s: %s", k, err.Error())
-		}
+	event, err := events.NewEvent(
+		s.agg.Id().Value(),
+		SchemaDeletedTopic,
+		SchemaDeleted{
+			Id: s.agg.Id().Value(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	s.agg.RecordEvent(event)
+
+	return nil
+}
+
+func (s *Schema) Restore() error {
+	if err := s.agg.Restore(); err != nil {
+		return err
 	}
 
+	event, err := events.NewEvent(
+		s.agg.Id().Value(),
+		SchemaRestoredTopic,
+		SchemaRestored{
+			Id: s.agg.Id().Value(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	s.agg.RecordEvent(event)
+
 	return nil
 }
 
+// Validate returns a *ValidationResult as error if the config is invalid.
+func (s *Schema) Validate(c config.ConfigData) error {
+	if res := s.Check(c); !res.Valid {
+		return res
+	}
+
+	return nil
+}
+
+// Check validates the config collecting every violation.
+func (s *Schema) Check(c config.ConfigData) *ValidationResult {
+	return newValidationResult(props.CheckObject("", s.props, c))
+}
+
+// Resolve returns a copy of the config with missing keys filled from defaults.
+func (s *Schema) Resolve(c config.ConfigData) config.ConfigData {
+	return props.ResolveObject(s.props, c)
+}
+
 func (s *Schema) ToMap() map[string]interface{} {
 	return propsToMap(s.props)
 }
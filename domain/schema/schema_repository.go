@@ -11,8 +11,15 @@ var (
 	ErrNotFound = errors.New("schema not found")
 )
 
+// SchemaRepository finders skip soft-deleted schemas except for the
+// FindDeleted* ones.
 type SchemaRepository interface {
 	FindById(ctx context.Context, id models.Id) (*Schema, error)
+	FindDeletedById(ctx context.Context, id models.Id) (*Schema, error)
+	FindDeleted(ctx context.Context) ([]*Schema, error)
+	// Find returns up to Pagination.Limit schemas after the cursor.
+	Find(ctx context.Context, criteria Criteria) ([]*Schema, error)
 	Save(ctx context.Context, schema *Schema) error
+	// Delete removes the schema permanently.
 	Delete(ctx context.Context, id models.Id) error
 }
@@ -33,7 +33,7 @@ func TestValidateSchema(t *testing.T) {
 				id, err := models.NewSlug(n.Value())
 				utils.Ok(err)
 
-				s, err := NewSchema(id, n, str)
+				s, err := NewSchema(id, n, WARN_VALIDATION, nil, str)
 				utils.Ok(err)
 
 				return s
@@ -58,7 +58,7 @@ func TestValidateSchema(t *testing.T) {
 				id, err := models.NewSlug(n.Value())
 				utils.Ok(err)
 
-				s, err := NewSchema(id, n, obj)
+				s, err := NewSchema(id, n, WARN_VALIDATION, nil, obj)
 				utils.Ok(err)
 
 				return s
@@ -85,7 +85,7 @@ func TestValidateSchema(t *testing.T) {
 				id, err := models.NewSlug(n.Value())
 				utils.Ok(err)
 
-				s, err := NewSchema(id, n, obj)
+				s, err := NewSchema(id, n, WARN_VALIDATION, nil, obj)
 				utils.Ok(err)
 
 				return s
@@ -109,7 +109,7 @@ func TestValidateSchema(t *testing.T) {
 				id, err := models.NewSlug(n.Value())
 				utils.Ok(err)
 
-				s, err := NewSchema(id, n, env)
+				s, err := NewSchema(id, n, WARN_VALIDATION, nil, env)
 				utils.Ok(err)
 
 				return s
@@ -131,7 +131,7 @@ func TestValidateSchema(t *testing.T) {
 				id, err := models.NewSlug(n.Value())
 				utils.Ok(err)
 
-				s, err := NewSchema(id, n, strs)
+				s, err := NewSchema(id, n, WARN_VALIDATION, nil, strs)
 				utils.Ok(err)
 
 				return s
@@ -153,7 +153,7 @@ func TestValidateSchema(t *testing.T) {
 				id, err := models.NewSlug(n.Value())
 				utils.Ok(err)
 
-				s, err := NewSchema(id, n, env)
+				s, err := NewSchema(id, n, WARN_VALIDATION, nil, env)
 				utils.Ok(err)
 
 				return s
@@ -181,7 +181,7 @@ func TestValidateSchema(t *testing.T) {
 				id, err := models.NewSlug(n.Value())
 				utils.Ok(err)
 
-				s, err := NewSchema(id, n, obj)
+				s, err := NewSchema(id, n, WARN_VALIDATION, nil, obj)
 				utils.Ok(err)
 
 				return s
@@ -209,7 +209,7 @@ func TestValidateSchema(t *testing.T) {
 				id, err := models.NewSlug(n.Value())
 				utils.Ok(err)
 
-				s, err := NewSchema(id, n, obj)
+				s, err := NewSchema(id, n, WARN_VALIDATION, nil, obj)
 				utils.Ok(err)
 
 				return s
@@ -248,7 +248,7 @@ func TestValidateSchema(t *testing.T) {
 				id, err := models.NewSlug(n.Value())
 				utils.Ok(err)
 
-				s, err := NewSchema(id, n, integers, strings, array)
+				s, err := NewSchema(id, n, WARN_VALIDATION, nil, integers, strings, array)
 				utils.Ok(err)
 
 				return s
@@ -304,7 +304,7 @@ func TestValidateSchema(t *testing.T) {
 				id, err := models.NewSlug(n.Value())
 				utils.Ok(err)
 
-				s, err := NewSchema(id, n, integers, strings, array)
+				s, err := NewSchema(id, n, WARN_VALIDATION, nil, integers, strings, array)
 				utils.Ok(err)
 
 				return s
@@ -379,7 +379,7 @@ func TestSchemaToMap(t *testing.T) {
 				id, err := models.NewSlug(n.Value())
 				utils.Ok(err)
 
-				s, err := NewSchema(id, n, str, int, float)
+				s, err := NewSchema(id, n, WARN_VALIDATION, nil, str, int, float)
 				utils.Ok(err)
 
 				return s
@@ -452,7 +452,7 @@ func TestSchemaToMap(t *testing.T) {
 				id, err := models.NewSlug(n.Value())
 				utils.Ok(err)
 
-				s, err := NewSchema(id, n, obj1, obj2)
+				s, err := NewSchema(id, n, WARN_VALIDATION, nil, obj1, obj2)
 				utils.Ok(err)
 
 				return s
@@ -532,7 +532,7 @@ func TestSchemaToMap(t *testing.T) {
 				id, err := models.NewSlug(n.Value())
 				utils.Ok(err)
 
-				s, err := NewSchema(id, n, objs, ints)
+				s, err := NewSchema(id, n, WARN_VALIDATION, nil, objs, ints)
 				utils.Ok(err)
 
 				return s
@@ -581,3 +581,163 @@ func TestSchemaToMap(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckSchema(t *testing.T) {
+	url, err := props.NewString("url", props.WithRequired())
+	utils.Ok(err)
+
+	port, err := props.NewInteger("port", props.WithInterval(80, 18080))
+	utils.Ok(err)
+
+	service, err := props.NewObject("internal_service", props.WithProps(url, port))
+	utils.Ok(err)
+
+	versions, err := props.NewString("versions", props.WithArray(), props.WithRegex("^v[0-9]+$"))
+	utils.Ok(err)
+
+	env, err := props.NewString("env", props.WithEnum("dev", "prod"))
+	utils.Ok(err)
+
+	n, err := NewName("check")
+	utils.Ok(err)
+
+	id, err := models.NewSlug(n.Value())
+	utils.Ok(err)
+
+	s, err := NewSchema(id, n, WARN_VALIDATION, nil, service, versions, env)
+	utils.Ok(err)
+
+	res := s.Check(config.ConfigData{
+		"internal_service": map[string]interface{}{
+			"port":  float64(8),
+			"extra": true,
+		},
+		"versions": []interface{}{"v1", "2", "v3"},
+		"env":      12,
+	})
+
+	assert.False(t, res.Valid)
+	assert.Equal(t, []props.Violation{
+		{
+			Path:    "/env",
+			Rule:    props.TYPE_RULE,
+			Value:   12,
+			Message: "12 is not a string",
+		},
+		{
+			Path:    "/internal_service/extra",
+			Rule:    props.UNKNOWN_KEY_RULE,
+			Value:   true,
+			Message: "unknown key extra",
+		},
+		{
+			Path:    "/internal_service/port",
+			Rule:    props.INTERVAL_RULE,
+			Value:   float64(8),
+			Message: "8 is lesser than the minimum value in interval",
+		},
+		{
+			Path:    "/internal_service/url",
+			Rule:    props.REQUIRED_RULE,
+			Message: "missing prop for key url",
+		},
+		{
+			Path:    "/versions/1",
+			Rule:    props.REGEX_RULE,
+			Value:   "2",
+			Message: "2 does not match regex ^v[0-9]+$",
+		},
+	}, res.Violations)
+
+	res = s.Check(config.ConfigData{
+		"internal_service": map[string]interface{}{
+			"url":  "http://localhost",
+			"port": float64(8080),
+		},
+		"versions": []interface{}{"v1"},
+		"env":      "dev",
+	})
+
+	assert.True(t, res.Valid)
+	assert.Empty(t, res.Violations)
+}
+
+func TestResolveSchema(t *testing.T) {
+	url, err := props.NewString("url", props.WithRequired())
+	utils.Ok(err)
+
+	port, err := props.NewInteger("port", props.WithDefault(8080))
+	utils.Ok(err)
+
+	service, err := props.NewObject("internal_service", props.WithProps(url, port))
+	utils.Ok(err)
+
+	threshold, err := props.NewFloat("threshold", props.WithDefault(0.6))
+	utils.Ok(err)
+
+	breaker, err := props.NewObject("circuit_breaker", props.WithProps(threshold))
+	utils.Ok(err)
+
+	enabled, err := props.NewBool("enabled", props.WithDefault(true))
+	utils.Ok(err)
+
+	name, err := props.NewString("name")
+	utils.Ok(err)
+
+	workers, err := props.NewObject("workers", props.WithArray(), props.WithProps(enabled, name))
+	utils.Ok(err)
+
+	env, err := props.NewString("env")
+	utils.Ok(err)
+
+	n, err := NewName("resolve")
+	utils.Ok(err)
+
+	id, err := models.NewSlug(n.Value())
+	utils.Ok(err)
+
+	s, err := NewSchema(id, n, WARN_VALIDATION, nil, service, breaker, workers, env)
+	utils.Ok(err)
+
+	c := config.ConfigData{
+		"internal_service": map[string]interface{}{
+			"url": "http://localhost",
+		},
+		"workers": []interface{}{
+			map[string]interface{}{"name": "a"},
+			map[string]interface{}{"name": "b", "enabled": false},
+		},
+	}
+
+	// Optional props may be absent, but a missing object is still reported
+	// as required
+	assert.False(t, s.Check(c).Valid)
+	assert.Equal(t, []props.Violation{
+		{
+			Path:    "/circuit_breaker",
+			Rule:    props.REQUIRED_RULE,
+			Message: "missing prop for key circuit_breaker",
+		},
+	}, s.Check(c).Violations)
+
+	resolved := s.Resolve(c)
+
+	assert.Equal(t, config.ConfigData{
+		"internal_service": map[string]interface{}{
+			"url":  "http://localhost",
+			"port": 8080,
+		},
+		"circuit_breaker": map[string]interface{}{
+			"threshold": 0.6,
+		},
+		"workers": []interface{}{
+			map[string]interface{}{"name": "a", "enabled": true},
+			map[string]interface{}{"name": "b", "enabled": false},
+		},
+	}, resolved)
+	assert.True(t, s.Check(resolved).Valid)
+
+	// Original config is not modified
+	assert.NotContains(t, c, "circuit_breaker")
+	assert.NotContains(t, c["internal_service"], "port")
+}
@@ -0,0 +1,34 @@
+package schema
+
+import (
+	"strings"
+
+	"github.com/aboglioli/configd/domain/props"
+)
+
+// ValidationResult lists every violation found validating a config against a
+// schema.
+type ValidationResult struct {
+	Valid      bool              `json:"valid"`
+	Violations []props.Violation `json:"violations"`
+}
+
+func newValidationResult(violations []props.Violation) *ValidationResult {
+	if violations == nil {
+		violations = make([]props.Violation, 0)
+	}
+
+	return &ValidationResult{
+		Valid:      len(violations) == 0,
+		Violations: violations,
+	}
+}
+
+func (r *ValidationResult) Error() string {
+	msgs := make([]string, len(r.Violations))
+	for i, v := range r.Violations {
+		msgs[i] = v.Error()
+	}
+
+	return "invalid config: " + strings.Join(msgs, "; ")
+}
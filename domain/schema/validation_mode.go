@@ -0,0 +1,31 @@
+package schema
+
+import (
+	"fmt"
+)
+
+// ValidationMode defines what happens when a config does not satisfy the
+// schema on write.
+type ValidationMode string
+
+const (
+	// Invalid configs are rejected
+	STRICT_VALIDATION ValidationMode = "strict"
+	// Invalid configs are saved and reported as invalid
+	WARN_VALIDATION ValidationMode = "warn"
+)
+
+func NewValidationMode(mode string) (ValidationMode, error) {
+	switch mode {
+	case string(STRICT_VALIDATION):
+		return STRICT_VALIDATION, nil
+	case string(WARN_VALIDATION):
+		return WARN_VALIDATION, nil
+	}
+
+	return "", fmt.Errorf("invalid validation mode %s", mode)
+}
+
+func (m ValidationMode) String() string {
+	return string(m)
+}
@@ -0,0 +1,106 @@
+package schema
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/props"
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+// Version is an immutable snapshot of the schema props. Versions are numbered
+// in sequence and only props changes make a new one, so renaming a schema
+// leaves no gaps. Configs pinned to a version are not affected by later schema
+// changes.
+type Version struct {
+	schemaId  models.Id
+	version   uint
+	props     map[string]*props.Prop
+	keyOrder  codec.KeyOrder
+	createdAt time.Time
+}
+
+func BuildVersion(
+	schemaId models.Id,
+	version uint,
+	createdAt time.Time,
+	keyOrder codec.KeyOrder,
+	ps ...*props.Prop,
+) (*Version, error) {
+	if version == 0 {
+		return nil, errors.New("invalid schema version")
+	}
+
+	if len(ps) == 0 {
+		return nil, errors.New("schema does not have props")
+	}
+
+	psMap := make(map[string]*props.Prop)
+	for _, p := range ps {
+		psMap[p.Name()] = p
+	}
+
+	return &Version{
+		schemaId:  schemaId,
+		version:   version,
+		props:     psMap,
+		keyOrder:  keyOrder,
+		createdAt: createdAt,
+	}, nil
+}
+
+// NewVersion takes a snapshot of the current schema props as the version
+// following previous, or as the first one when there is no previous version.
+func NewVersion(s *Schema, previous *Version) (*Version, error) {
+	version := uint(1)
+	if previous != nil {
+		if !previous.schemaId.Equals(s.agg.Id()) {
+			return nil, errors.New("previous version belongs to another schema")
+		}
+
+		version = previous.version + 1
+	}
+
+	ps := make([]*props.Prop, 0, len(s.props))
+	for _, p := range s.props {
+		ps = append(ps, p)
+	}
+
+	return BuildVersion(s.agg.Id(), version, s.agg.UpdatedAt(), s.keyOrder, ps...)
+}
+
+func (v *Version) SchemaId() models.Id {
+	return v.schemaId
+}
+
+func (v *Version) Version() uint {
+	return v.version
+}
+
+func (v *Version) Props() map[string]*props.Prop {
+	return v.props
+}
+
+func (v *Version) KeyOrder() codec.KeyOrder {
+	return v.keyOrder
+}
+
+func (v *Version) CreatedAt() time.Time {
+	return v.createdAt
+}
+
+// Check validates the config collecting every violation.
+func (v *Version) Check(c config.ConfigData) *ValidationResult {
+	return newValidationResult(props.CheckObject("", v.props, c))
+}
+
+// Resolve returns a copy of the config with missing keys filled from defaults.
+func (v *Version) Resolve(c config.ConfigData) config.ConfigData {
+	return props.ResolveObject(v.props, c)
+}
+
+func (v *Version) ToMap() map[string]interface{} {
+	return propsToMap(v.props)
+}
@@ -0,0 +1,23 @@
+package schema
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var (
+	ErrVersionNotFound = errors.New("schema version not found")
+)
+
+type VersionRepository interface {
+	// FindBySchemaId returns every version of a schema ordered by number.
+	FindBySchemaId(ctx context.Context, schemaId models.Id) ([]*Version, error)
+	FindByVersion(ctx context.Context, schemaId models.Id, version uint) (*Version, error)
+	FindLatest(ctx context.Context, schemaId models.Id) (*Version, error)
+	// Save stores a new version. Versions are immutable: saving an existing
+	// one is a no-op.
+	Save(ctx context.Context, version *Version) error
+	DeleteBySchemaId(ctx context.Context, schemaId models.Id) error
+}
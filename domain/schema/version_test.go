@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/props"
+	"github.com/aboglioli/configd/pkg/models"
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersion(t *testing.T) {
+	port, err := props.NewInteger("port", props.WithRequired())
+	utils.Ok(err)
+
+	id, err := models.BuildId("my-schema")
+	utils.Ok(err)
+
+	name, err := NewName("My Schema")
+	utils.Ok(err)
+
+	s, err := NewSchema(id, name, WARN_VALIDATION, nil, port)
+	utils.Ok(err)
+
+	v1, err := NewVersion(s, nil)
+	utils.Ok(err)
+	assert.Equal(t, uint(1), v1.Version())
+
+	host, err := props.NewString("host", props.WithRequired())
+	utils.Ok(err)
+
+	// Versions follow each other whatever other changes were made
+	renamed, err := NewName("Renamed Schema")
+	utils.Ok(err)
+	utils.Ok(s.ChangeName(renamed))
+
+	// Later schema changes do not affect previous versions
+	utils.Ok(s.ChangeProps(nil, host))
+	assert.Equal(t, uint(2), s.Base().Version())
+
+	v2, err := NewVersion(s, v1)
+	utils.Ok(err)
+	assert.Equal(t, uint(2), v2.Version())
+
+	c := config.ConfigData{"port": 8080}
+	assert.True(t, v1.Check(c).Valid)
+	assert.False(t, v2.Check(c).Valid)
+	assert.Equal(t, s.ToMap(), v2.ToMap())
+}
+
+func TestNewVersionOfAnotherSchema(t *testing.T) {
+	port, err := props.NewInteger("port")
+	utils.Ok(err)
+
+	name, err := NewName("My Schema")
+	utils.Ok(err)
+
+	id, err := models.BuildId("my-schema")
+	utils.Ok(err)
+
+	otherId, err := models.BuildId("other-schema")
+	utils.Ok(err)
+
+	s, err := NewSchema(id, name, WARN_VALIDATION, nil, port)
+	utils.Ok(err)
+
+	other, err := NewSchema(otherId, name, WARN_VALIDATION, nil, port)
+	utils.Ok(err)
+
+	previous, err := NewVersion(other, nil)
+	utils.Ok(err)
+
+	_, err = NewVersion(s, previous)
+	assert.Error(t, err)
+}
@@ -1,21 +1,48 @@
 package security
 
 import (
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
-	"math/rand"
-	"time"
+	"hash/crc32"
+	"strings"
+
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+const (
+	// Keys look like cfgd_<id>_<secret>_<checksum>, so secret scanners can
+	// find leaked ones and the id locates its authorization
+	API_KEY_SCHEME          = "cfgd"
+	API_KEY_SEPARATOR       = "_"
+	API_KEY_ID_LENGTH       = 12
+	API_KEY_SECRET_LENGTH   = 32
+	API_KEY_CHECKSUM_LENGTH = 6
+
+	// Leading characters kept to tell legacy keys apart
+	API_KEY_PREFIX_LENGTH = 8
 )
 
-var apiKeyCharacters = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+var ErrMalformedApiKey = errors.New("malformed api key")
+
+const apiKeyCharacters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 
 // API Key
 type ApiKey struct {
 	apiKey string
+	// Empty for legacy keys, generated before keys carried an id
+	id string
 }
 
+// NewApiKey parses a raw API key. Keys with the cfgd scheme must be well
+// formed and match their checksum. Any other key is a legacy key.
 func NewApiKey(apiKey string) (ApiKey, error) {
+	if strings.HasPrefix(apiKey, API_KEY_SCHEME+API_KEY_SEPARATOR) {
+		return parseApiKey(apiKey)
+	}
+
 	if len(apiKey) < 10 {
 		return ApiKey{}, fmt.Errorf("api key %s too short", apiKey)
 	}
@@ -25,22 +52,75 @@ func NewApiKey(apiKey string) (ApiKey, error) {
 	}, nil
 }
 
+func parseApiKey(apiKey string) (ApiKey, error) {
+	parts := strings.Split(apiKey, API_KEY_SEPARATOR)
+	if len(parts) != 4 {
+		return ApiKey{}, ErrMalformedApiKey
+	}
+
+	id, secret, checksum := parts[1], parts[2], parts[3]
+	if len(id) != API_KEY_ID_LENGTH ||
+		len(secret) != API_KEY_SECRET_LENGTH ||
+		len(checksum) != API_KEY_CHECKSUM_LENGTH ||
+		!isApiKeyText(id) || !isApiKeyText(secret) {
+		return ApiKey{}, ErrMalformedApiKey
+	}
+
+	body := apiKey[:len(apiKey)-len(checksum)-len(API_KEY_SEPARATOR)]
+	if apiKeyChecksum(body) != checksum {
+		return ApiKey{}, ErrMalformedApiKey
+	}
+
+	return ApiKey{
+		apiKey: apiKey,
+		id:     id,
+	}, nil
+}
+
+// GenerateApiKey generates a new key from a cryptographically secure source.
 func GenerateApiKey() (ApiKey, error) {
-	src := rand.NewSource(time.Now().UnixNano())
-	rand := rand.New(src)
+	id, err := randomApiKeyText(API_KEY_ID_LENGTH)
+	if err != nil {
+		return ApiKey{}, err
+	}
 
-	keys := make([]rune, 36)
-	for i := range keys {
-		keys[i] = apiKeyCharacters[rand.Intn(len(apiKeyCharacters))]
+	secret, err := randomApiKeyText(API_KEY_SECRET_LENGTH)
+	if err != nil {
+		return ApiKey{}, err
 	}
 
-	return NewApiKey(string(keys))
+	body := strings.Join([]string{API_KEY_SCHEME, id, secret}, API_KEY_SEPARATOR)
+
+	return NewApiKey(body + API_KEY_SEPARATOR + apiKeyChecksum(body))
 }
 
 func (a ApiKey) Value() string {
 	return a.apiKey
 }
 
+// Id returns the id embedded in the key, if it is not a legacy key.
+func (a ApiKey) Id() (models.Id, bool) {
+	if a.id == "" {
+		return models.Id{}, false
+	}
+
+	id, err := models.BuildId(a.id)
+	if err != nil {
+		return models.Id{}, false
+	}
+
+	return id, true
+}
+
+// Prefix is the public part of the key, safe to display.
+func (a ApiKey) Prefix() string {
+	if a.id != "" {
+		return API_KEY_SCHEME + API_KEY_SEPARATOR + a.id
+	}
+
+	return a.apiKey[:API_KEY_PREFIX_LENGTH]
+}
+
 func (a ApiKey) Equals(o ApiKey) bool {
 	return a.apiKey == o.apiKey
 }
@@ -50,3 +130,50 @@ func (a ApiKey) Hash() (HashedApiKey, error) {
 
 	return NewHashedApiKey(hex.EncodeToString(hashedApiKey[:]))
 }
+
+// randomApiKeyText picks alphanumeric characters uniformly, discarding bytes
+// that would bias the modulo.
+func randomApiKeyText(length int) (string, error) {
+	max := byte(256 - 256%len(apiKeyCharacters))
+
+	text := make([]byte, 0, length)
+	buf := make([]byte, length)
+	for len(text) < length {
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+
+		for _, b := range buf {
+			if b >= max || len(text) == length {
+				continue
+			}
+
+			text = append(text, apiKeyCharacters[int(b)%len(apiKeyCharacters)])
+		}
+	}
+
+	return string(text), nil
+}
+
+func isApiKeyText(text string) bool {
+	for _, c := range text {
+		if !strings.ContainsRune(apiKeyCharacters, c) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// apiKeyChecksum encodes the CRC32 of the key body in base62, left padded.
+func apiKeyChecksum(body string) string {
+	sum := crc32.ChecksumIEEE([]byte(body))
+
+	checksum := make([]byte, API_KEY_CHECKSUM_LENGTH)
+	for i := len(checksum) - 1; i >= 0; i-- {
+		checksum[i] = apiKeyCharacters[sum%uint32(len(apiKeyCharacters))]
+		sum /= uint32(len(apiKeyCharacters))
+	}
+
+	return string(checksum)
+}
@@ -0,0 +1,129 @@
+package security
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateApiKey(t *testing.T) {
+	apiKey, err := GenerateApiKey()
+	utils.Ok(err)
+
+	parts := strings.Split(apiKey.Value(), "_")
+	if assert.Len(t, parts, 4) {
+		assert.Equal(t, "cfgd", parts[0])
+		assert.Len(t, parts[1], API_KEY_ID_LENGTH)
+		assert.Len(t, parts[2], API_KEY_SECRET_LENGTH)
+		assert.Len(t, parts[3], API_KEY_CHECKSUM_LENGTH)
+	}
+
+	id, ok := apiKey.Id()
+	if assert.True(t, ok) {
+		assert.Equal(t, parts[1], id.Value())
+	}
+
+	assert.Equal(t, "cfgd_"+parts[1], apiKey.Prefix())
+
+	parsed, err := NewApiKey(apiKey.Value())
+	if assert.NoError(t, err) {
+		assert.True(t, apiKey.Equals(parsed))
+	}
+
+	other, err := GenerateApiKey()
+	utils.Ok(err)
+
+	assert.False(t, apiKey.Equals(other))
+}
+
+func TestNewApiKey(t *testing.T) {
+	apiKey, err := GenerateApiKey()
+	utils.Ok(err)
+
+	valid := apiKey.Value()
+	checksum := valid[len(valid)-API_KEY_CHECKSUM_LENGTH:]
+
+	// Swap the first secret character for another one
+	secretStart := len("cfgd_") + API_KEY_ID_LENGTH + 1
+	swapped := "a"
+	if valid[secretStart] == 'a' {
+		swapped = "b"
+	}
+	tampered := valid[:secretStart] + swapped + valid[secretStart+1:]
+
+	type test struct {
+		name   string
+		apiKey string
+		legacy bool
+		err    bool
+	}
+
+	tests := []test{
+		{
+			name:   "generated",
+			apiKey: valid,
+		},
+		{
+			name:   "legacy",
+			apiKey: "fBzKQ3h9XtLmPo2sVwYcRnA7eDg5uJiT0kH1",
+			legacy: true,
+		},
+		{
+			name:   "legacy too short",
+			apiKey: "short",
+			err:    true,
+		},
+		{
+			name:   "tampered secret",
+			apiKey: tampered,
+			err:    true,
+		},
+		{
+			name:   "missing checksum",
+			apiKey: strings.TrimSuffix(valid, "_"+checksum),
+			err:    true,
+		},
+		{
+			name:   "extra segment",
+			apiKey: valid + "_abc",
+			err:    true,
+		},
+		{
+			name:   "invalid characters",
+			apiKey: "cfgd_abc-def-ghij_" + strings.Repeat("a", API_KEY_SECRET_LENGTH) + "_" + checksum,
+			err:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			apiKey, err := NewApiKey(test.apiKey)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				_, ok := apiKey.Id()
+				assert.Equal(t, !test.legacy, ok)
+			}
+		})
+	}
+}
+
+func TestHashedApiKeyValidate(t *testing.T) {
+	apiKey, err := GenerateApiKey()
+	utils.Ok(err)
+
+	other, err := GenerateApiKey()
+	utils.Ok(err)
+
+	hash, err := apiKey.Hash()
+	utils.Ok(err)
+
+	assert.True(t, hash.Validate(apiKey))
+	assert.False(t, hash.Validate(other))
+}
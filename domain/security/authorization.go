@@ -1,9 +1,23 @@
 package security
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/aboglioli/configd/pkg/models"
 )
 
+const (
+	// Last use is recorded with this resolution, to avoid a write per request
+	LAST_USED_RESOLUTION = time.Minute
+)
+
+var (
+	ErrRevoked = errors.New("api key revoked")
+	ErrExpired = errors.New("api key expired")
+)
+
 type Access string
 
 const (
@@ -11,35 +25,120 @@ const (
 	FULL_ACCESS      Access = "full_access"
 )
 
+func NewAccess(access string) (Access, error) {
+	switch access {
+	case string(READ_ONLY_ACCESS):
+		return READ_ONLY_ACCESS, nil
+	case string(FULL_ACCESS):
+		return FULL_ACCESS, nil
+	}
+
+	return "", fmt.Errorf("invalid access %s", access)
+}
+
+// Authorization grants access to a resource to the holder of an API key. Only
+// the key hash is kept, along with its prefix to tell keys apart. Revoked and
+// expired authorizations are kept until the resource is removed.
 type Authorization struct {
+	id           models.Id
+	name         string
+	prefix       string
 	hashedApiKey HashedApiKey
 	resourceId   models.Id
 	access       Access
+	createdAt    time.Time
+	lastUsedAt   *time.Time
+	expiresAt    *time.Time
+	revokedAt    *time.Time
 }
 
 func BuildAuthorization(
+	id models.Id,
+	name string,
+	prefix string,
 	hashedApiKey HashedApiKey,
 	resourceId models.Id,
 	access Access,
+	createdAt time.Time,
+	lastUsedAt *time.Time,
+	expiresAt *time.Time,
+	revokedAt *time.Time,
 ) (*Authorization, error) {
+	if name == "" {
+		return nil, errors.New("api key name is required")
+	}
+
+	if len(name) > 64 {
+		return nil, errors.New("api key name too long")
+	}
+
 	return &Authorization{
+		id:           id,
+		name:         name,
+		prefix:       prefix,
 		hashedApiKey: hashedApiKey,
 		resourceId:   resourceId,
 		access:       access,
+		createdAt:    createdAt,
+		lastUsedAt:   lastUsedAt,
+		expiresAt:    expiresAt,
+		revokedAt:    revokedAt,
 	}, nil
 }
 
+// NewAuthorization authorizes a new API key, without expiration when
+// expiresAt is nil. The authorization takes the id embedded in the key, so
+// the key can be looked up by it.
 func NewAuthorization(
 	apiKey ApiKey,
+	name string,
 	resourceId models.Id,
 	access Access,
+	expiresAt *time.Time,
 ) (*Authorization, error) {
+	now := time.Now()
+
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, errors.New("api key expiration must be in the future")
+	}
+
+	id, ok := apiKey.Id()
+	if !ok {
+		var err error
+		if id, err = models.NewUuid(); err != nil {
+			return nil, err
+		}
+	}
+
 	hash, err := apiKey.Hash()
 	if err != nil {
 		return nil, err
 	}
 
-	return BuildAuthorization(hash, resourceId, access)
+	return BuildAuthorization(
+		id,
+		name,
+		apiKey.Prefix(),
+		hash,
+		resourceId,
+		access,
+		now,
+		nil,
+		expiresAt,
+		nil,
+	)
+}
+
+func (a *Authorization) Id() models.Id {
+	return a.id
+}
+
+func (a *Authorization) Name() string {
+	return a.name
+}
+
+func (a *Authorization) Prefix() string {
+	return a.prefix
 }
 
 func (a *Authorization) HashedApiKey() HashedApiKey {
@@ -53,3 +152,66 @@ func (a *Authorization) ResourceId() models.Id {
 func (a *Authorization) Access() Access {
 	return a.access
 }
+
+func (a *Authorization) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+func (a *Authorization) LastUsedAt() *time.Time {
+	return a.lastUsedAt
+}
+
+func (a *Authorization) ExpiresAt() *time.Time {
+	return a.expiresAt
+}
+
+func (a *Authorization) RevokedAt() *time.Time {
+	return a.revokedAt
+}
+
+// Check returns why the API key cannot be used at the given time, if so.
+func (a *Authorization) Check(now time.Time) error {
+	if a.revokedAt != nil {
+		return ErrRevoked
+	}
+
+	if a.expiresAt != nil && !now.Before(*a.expiresAt) {
+		return ErrExpired
+	}
+
+	return nil
+}
+
+func (a *Authorization) IsActive(now time.Time) bool {
+	return a.Check(now) == nil
+}
+
+// Use records the API key was used and reports whether it changed, given
+// LAST_USED_RESOLUTION.
+func (a *Authorization) Use(now time.Time) bool {
+	if a.lastUsedAt != nil && now.Sub(*a.lastUsedAt) < LAST_USED_RESOLUTION {
+		return false
+	}
+
+	a.lastUsedAt = &now
+
+	return true
+}
+
+func (a *Authorization) Revoke(now time.Time) error {
+	if a.revokedAt != nil {
+		return ErrRevoked
+	}
+
+	a.revokedAt = &now
+
+	return nil
+}
+
+// ExpireAt brings the expiration forward to t. Later expirations are
+// ignored.
+func (a *Authorization) ExpireAt(t time.Time) {
+	if a.expiresAt == nil || t.Before(*a.expiresAt) {
+		a.expiresAt = &t
+	}
+}
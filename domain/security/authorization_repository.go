@@ -3,6 +3,9 @@ package security
 import (
 	"context"
 	"errors"
+	"time"
+
+	"github.com/aboglioli/configd/pkg/models"
 )
 
 var (
@@ -10,7 +13,16 @@ var (
 )
 
 type AuthorizationRepository interface {
+	FindById(ctx context.Context, id models.Id) (*Authorization, error)
 	FindByApiKey(ctx context.Context, hashedApiKey HashedApiKey) (*Authorization, error)
+	// Sorted by creation
+	FindByResourceId(ctx context.Context, resourceId models.Id) ([]*Authorization, error)
+	// Save never clears a revocation nor postpones an expiration, so saving a
+	// stale authorization cannot enable its API key again.
 	Save(ctx context.Context, authorization *Authorization) error
+	// UpdateLastUsedAt only records the last use of a non-revoked
+	// authorization.
+	UpdateLastUsedAt(ctx context.Context, id models.Id, lastUsedAt time.Time) error
 	Delete(ctx context.Context, hashedApiKey HashedApiKey) error
+	DeleteByResourceId(ctx context.Context, resourceId models.Id) error
 }
@@ -0,0 +1,103 @@
+package security
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aboglioli/configd/pkg/models"
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuthorization(t *testing.T) {
+	apiKey, err := GenerateApiKey()
+	utils.Ok(err)
+
+	apiKeyId, _ := apiKey.Id()
+
+	resourceId, err := models.BuildId("my-config")
+	utils.Ok(err)
+
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+
+	type test struct {
+		name      string
+		keyName   string
+		expiresAt *time.Time
+		err       bool
+	}
+
+	tests := []test{
+		{
+			name:    "without expiration",
+			keyName: "default",
+		},
+		{
+			name:      "with expiration",
+			keyName:   "ci",
+			expiresAt: &future,
+		},
+		{
+			name:      "expired",
+			keyName:   "ci",
+			expiresAt: &past,
+			err:       true,
+		},
+		{
+			name: "without name",
+			err:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			auth, err := NewAuthorization(apiKey, test.keyName, resourceId, READ_ONLY_ACCESS, test.expiresAt)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.keyName, auth.Name())
+				assert.Equal(t, apiKey.Prefix(), auth.Prefix())
+				assert.Equal(t, apiKeyId, auth.Id())
+				assert.True(t, auth.HashedApiKey().Validate(apiKey))
+				assert.Equal(t, test.expiresAt, auth.ExpiresAt())
+				assert.True(t, auth.IsActive(time.Now()))
+			}
+		})
+	}
+}
+
+func TestAuthorizationLifecycle(t *testing.T) {
+	apiKey, err := GenerateApiKey()
+	utils.Ok(err)
+
+	resourceId, err := models.BuildId("my-config")
+	utils.Ok(err)
+
+	auth, err := NewAuthorization(apiKey, "default", resourceId, READ_ONLY_ACCESS, nil)
+	utils.Ok(err)
+
+	now := time.Now()
+
+	// Uses are recorded once per resolution
+	assert.True(t, auth.Use(now))
+	assert.False(t, auth.Use(now.Add(LAST_USED_RESOLUTION/2)))
+	assert.True(t, auth.Use(now.Add(LAST_USED_RESOLUTION)))
+
+	// Expirations are only brought forward
+	grace := now.Add(time.Hour)
+	auth.ExpireAt(grace)
+	auth.ExpireAt(now.Add(2 * time.Hour))
+	assert.Equal(t, grace, *auth.ExpiresAt())
+
+	assert.NoError(t, auth.Check(now))
+	assert.Equal(t, ErrExpired, auth.Check(grace))
+
+	assert.NoError(t, auth.Revoke(now))
+	assert.Equal(t, ErrRevoked, auth.Check(now))
+	assert.Equal(t, ErrRevoked, auth.Revoke(now))
+}
@@ -1,6 +1,7 @@
 package security
 
 import (
+	"crypto/subtle"
 	"fmt"
 )
 
@@ -32,5 +33,5 @@ func (a HashedApiKey) Validate(apiKey ApiKey) bool {
 		return false
 	}
 
-	return a.Equals(hash)
+	return subtle.ConstantTimeCompare([]byte(a.hashedApiKey), []byte(hash.hashedApiKey)) == 1
 }
@@ -21,3 +21,9 @@ func NewAccess(access string) (Access, error) {
 
 	return "", fmt.Errorf("invalid access %s", access)
 }
+
+// Allows reports whether this access covers the required one. Full access
+// covers read-only access.
+func (a Access) Allows(required Access) bool {
+	return a == FULL_ACCESS || a == required
+}
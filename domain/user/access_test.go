@@ -0,0 +1,49 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessAllows(t *testing.T) {
+	type test struct {
+		name     string
+		access   Access
+		required Access
+		expected bool
+	}
+
+	tests := []test{
+		{
+			name:     "read only reads",
+			access:   READ_ONLY_ACCESS,
+			required: READ_ONLY_ACCESS,
+			expected: true,
+		},
+		{
+			name:     "read only writes",
+			access:   READ_ONLY_ACCESS,
+			required: FULL_ACCESS,
+			expected: false,
+		},
+		{
+			name:     "full access reads",
+			access:   FULL_ACCESS,
+			required: READ_ONLY_ACCESS,
+			expected: true,
+		},
+		{
+			name:     "full access writes",
+			access:   FULL_ACCESS,
+			required: FULL_ACCESS,
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.access.Allows(test.required))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package user
+
+import (
+	"context"
+)
+
+type contextKey struct{}
+
+type principal struct {
+	user   *User
+	grants Grants
+}
+
+// NewContext returns a copy of ctx carrying the authenticated user and the
+// permissions granted to them.
+func NewContext(ctx context.Context, u *User, grants Grants) context.Context {
+	return context.WithValue(ctx, contextKey{}, &principal{
+		user:   u,
+		grants: grants,
+	})
+}
+
+// NewSystemContext returns a copy of ctx allowed to do anything, for tasks
+// run by the service itself.
+func NewSystemContext(ctx context.Context) context.Context {
+	return NewContext(ctx, nil, AllGrants())
+}
+
+// FromContext returns the authenticated user carried by ctx, if any.
+func FromContext(ctx context.Context) (*User, bool) {
+	p, ok := ctx.Value(contextKey{}).(*principal)
+	if !ok || p.user == nil {
+		return nil, false
+	}
+
+	return p.user, true
+}
+
+// GrantsFromContext returns the permissions granted to the user or system
+// carried by ctx, if any.
+func GrantsFromContext(ctx context.Context) (Grants, bool) {
+	p, ok := ctx.Value(contextKey{}).(*principal)
+	if !ok {
+		return nil, false
+	}
+
+	return p.grants, true
+}
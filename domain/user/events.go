@@ -0,0 +1,14 @@
+package user
+
+import (
+	"github.com/aboglioli/configd/pkg/events"
+)
+
+var (
+	UserRegisteredTopic = events.NewTopic("user", "registered")
+)
+
+type UserRegistered struct {
+	Username string `json:"username"`
+	Access   string `json:"access"`
+}
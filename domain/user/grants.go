@@ -0,0 +1,53 @@
+package user
+
+// Grant is a permission within a scope.
+type Grant struct {
+	Permission Permission
+	Scope      Scope
+}
+
+// Grants are the permissions a user gets from their role assignments.
+type Grants []Grant
+
+// NewGrants resolves the permissions of the assigned roles. Roles missing
+// from roles, e.g. deleted, grant nothing.
+func NewGrants(assignments []*RoleAssignment, roles map[string]*Role) Grants {
+	grants := make(Grants, 0)
+	for _, a := range assignments {
+		r, ok := roles[a.RoleId().Value()]
+		if !ok {
+			continue
+		}
+
+		for _, p := range r.Permissions() {
+			grants = append(grants, Grant{
+				Permission: p,
+				Scope:      a.Scope(),
+			})
+		}
+	}
+
+	return grants
+}
+
+// AllGrants grants every permission everywhere.
+func AllGrants() Grants {
+	grants := make(Grants, 0, len(permissions))
+	for _, p := range permissions {
+		grants = append(grants, Grant{
+			Permission: p,
+		})
+	}
+
+	return grants
+}
+
+func (g Grants) Allows(permission Permission, r Resource) bool {
+	for _, grant := range g {
+		if grant.Permission == permission && grant.Scope.Matches(r) {
+			return true
+		}
+	}
+
+	return false
+}
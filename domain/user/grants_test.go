@@ -0,0 +1,147 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/aboglioli/configd/pkg/models"
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRole(t *testing.T) {
+	type test struct {
+		name        string
+		id          string
+		permissions []Permission
+		err         bool
+	}
+
+	tests := []test{
+		{
+			name:        "custom",
+			id:          "key-manager",
+			permissions: []Permission{KEY_MANAGE_PERMISSION, CONFIG_READ_PERMISSION},
+		},
+		{
+			name:        "builtin id",
+			id:          ADMIN_ROLE,
+			permissions: []Permission{CONFIG_READ_PERMISSION},
+			err:         true,
+		},
+		{
+			name: "without permissions",
+			id:   "nothing",
+			err:  true,
+		},
+		{
+			name:        "duplicated permissions",
+			id:          "reader",
+			permissions: []Permission{CONFIG_READ_PERMISSION, CONFIG_READ_PERMISSION},
+			err:         true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			id, err := models.BuildId(test.id)
+			utils.Ok(err)
+
+			r, err := NewRole(id, test.id, test.permissions)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.False(t, r.IsBuiltin())
+				assert.Equal(t, test.permissions, r.Permissions())
+			}
+		})
+	}
+
+	t.Run("builtin roles cannot be updated", func(t *testing.T) {
+		for _, r := range BuiltinRoles() {
+			assert.Equal(t, ErrBuiltinRole, r.Update("other", []Permission{CONFIG_READ_PERMISSION}))
+		}
+	})
+}
+
+func TestGrantsAllows(t *testing.T) {
+	username, err := NewUsername("team-a-lead")
+	utils.Ok(err)
+
+	roles := make(map[string]*Role)
+	for _, r := range BuiltinRoles() {
+		roles[r.Id().Value()] = r
+	}
+
+	assign := func(role, rawScope string) *RoleAssignment {
+		roleId, err := models.BuildId(role)
+		utils.Ok(err)
+
+		scope, err := NewScope(rawScope)
+		utils.Ok(err)
+
+		a, err := NewRoleAssignment(username, roleId, scope)
+		utils.Ok(err)
+
+		return a
+	}
+
+	grants := NewGrants([]*RoleAssignment{
+		assign(VIEWER_ROLE, "*"),
+		assign(EDITOR_ROLE, "schema:team-a-*"),
+		assign("deleted-role", "*"),
+	}, roles)
+
+	type test struct {
+		name       string
+		permission Permission
+		resource   Resource
+		expected   bool
+	}
+
+	tests := []test{
+		{
+			name:       "read any config",
+			permission: CONFIG_READ_PERMISSION,
+			resource:   ConfigResource("billing-dev", "billing"),
+			expected:   true,
+		},
+		{
+			name:       "write team schema",
+			permission: SCHEMA_WRITE_PERMISSION,
+			resource:   SchemaResource("team-a-billing"),
+			expected:   true,
+		},
+		{
+			name:       "write configs of team schemas",
+			permission: CONFIG_WRITE_PERMISSION,
+			resource:   ConfigResource("billing-dev", "team-a-billing"),
+			expected:   true,
+		},
+		{
+			name:       "write other schema",
+			permission: SCHEMA_WRITE_PERMISSION,
+			resource:   SchemaResource("team-b-billing"),
+			expected:   false,
+		},
+		{
+			name:       "manage users",
+			permission: USER_MANAGE_PERMISSION,
+			resource:   Resource{},
+			expected:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, grants.Allows(test.permission, test.resource))
+		})
+	}
+
+	t.Run("all grants", func(t *testing.T) {
+		assert.True(t, AllGrants().Allows(USER_MANAGE_PERMISSION, Resource{}))
+	})
+}
@@ -0,0 +1,154 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Permission string
+
+const (
+	SCHEMA_READ_PERMISSION  Permission = "schema:read"
+	SCHEMA_WRITE_PERMISSION Permission = "schema:write"
+	CONFIG_READ_PERMISSION  Permission = "config:read"
+	CONFIG_WRITE_PERMISSION Permission = "config:write"
+	KEY_MANAGE_PERMISSION   Permission = "key:manage"
+	USER_MANAGE_PERMISSION  Permission = "user:manage"
+)
+
+var permissions = []Permission{
+	SCHEMA_READ_PERMISSION,
+	SCHEMA_WRITE_PERMISSION,
+	CONFIG_READ_PERMISSION,
+	CONFIG_WRITE_PERMISSION,
+	KEY_MANAGE_PERMISSION,
+	USER_MANAGE_PERMISSION,
+}
+
+func NewPermission(permission string) (Permission, error) {
+	for _, p := range permissions {
+		if string(p) == permission {
+			return p, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid permission %s", permission)
+}
+
+// Resource is what a permission is checked against. Configs carry the id of
+// their schema, so schema scopes also cover their configs. Resources without
+// ids are only covered by global scopes.
+type Resource struct {
+	SchemaId string
+	ConfigId string
+}
+
+func SchemaResource(schemaId string) Resource {
+	return Resource{
+		SchemaId: schemaId,
+	}
+}
+
+func ConfigResource(configId, schemaId string) Resource {
+	return Resource{
+		SchemaId: schemaId,
+		ConfigId: configId,
+	}
+}
+
+type ScopeKind string
+
+const (
+	GLOBAL_SCOPE ScopeKind = ""
+	SCHEMA_SCOPE ScopeKind = "schema"
+	CONFIG_SCOPE ScopeKind = "config"
+)
+
+const (
+	GLOBAL_SCOPE_VALUE = "*"
+	SCOPE_SEPARATOR    = ":"
+	SCOPE_WILDCARD     = "*"
+)
+
+// Scope limits where a role applies: everywhere (*), to a schema or config id
+// (schema:my-service) or to ids starting with a prefix (config:team-a-*).
+type Scope struct {
+	kind   ScopeKind
+	id     string
+	prefix bool
+}
+
+func NewScope(scope string) (Scope, error) {
+	if scope == GLOBAL_SCOPE_VALUE {
+		return Scope{}, nil
+	}
+
+	i := strings.Index(scope, SCOPE_SEPARATOR)
+	if i < 0 {
+		return Scope{}, fmt.Errorf("invalid scope %s", scope)
+	}
+
+	kind := ScopeKind(scope[:i])
+	if kind != SCHEMA_SCOPE && kind != CONFIG_SCOPE {
+		return Scope{}, fmt.Errorf("invalid scope %s", scope)
+	}
+
+	id := scope[i+1:]
+	prefix := strings.HasSuffix(id, SCOPE_WILDCARD)
+	id = strings.TrimSuffix(id, SCOPE_WILDCARD)
+
+	// Use * instead of an empty prefix
+	if id == "" || strings.Contains(id, SCOPE_WILDCARD) {
+		return Scope{}, fmt.Errorf("invalid scope %s", scope)
+	}
+
+	return Scope{
+		kind:   kind,
+		id:     id,
+		prefix: prefix,
+	}, nil
+}
+
+func (s Scope) Kind() ScopeKind {
+	return s.kind
+}
+
+func (s Scope) IsGlobal() bool {
+	return s.kind == GLOBAL_SCOPE
+}
+
+func (s Scope) Value() string {
+	if s.IsGlobal() {
+		return GLOBAL_SCOPE_VALUE
+	}
+
+	value := string(s.kind) + SCOPE_SEPARATOR + s.id
+	if s.prefix {
+		value += SCOPE_WILDCARD
+	}
+
+	return value
+}
+
+func (s Scope) Matches(r Resource) bool {
+	switch s.kind {
+	case SCHEMA_SCOPE:
+		return s.matchesId(r.SchemaId)
+	case CONFIG_SCOPE:
+		return s.matchesId(r.ConfigId)
+	}
+
+	return true
+}
+
+func (s Scope) matchesId(id string) bool {
+	if id == "" {
+		return false
+	}
+
+	if s.prefix {
+		return strings.HasPrefix(id, s.id)
+	}
+
+	return id == s.id
+}
@@ -0,0 +1,145 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewScope(t *testing.T) {
+	type test struct {
+		name  string
+		scope string
+		kind  ScopeKind
+		err   bool
+	}
+
+	tests := []test{
+		{
+			name:  "global",
+			scope: "*",
+			kind:  GLOBAL_SCOPE,
+		},
+		{
+			name:  "schema",
+			scope: "schema:my-service",
+			kind:  SCHEMA_SCOPE,
+		},
+		{
+			name:  "config prefix",
+			scope: "config:team-a-*",
+			kind:  CONFIG_SCOPE,
+		},
+		{
+			name:  "unknown kind",
+			scope: "user:admin",
+			err:   true,
+		},
+		{
+			name:  "without kind",
+			scope: "my-service",
+			err:   true,
+		},
+		{
+			name:  "empty prefix",
+			scope: "schema:*",
+			err:   true,
+		},
+		{
+			name:  "wildcard in the middle",
+			scope: "schema:team-*-a",
+			err:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := NewScope(test.scope)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.kind, s.Kind())
+				assert.Equal(t, test.scope, s.Value())
+			}
+		})
+	}
+}
+
+func TestScopeMatches(t *testing.T) {
+	type test struct {
+		name     string
+		scope    string
+		resource Resource
+		expected bool
+	}
+
+	tests := []test{
+		{
+			name:     "global matches anything",
+			scope:    "*",
+			resource: Resource{},
+			expected: true,
+		},
+		{
+			name:     "schema",
+			scope:    "schema:my-service",
+			resource: SchemaResource("my-service"),
+			expected: true,
+		},
+		{
+			name:     "other schema",
+			scope:    "schema:my-service",
+			resource: SchemaResource("my-service-v2"),
+			expected: false,
+		},
+		{
+			name:     "configs of the schema",
+			scope:    "schema:my-service",
+			resource: ConfigResource("my-service-dev", "my-service"),
+			expected: true,
+		},
+		{
+			name:     "schema prefix",
+			scope:    "schema:team-a-*",
+			resource: SchemaResource("team-a-billing"),
+			expected: true,
+		},
+		{
+			name:     "config",
+			scope:    "config:my-service-dev",
+			resource: ConfigResource("my-service-dev", "my-service"),
+			expected: true,
+		},
+		{
+			name:     "config scope does not cover the schema",
+			scope:    "config:my-service-dev",
+			resource: SchemaResource("my-service"),
+			expected: false,
+		},
+		{
+			name:     "config prefix",
+			scope:    "config:my-service-*",
+			resource: ConfigResource("my-service-prod", "my-service"),
+			expected: true,
+		},
+		{
+			name:     "scoped does not match resources without id",
+			scope:    "schema:my-service",
+			resource: Resource{},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := NewScope(test.scope)
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.expected, s.Matches(test.resource))
+			}
+		})
+	}
+}
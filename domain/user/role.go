@@ -0,0 +1,148 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var (
+	ErrBuiltinRole = errors.New("builtin roles cannot be changed")
+)
+
+const (
+	VIEWER_ROLE = "viewer"
+	EDITOR_ROLE = "editor"
+	ADMIN_ROLE  = "admin"
+)
+
+var builtinRoles = map[string][]Permission{
+	VIEWER_ROLE: {
+		SCHEMA_READ_PERMISSION,
+		CONFIG_READ_PERMISSION,
+	},
+	EDITOR_ROLE: {
+		SCHEMA_READ_PERMISSION,
+		SCHEMA_WRITE_PERMISSION,
+		CONFIG_READ_PERMISSION,
+		CONFIG_WRITE_PERMISSION,
+		KEY_MANAGE_PERMISSION,
+	},
+	ADMIN_ROLE: permissions,
+}
+
+// Role is a named set of permissions, granted to users within a scope.
+// Viewer, editor and admin are builtin, other roles are defined by admins.
+type Role struct {
+	id          models.Id
+	name        string
+	permissions []Permission
+	builtin     bool
+}
+
+func BuildRole(id models.Id, name string, permissions []Permission) (*Role, error) {
+	if name == "" {
+		return nil, errors.New("role name is required")
+	}
+
+	if len(permissions) == 0 {
+		return nil, fmt.Errorf("role %s has no permissions", id.Value())
+	}
+
+	seen := make(map[Permission]bool)
+	for _, p := range permissions {
+		if seen[p] {
+			return nil, fmt.Errorf("role %s has duplicated permission %s", id.Value(), p)
+		}
+
+		seen[p] = true
+	}
+
+	return &Role{
+		id:          id,
+		name:        name,
+		permissions: permissions,
+	}, nil
+}
+
+// NewRole defines a custom role, whose id cannot be a builtin one.
+func NewRole(id models.Id, name string, permissions []Permission) (*Role, error) {
+	if _, ok := BuiltinRole(id); ok {
+		return nil, fmt.Errorf("role %s already exists", id.Value())
+	}
+
+	return BuildRole(id, name, permissions)
+}
+
+// BuiltinRole returns the builtin role with the given id, if any.
+func BuiltinRole(id models.Id) (*Role, bool) {
+	permissions, ok := builtinRoles[id.Value()]
+	if !ok {
+		return nil, false
+	}
+
+	return &Role{
+		id:          id,
+		name:        id.Value(),
+		permissions: permissions,
+		builtin:     true,
+	}, true
+}
+
+// BuiltinRoles returns the builtin roles, from least to most privileged.
+func BuiltinRoles() []*Role {
+	roles := make([]*Role, 0, len(builtinRoles))
+	for _, rawId := range []string{VIEWER_ROLE, EDITOR_ROLE, ADMIN_ROLE} {
+		id, _ := models.BuildId(rawId)
+		r, _ := BuiltinRole(id)
+
+		roles = append(roles, r)
+	}
+
+	return roles
+}
+
+// RoleForAccess maps the legacy access levels to builtin roles.
+func RoleForAccess(access Access) models.Id {
+	rawId := VIEWER_ROLE
+	if access == FULL_ACCESS {
+		rawId = ADMIN_ROLE
+	}
+
+	id, _ := models.BuildId(rawId)
+
+	return id
+}
+
+func (r *Role) Id() models.Id {
+	return r.id
+}
+
+func (r *Role) Name() string {
+	return r.name
+}
+
+func (r *Role) Permissions() []Permission {
+	return r.permissions
+}
+
+func (r *Role) IsBuiltin() bool {
+	return r.builtin
+}
+
+func (r *Role) Update(name string, permissions []Permission) error {
+	if r.builtin {
+		return ErrBuiltinRole
+	}
+
+	updated, err := BuildRole(r.id, name, permissions)
+	if err != nil {
+		return err
+	}
+
+	r.name = updated.name
+	r.permissions = updated.permissions
+
+	return nil
+}
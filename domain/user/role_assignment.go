@@ -0,0 +1,61 @@
+package user
+
+import (
+	"time"
+
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+// RoleAssignment grants a role to a user within a scope.
+type RoleAssignment struct {
+	id        models.Id
+	username  Username
+	roleId    models.Id
+	scope     Scope
+	createdAt time.Time
+}
+
+func BuildRoleAssignment(
+	id models.Id,
+	username Username,
+	roleId models.Id,
+	scope Scope,
+	createdAt time.Time,
+) (*RoleAssignment, error) {
+	return &RoleAssignment{
+		id:        id,
+		username:  username,
+		roleId:    roleId,
+		scope:     scope,
+		createdAt: createdAt,
+	}, nil
+}
+
+func NewRoleAssignment(username Username, roleId models.Id, scope Scope) (*RoleAssignment, error) {
+	id, err := models.NewUuid()
+	if err != nil {
+		return nil, err
+	}
+
+	return BuildRoleAssignment(id, username, roleId, scope, time.Now())
+}
+
+func (a *RoleAssignment) Id() models.Id {
+	return a.id
+}
+
+func (a *RoleAssignment) Username() Username {
+	return a.username
+}
+
+func (a *RoleAssignment) RoleId() models.Id {
+	return a.roleId
+}
+
+func (a *RoleAssignment) Scope() Scope {
+	return a.scope
+}
+
+func (a *RoleAssignment) CreatedAt() time.Time {
+	return a.createdAt
+}
@@ -0,0 +1,31 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var (
+	ErrRoleNotFound           = errors.New("role not found")
+	ErrRoleAssignmentNotFound = errors.New("role assignment not found")
+)
+
+// RoleRepository stores custom roles, builtin ones are not stored.
+type RoleRepository interface {
+	FindById(ctx context.Context, id models.Id) (*Role, error)
+	// FindAll returns custom roles sorted by id
+	FindAll(ctx context.Context) ([]*Role, error)
+	Save(ctx context.Context, role *Role) error
+	Delete(ctx context.Context, id models.Id) error
+}
+
+type RoleAssignmentRepository interface {
+	FindById(ctx context.Context, id models.Id) (*RoleAssignment, error)
+	// FindByUsername returns the assignments of a user sorted by creation
+	FindByUsername(ctx context.Context, username Username) ([]*RoleAssignment, error)
+	FindByRoleId(ctx context.Context, roleId models.Id) ([]*RoleAssignment, error)
+	Save(ctx context.Context, assignment *RoleAssignment) error
+	Delete(ctx context.Context, id models.Id) error
+}
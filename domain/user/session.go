@@ -0,0 +1,195 @@
+package user
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+const (
+	REFRESH_TOKEN_SECRET_LENGTH = 32
+	REFRESH_TOKEN_SEPARATOR     = "."
+)
+
+var (
+	ErrSessionRevoked      = errors.New("session revoked")
+	ErrSessionExpired      = errors.New("session expired")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+)
+
+// RefreshToken is handed out once on login and refresh. It carries the id of
+// its session, only its hash is stored.
+type RefreshToken struct {
+	sessionId models.Id
+	token     string
+}
+
+func NewRefreshToken(token string) (RefreshToken, error) {
+	i := strings.Index(token, REFRESH_TOKEN_SEPARATOR)
+	if i < 0 || i == len(token)-1 {
+		return RefreshToken{}, ErrInvalidRefreshToken
+	}
+
+	sessionId, err := models.BuildId(token[:i])
+	if err != nil {
+		return RefreshToken{}, ErrInvalidRefreshToken
+	}
+
+	return RefreshToken{
+		sessionId: sessionId,
+		token:     token,
+	}, nil
+}
+
+func generateRefreshToken(sessionId models.Id) (RefreshToken, error) {
+	secret := make([]byte, REFRESH_TOKEN_SECRET_LENGTH)
+	if _, err := rand.Read(secret); err != nil {
+		return RefreshToken{}, err
+	}
+
+	return NewRefreshToken(sessionId.Value() + REFRESH_TOKEN_SEPARATOR + base64.RawURLEncoding.EncodeToString(secret))
+}
+
+func (t RefreshToken) SessionId() models.Id {
+	return t.sessionId
+}
+
+func (t RefreshToken) Value() string {
+	return t.token
+}
+
+func (t RefreshToken) Hash() string {
+	hash := sha256.Sum256([]byte(t.token))
+	return hex.EncodeToString(hash[:])
+}
+
+// Session is opened on login and backs the tokens issued for it. Refreshing
+// rotates its refresh token and extends it, revoking it logs its tokens out.
+type Session struct {
+	id                 models.Id
+	username           Username
+	hashedRefreshToken string
+	createdAt          time.Time
+	expiresAt          time.Time
+	revokedAt          *time.Time
+}
+
+func BuildSession(
+	id models.Id,
+	username Username,
+	hashedRefreshToken string,
+	createdAt time.Time,
+	expiresAt time.Time,
+	revokedAt *time.Time,
+) (*Session, error) {
+	if len(hashedRefreshToken) != 64 {
+		return nil, fmt.Errorf("hashed refresh token %s is not SHA256", hashedRefreshToken)
+	}
+
+	return &Session{
+		id:                 id,
+		username:           username,
+		hashedRefreshToken: hashedRefreshToken,
+		createdAt:          createdAt,
+		expiresAt:          expiresAt,
+		revokedAt:          revokedAt,
+	}, nil
+}
+
+// NewSession opens a session lasting ttl, along with its first refresh token.
+func NewSession(username Username, ttl time.Duration, now time.Time) (*Session, RefreshToken, error) {
+	id, err := models.NewUuid()
+	if err != nil {
+		return nil, RefreshToken{}, err
+	}
+
+	refreshToken, err := generateRefreshToken(id)
+	if err != nil {
+		return nil, RefreshToken{}, err
+	}
+
+	s, err := BuildSession(id, username, refreshToken.Hash(), now, now.Add(ttl), nil)
+	if err != nil {
+		return nil, RefreshToken{}, err
+	}
+
+	return s, refreshToken, nil
+}
+
+func (s *Session) Id() models.Id {
+	return s.id
+}
+
+func (s *Session) Username() Username {
+	return s.username
+}
+
+func (s *Session) HashedRefreshToken() string {
+	return s.hashedRefreshToken
+}
+
+func (s *Session) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s *Session) ExpiresAt() time.Time {
+	return s.expiresAt
+}
+
+func (s *Session) RevokedAt() *time.Time {
+	return s.revokedAt
+}
+
+// Check returns why the session cannot be used at the given time, if so.
+func (s *Session) Check(now time.Time) error {
+	if s.revokedAt != nil {
+		return ErrSessionRevoked
+	}
+
+	if !now.Before(s.expiresAt) {
+		return ErrSessionExpired
+	}
+
+	return nil
+}
+
+// Refresh exchanges the current refresh token for a new one and extends the
+// session by ttl. Previous refresh tokens are no longer valid.
+func (s *Session) Refresh(refreshToken RefreshToken, ttl time.Duration, now time.Time) (RefreshToken, error) {
+	if err := s.Check(now); err != nil {
+		return RefreshToken{}, err
+	}
+
+	if !refreshToken.SessionId().Equals(s.id) ||
+		subtle.ConstantTimeCompare([]byte(refreshToken.Hash()), []byte(s.hashedRefreshToken)) != 1 {
+		return RefreshToken{}, ErrInvalidRefreshToken
+	}
+
+	next, err := generateRefreshToken(s.id)
+	if err != nil {
+		return RefreshToken{}, err
+	}
+
+	s.hashedRefreshToken = next.Hash()
+	s.expiresAt = now.Add(ttl)
+
+	return next, nil
+}
+
+func (s *Session) Revoke(now time.Time) error {
+	if s.revokedAt != nil {
+		return ErrSessionRevoked
+	}
+
+	s.revokedAt = &now
+
+	return nil
+}
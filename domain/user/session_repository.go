@@ -0,0 +1,26 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+type SessionRepository interface {
+	FindById(ctx context.Context, id models.Id) (*Session, error)
+	// Save never clears a revocation, so saving a stale session cannot undo
+	// a logout.
+	Save(ctx context.Context, session *Session) error
+	// SaveRefreshed stores a refreshed session only if its refresh token is
+	// still the previous one and it is not revoked, so each refresh token is
+	// used once. Otherwise it returns ErrInvalidRefreshToken.
+	SaveRefreshed(ctx context.Context, session *Session, previousHashedRefreshToken string) error
+	// DeleteInactive removes sessions revoked or expired at the given time
+	DeleteInactive(ctx context.Context, now time.Time) error
+}
@@ -0,0 +1,90 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRefreshToken(t *testing.T) {
+	type test struct {
+		name  string
+		token string
+		err   bool
+	}
+
+	tests := []test{
+		{
+			name:  "valid",
+			token: "0c5a7b5e-7f47-4c1a-9d6f-0d3b1f0e6a11.c2VjcmV0",
+		},
+		{
+			name:  "without secret",
+			token: "0c5a7b5e-7f47-4c1a-9d6f-0d3b1f0e6a11.",
+			err:   true,
+		},
+		{
+			name:  "without session",
+			token: "c2VjcmV0",
+			err:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewRefreshToken(test.token)
+
+			if test.err {
+				assert.Equal(t, ErrInvalidRefreshToken, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSessionLifecycle(t *testing.T) {
+	username, err := NewUsername("admin")
+	utils.Ok(err)
+
+	now := time.Now()
+
+	s, refreshToken, err := NewSession(username, time.Hour, now)
+	utils.Ok(err)
+
+	assert.Equal(t, s.Id(), refreshToken.SessionId())
+	assert.Equal(t, refreshToken.Hash(), s.HashedRefreshToken())
+	assert.NoError(t, s.Check(now))
+	assert.Equal(t, ErrSessionExpired, s.Check(now.Add(time.Hour)))
+
+	// Refresh tokens rotate and extend the session
+	later := now.Add(30 * time.Minute)
+	next, err := s.Refresh(refreshToken, time.Hour, later)
+	if assert.NoError(t, err) {
+		assert.NotEqual(t, refreshToken.Value(), next.Value())
+		assert.Equal(t, later.Add(time.Hour), s.ExpiresAt())
+	}
+
+	_, err = s.Refresh(refreshToken, time.Hour, later)
+	assert.Equal(t, ErrInvalidRefreshToken, err)
+
+	// Refresh tokens of other sessions
+	_, other, err := NewSession(username, time.Hour, now)
+	utils.Ok(err)
+
+	_, err = s.Refresh(other, time.Hour, later)
+	assert.Equal(t, ErrInvalidRefreshToken, err)
+
+	_, err = s.Refresh(next, time.Hour, later.Add(2*time.Hour))
+	assert.Equal(t, ErrSessionExpired, err)
+
+	// Revocation
+	assert.NoError(t, s.Revoke(later))
+	assert.Equal(t, ErrSessionRevoked, s.Revoke(later))
+	assert.Equal(t, ErrSessionRevoked, s.Check(later))
+
+	_, err = s.Refresh(next, time.Hour, later)
+	assert.Equal(t, ErrSessionRevoked, err)
+}
@@ -0,0 +1,110 @@
+package user
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	// Shorter HMAC secrets are easy to brute force from a single token
+	MIN_HMAC_SECRET_LENGTH = 32
+)
+
+type SigningAlgorithm string
+
+const (
+	HS256_ALGORITHM SigningAlgorithm = "HS256"
+	RS256_ALGORITHM SigningAlgorithm = "RS256"
+	EDDSA_ALGORITHM SigningAlgorithm = "EdDSA"
+)
+
+func NewSigningAlgorithm(algorithm string) (SigningAlgorithm, error) {
+	switch algorithm {
+	case string(HS256_ALGORITHM):
+		return HS256_ALGORITHM, nil
+	case string(RS256_ALGORITHM):
+		return RS256_ALGORITHM, nil
+	case string(EDDSA_ALGORITHM):
+		return EDDSA_ALGORITHM, nil
+	}
+
+	return "", fmt.Errorf("invalid signing algorithm %s", algorithm)
+}
+
+// SigningKey signs and verifies tokens. It is identified by the kid header of
+// the tokens it signs, so keys can be rotated while tokens signed by previous
+// ones are still accepted.
+type SigningKey struct {
+	id        string
+	algorithm SigningAlgorithm
+	method    jwt.SigningMethod
+	// Nil for keys only verifying tokens
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// NewSigningKey builds a key from its material: the secret for HS256, a PEM
+// encoded private or public key for RS256 and EdDSA. Keys built from a public
+// key only verify tokens.
+func NewSigningKey(id string, algorithm SigningAlgorithm, material []byte) (*SigningKey, error) {
+	if id == "" {
+		return nil, errors.New("signing key id is required")
+	}
+
+	k := &SigningKey{
+		id:        id,
+		algorithm: algorithm,
+	}
+
+	switch algorithm {
+	case HS256_ALGORITHM:
+		if len(material) < MIN_HMAC_SECRET_LENGTH {
+			return nil, fmt.Errorf("signing key %s: secret shorter than %d bytes", id, MIN_HMAC_SECRET_LENGTH)
+		}
+
+		k.method = jwt.SigningMethodHS256
+		k.signKey = material
+		k.verifyKey = material
+	case RS256_ALGORITHM:
+		k.method = jwt.SigningMethodRS256
+
+		if privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(material); err == nil {
+			k.signKey = privateKey
+			k.verifyKey = &privateKey.PublicKey
+		} else if publicKey, err := jwt.ParseRSAPublicKeyFromPEM(material); err == nil {
+			k.verifyKey = publicKey
+		} else {
+			return nil, fmt.Errorf("signing key %s: invalid RSA key", id)
+		}
+	case EDDSA_ALGORITHM:
+		k.method = jwt.SigningMethodEdDSA
+
+		if privateKey, err := jwt.ParseEdPrivateKeyFromPEM(material); err == nil {
+			k.signKey = privateKey
+			k.verifyKey = privateKey.(ed25519.PrivateKey).Public()
+		} else if publicKey, err := jwt.ParseEdPublicKeyFromPEM(material); err == nil {
+			k.verifyKey = publicKey
+		} else {
+			return nil, fmt.Errorf("signing key %s: invalid Ed25519 key", id)
+		}
+	default:
+		return nil, fmt.Errorf("signing key %s: invalid signing algorithm %s", id, algorithm)
+	}
+
+	return k, nil
+}
+
+func (k *SigningKey) Id() string {
+	return k.id
+}
+
+func (k *SigningKey) Algorithm() SigningAlgorithm {
+	return k.algorithm
+}
+
+func (k *SigningKey) CanSign() bool {
+	return k.signKey != nil
+}
@@ -1,17 +1,18 @@
 package user
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
 )
 
-const (
-	JWT_SECRET = "my-secret"
+var (
+	ErrInvalidToken = errors.New("invalid token")
 )
 
-type TokenData = jwt.MapClaims
-
 type Token struct {
 	token string
 }
@@ -26,14 +27,90 @@ func NewToken(token string) (Token, error) {
 	}, nil
 }
 
-func GenerateToken(data TokenData) (Token, error) {
-	if data == nil {
-		return Token{}, fmt.Errorf("nil token data")
+func (t Token) Value() string {
+	return t.token
+}
+
+// TokenClaims are the claims of a valid access token.
+type TokenClaims struct {
+	Id        string
+	Subject   string
+	SessionId string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+type jwtClaims struct {
+	jwt.StandardClaims
+	SessionId string `json:"sid"`
+}
+
+// TokenIssuer signs short-lived access tokens for sessions. Tokens are signed
+// with a single key and verified with any known key, matched by kid.
+type TokenIssuer struct {
+	signingKey *SigningKey
+	keys       map[string]*SigningKey
+	accessTtl  time.Duration
+	refreshTtl time.Duration
+}
+
+func NewTokenIssuer(
+	signingKey *SigningKey,
+	verifyKeys []*SigningKey,
+	accessTtl time.Duration,
+	refreshTtl time.Duration,
+) (*TokenIssuer, error) {
+	if signingKey == nil || !signingKey.CanSign() {
+		return nil, errors.New("signing key cannot sign tokens")
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, data)
+	if accessTtl <= 0 || refreshTtl <= 0 {
+		return nil, errors.New("token lifetimes must be positive")
+	}
 
-	tokenStr, err := token.SignedString([]byte(JWT_SECRET))
+	keys := map[string]*SigningKey{
+		signingKey.Id(): signingKey,
+	}
+
+	for _, k := range verifyKeys {
+		if _, ok := keys[k.Id()]; ok {
+			return nil, fmt.Errorf("duplicated signing key %s", k.Id())
+		}
+
+		keys[k.Id()] = k
+	}
+
+	return &TokenIssuer{
+		signingKey: signingKey,
+		keys:       keys,
+		accessTtl:  accessTtl,
+		refreshTtl: refreshTtl,
+	}, nil
+}
+
+func (i *TokenIssuer) AccessTtl() time.Duration {
+	return i.accessTtl
+}
+
+func (i *TokenIssuer) RefreshTtl() time.Duration {
+	return i.refreshTtl
+}
+
+// Issue signs an access token for the session user, expiring after the
+// access lifetime.
+func (i *TokenIssuer) Issue(s *Session, now time.Time) (Token, error) {
+	token := jwt.NewWithClaims(i.signingKey.method, jwtClaims{
+		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.NewString(),
+			Subject:   s.Username().Value(),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(i.accessTtl).Unix(),
+		},
+		SessionId: s.Id().Value(),
+	})
+	token.Header["kid"] = i.signingKey.Id()
+
+	tokenStr, err := token.SignedString(i.signingKey.signKey)
 	if err != nil {
 		return Token{}, err
 	}
@@ -41,26 +118,40 @@ func GenerateToken(data TokenData) (Token, error) {
 	return NewToken(tokenStr)
 }
 
-func (t Token) ParseData() (TokenData, error) {
-	token, err := jwt.Parse(t.token, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+// Parse verifies the token signature and lifetime. Tokens without kid, with
+// an unknown one or with an algorithm other than its key's are rejected.
+func (i *TokenIssuer) Parse(t Token) (*TokenClaims, error) {
+	var claims jwtClaims
+
+	token, err := jwt.ParseWithClaims(t.token, &claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+
+		k, ok := i.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %s", kid)
+		}
+
+		if token.Method.Alg() != k.method.Alg() {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 
-		return []byte(JWT_SECRET), nil
+		return k.verifyKey, nil
 	})
-	if err != nil {
-		return nil, err
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
 	}
 
-	claims, ok := token.Claims.(TokenData)
-	if !ok || !token.Valid {
-		return nil, fmt.Errorf("token %s is invalid", t.token)
+	// Standard claims are optional for the library, not for access tokens
+	if claims.Id == "" || claims.Subject == "" || claims.SessionId == "" ||
+		claims.IssuedAt == 0 || claims.ExpiresAt == 0 {
+		return nil, ErrInvalidToken
 	}
 
-	return claims, nil
-}
-
-func (t Token) Value() string {
-	return t.token
+	return &TokenClaims{
+		Id:        claims.Id,
+		Subject:   claims.Subject,
+		SessionId: claims.SessionId,
+		IssuedAt:  time.Unix(claims.IssuedAt, 0),
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0),
+	}, nil
 }
@@ -1,53 +1,259 @@
 package user
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/aboglioli/configd/pkg/utils"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestTokenSigningAndValidation(t *testing.T) {
+func pemKeys(privateKey interface{}, publicKey interface{}) ([]byte, []byte) {
+	private, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	utils.Ok(err)
+
+	public, err := x509.MarshalPKIXPublicKey(publicKey)
+	utils.Ok(err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: private}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: public})
+}
+
+func TestNewSigningKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	utils.Ok(err)
+	rsaPrivate, rsaPublic := pemKeys(rsaKey, &rsaKey.PublicKey)
+
+	edPublicKey, edKey, err := ed25519.GenerateKey(rand.Reader)
+	utils.Ok(err)
+	edPrivate, edPublic := pemKeys(edKey, edPublicKey)
+
 	type test struct {
-		name            string
-		data            TokenData
-		errOnGenerating bool
-		errOnParsing    bool
+		name      string
+		id        string
+		algorithm SigningAlgorithm
+		material  []byte
+		canSign   bool
+		err       bool
 	}
 
 	tests := []test{
 		{
-			name: "basic data",
-			data: TokenData{
-				"message": "hello",
-			},
-			errOnGenerating: false,
-			errOnParsing:    false,
+			name:      "hmac secret",
+			id:        "hmac",
+			algorithm: HS256_ALGORITHM,
+			material:  []byte(strings.Repeat("s", MIN_HMAC_SECRET_LENGTH)),
+			canSign:   true,
+		},
+		{
+			name:      "short hmac secret",
+			id:        "hmac",
+			algorithm: HS256_ALGORITHM,
+			material:  []byte("my-secret"),
+			err:       true,
+		},
+		{
+			name:      "rsa private key",
+			id:        "rsa",
+			algorithm: RS256_ALGORITHM,
+			material:  rsaPrivate,
+			canSign:   true,
+		},
+		{
+			name:      "rsa public key",
+			id:        "rsa",
+			algorithm: RS256_ALGORITHM,
+			material:  rsaPublic,
+		},
+		{
+			name:      "ed25519 private key",
+			id:        "ed",
+			algorithm: EDDSA_ALGORITHM,
+			material:  edPrivate,
+			canSign:   true,
+		},
+		{
+			name:      "ed25519 public key",
+			id:        "ed",
+			algorithm: EDDSA_ALGORITHM,
+			material:  edPublic,
+		},
+		{
+			name:      "ed25519 key for rsa",
+			id:        "rsa",
+			algorithm: RS256_ALGORITHM,
+			material:  edPrivate,
+			err:       true,
 		},
 		{
-			name:            "nil data",
-			errOnGenerating: true,
-			errOnParsing:    true,
+			name:      "without id",
+			algorithm: HS256_ALGORITHM,
+			material:  []byte(strings.Repeat("s", MIN_HMAC_SECRET_LENGTH)),
+			err:       true,
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			token, err := GenerateToken(test.data)
-			if test.errOnGenerating {
+			k, err := NewSigningKey(test.id, test.algorithm, test.material)
+
+			if test.err {
 				assert.Error(t, err)
-			} else {
-				if assert.NoError(t, err) {
-					if assert.Greater(t, len(token.token), 30) {
-						d, err := token.ParseData()
-						if test.errOnParsing {
-							assert.Error(t, err)
-						} else {
-							assert.NoError(t, err)
-							assert.Equal(t, test.data, d)
-						}
-					}
-				}
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.id, k.Id())
+				assert.Equal(t, test.canSign, k.CanSign())
 			}
 		})
 	}
 }
+
+func TestTokenIssuer(t *testing.T) {
+	username, err := NewUsername("admin")
+	utils.Ok(err)
+
+	now := time.Now()
+
+	s, _, err := NewSession(username, time.Hour, now)
+	utils.Ok(err)
+
+	hmacKey, err := NewSigningKey("hmac", HS256_ALGORITHM, []byte(strings.Repeat("a", 32)))
+	utils.Ok(err)
+
+	otherHmacKey, err := NewSigningKey("hmac", HS256_ALGORITHM, []byte(strings.Repeat("b", 32)))
+	utils.Ok(err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	utils.Ok(err)
+	rsaPrivate, rsaPublic := pemKeys(rsaKey, &rsaKey.PublicKey)
+
+	rsaSigningKey, err := NewSigningKey("rsa", RS256_ALGORITHM, rsaPrivate)
+	utils.Ok(err)
+
+	rsaVerifyKey, err := NewSigningKey("rsa", RS256_ALGORITHM, rsaPublic)
+	utils.Ok(err)
+
+	edPublicKey, edKey, err := ed25519.GenerateKey(rand.Reader)
+	utils.Ok(err)
+	edPrivate, _ := pemKeys(edKey, edPublicKey)
+
+	edSigningKey, err := NewSigningKey("ed", EDDSA_ALGORITHM, edPrivate)
+	utils.Ok(err)
+
+	newIssuer := func(signingKey *SigningKey, verifyKeys ...*SigningKey) *TokenIssuer {
+		issuer, err := NewTokenIssuer(signingKey, verifyKeys, time.Minute, time.Hour)
+		utils.Ok(err)
+
+		return issuer
+	}
+
+	type test struct {
+		name     string
+		issuer   *TokenIssuer
+		verifier *TokenIssuer
+		issuedAt time.Time
+		err      bool
+	}
+
+	tests := []test{
+		{
+			name:     "hmac",
+			issuer:   newIssuer(hmacKey),
+			verifier: newIssuer(hmacKey),
+			issuedAt: now,
+		},
+		{
+			name:     "rsa",
+			issuer:   newIssuer(rsaSigningKey),
+			verifier: newIssuer(edSigningKey, rsaVerifyKey),
+			issuedAt: now,
+		},
+		{
+			name:     "eddsa",
+			issuer:   newIssuer(edSigningKey),
+			verifier: newIssuer(edSigningKey),
+			issuedAt: now,
+		},
+		{
+			name:     "signed by a rotated key",
+			issuer:   newIssuer(hmacKey),
+			verifier: newIssuer(edSigningKey, hmacKey),
+			issuedAt: now,
+		},
+		{
+			name:     "unknown key",
+			issuer:   newIssuer(rsaSigningKey),
+			verifier: newIssuer(edSigningKey),
+			issuedAt: now,
+			err:      true,
+		},
+		{
+			name:     "same kid different secret",
+			issuer:   newIssuer(hmacKey),
+			verifier: newIssuer(otherHmacKey),
+			issuedAt: now,
+			err:      true,
+		},
+		{
+			name:     "expired",
+			issuer:   newIssuer(hmacKey),
+			verifier: newIssuer(hmacKey),
+			issuedAt: now.Add(-2 * time.Minute),
+			err:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			token, err := test.issuer.Issue(s, test.issuedAt)
+			utils.Ok(err)
+
+			claims, err := test.verifier.Parse(token)
+
+			if test.err {
+				assert.Equal(t, ErrInvalidToken, err)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.NotEmpty(t, claims.Id)
+				assert.Equal(t, "admin", claims.Subject)
+				assert.Equal(t, s.Id().Value(), claims.SessionId)
+				assert.Equal(t, test.issuedAt.Unix(), claims.IssuedAt.Unix())
+				assert.Equal(t, test.issuedAt.Add(time.Minute).Unix(), claims.ExpiresAt.Unix())
+			}
+		})
+	}
+
+	t.Run("verification-only signing key", func(t *testing.T) {
+		_, err := NewTokenIssuer(rsaVerifyKey, nil, time.Minute, time.Hour)
+		assert.Error(t, err)
+	})
+
+	t.Run("duplicated key id", func(t *testing.T) {
+		_, err := NewTokenIssuer(hmacKey, []*SigningKey{otherHmacKey}, time.Minute, time.Hour)
+		assert.Error(t, err)
+	})
+
+	t.Run("tampered token", func(t *testing.T) {
+		issuer := newIssuer(hmacKey)
+
+		token, err := issuer.Issue(s, now)
+		utils.Ok(err)
+
+		parts := strings.Split(token.Value(), ".")
+		tampered, err := NewToken(parts[0] + "." + parts[1] + "x." + parts[2])
+		utils.Ok(err)
+
+		_, err = issuer.Parse(tampered)
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+}
@@ -3,6 +3,9 @@ package user
 import (
 	"errors"
 	"time"
+
+	"github.com/aboglioli/configd/pkg/events"
+	"github.com/aboglioli/configd/pkg/models"
 )
 
 var (
@@ -10,17 +13,21 @@ var (
 )
 
 type User struct {
+	agg *models.AggregateRoot
+
 	username       Username
 	hashedPassword HashedPassword
 	access         Access
 }
 
 func BuildUser(
+	agg *models.AggregateRoot,
 	username Username,
 	hashedPassword HashedPassword,
 	access Access,
 ) (*User, error) {
 	return &User{
+		agg:            agg,
 		username:       username,
 		hashedPassword: hashedPassword,
 		access:         access,
@@ -37,7 +44,44 @@ func NewUser(
 		return nil, err
 	}
 
-	return BuildUser(username, hashedPassword, access)
+	id, err := models.BuildId(username.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	agg, err := models.NewAggregateRoot(id)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := BuildUser(agg, username, hashedPassword, access)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := events.NewEvent(
+		u.agg.Id().Value(),
+		UserRegisteredTopic,
+		UserRegistered{
+			Username: u.username.Value(),
+			Access:   string(u.access),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	u.agg.RecordEvent(event)
+
+	return u, nil
+}
+
+func (u *User) Base() models.ReadOnlyAggregateRoot {
+	return u.agg
+}
+
+func (u *User) ClearEvents() {
+	u.agg.ClearEvents()
 }
 
 func (u *User) Username() Username {
@@ -52,13 +96,17 @@ func (u *User) Access() Access {
 	return u.access
 }
 
-func (u *User) Login(username Username, password Password) (Token, error) {
-	if u.username.Equals(username) && u.hashedPassword.Validate(password) {
-		return GenerateToken(TokenData{
-			"username":  u.username.Value(),
-			"timestamp": time.Now(),
-		})
+// Login checks the credentials and opens a session lasting ttl, returned
+// with its refresh token.
+func (u *User) Login(
+	username Username,
+	password Password,
+	ttl time.Duration,
+	now time.Time,
+) (*Session, RefreshToken, error) {
+	if !u.username.Equals(username) || !u.hashedPassword.Validate(password) {
+		return nil, RefreshToken{}, ErrInvalidLogin
 	}
 
-	return Token{}, ErrInvalidLogin
+	return NewSession(u.username, ttl, now)
 }
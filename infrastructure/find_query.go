@@ -0,0 +1,91 @@
+package infrastructure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// findQuery builds listing queries over tables having name, created_at,
+// updated_at and deleted_at columns. Soft-deleted rows are always excluded.
+type findQuery struct {
+	dialect    sqlDialect
+	conditions []string
+	args       []interface{}
+}
+
+func newFindQuery(dialect sqlDialect) *findQuery {
+	return &findQuery{
+		dialect:    dialect,
+		conditions: []string{"deleted_at IS NULL"},
+	}
+}
+
+func (q *findQuery) arg(v interface{}) string {
+	q.args = append(q.args, v)
+	return q.dialect.param(len(q.args))
+}
+
+func (q *findQuery) equals(column string, v interface{}) {
+	q.conditions = append(q.conditions, column+" = "+q.arg(v))
+}
+
+// nameContains matches a case-insensitive substring of the name.
+func (q *findQuery) nameContains(substr string) {
+	q.conditions = append(
+		q.conditions,
+		fmt.Sprintf(`LOWER(name) LIKE %s ESCAPE '\'`, q.arg("%"+likeEscaper.Replace(strings.ToLower(substr))+"%")),
+	)
+}
+
+// build returns the full query ordered by the sort field and id, starting
+// after the cursor.
+func (q *findQuery) build(selectFrom string, pagination models.Pagination) (string, []interface{}) {
+	column := string(pagination.Sort.Field)
+	id := "id"
+	if q.dialect.binaryCollation != "" {
+		if pagination.Sort.Field == models.SORT_BY_NAME {
+			column += " " + q.dialect.binaryCollation
+		}
+		id += " " + q.dialect.binaryCollation
+	}
+
+	cmp, order := ">", "ASC"
+	if pagination.Sort.Desc {
+		cmp, order = "<", "DESC"
+	}
+
+	if cursor := pagination.Cursor; cursor != nil {
+		var value interface{} = cursor.Name
+		if cursor.Time != nil {
+			value = q.dialect.timeValue(*cursor.Time)
+		}
+
+		// The value is bound twice to keep numbered placeholders in order
+		q.conditions = append(q.conditions, fmt.Sprintf(
+			"(%[1]s %[2]s %[3]s OR (%[1]s = %[4]s AND %[5]s %[2]s %[6]s))",
+			column,
+			cmp,
+			q.arg(value),
+			q.arg(value),
+			id,
+			q.arg(cursor.Id),
+		))
+	}
+
+	query := fmt.Sprintf(
+		"%s\nWHERE %s\nORDER BY %s %s, %s %s\nLIMIT %d",
+		selectFrom,
+		strings.Join(q.conditions, " AND "),
+		column,
+		order,
+		id,
+		order,
+		pagination.Limit,
+	)
+
+	return query, q.args
+}
@@ -0,0 +1,205 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/props"
+	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/domain/security"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+// In-memory repositories store and return copies of aggregates, like any
+// persistent backend would, so unsaved changes never leak into the store.
+
+func copyAggregateRoot(base models.ReadOnlyAggregateRoot) (*models.AggregateRoot, error) {
+	return models.BuildAggregateRoot(
+		base.Id(),
+		base.CreatedAt(),
+		base.UpdatedAt(),
+		base.DeletedAt(),
+		base.Version(),
+	)
+}
+
+func copyConfig(c *config.Config) (*config.Config, error) {
+	agg, err := copyAggregateRoot(c.Base())
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := copyConfigData(c.Config())
+	if err != nil {
+		return nil, err
+	}
+
+	return config.BuildConfig(agg, c.SchemaId(), c.SchemaVersion(), c.Name(), data, copyKeyOrder(c.KeyOrder()))
+}
+
+func copyConfigData(data config.ConfigData) (config.ConfigData, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var copied config.ConfigData
+	if err := json.Unmarshal(b, &copied); err != nil {
+		return nil, err
+	}
+
+	return copied, nil
+}
+
+func copyRevision(r *config.Revision) (*config.Revision, error) {
+	data, err := copyConfigData(r.Config())
+	if err != nil {
+		return nil, err
+	}
+
+	return config.BuildRevision(r.ConfigId(), r.Version(), data, copyKeyOrder(r.KeyOrder()), r.Author(), r.CreatedAt())
+}
+
+func copyKeyOrder(order codec.KeyOrder) codec.KeyOrder {
+	if order == nil {
+		return nil
+	}
+
+	copied := make(codec.KeyOrder, len(order))
+	copy(copied, order)
+
+	return copied
+}
+
+func copySchema(s *schema.Schema) (*schema.Schema, error) {
+	agg, err := copyAggregateRoot(s.Base())
+	if err != nil {
+		return nil, err
+	}
+
+	// Props are immutable
+	ps := make([]*props.Prop, 0, len(s.Props()))
+	for _, p := range s.Props() {
+		ps = append(ps, p)
+	}
+
+	return schema.BuildSchema(agg, s.Name(), s.ValidationMode(), copyKeyOrder(s.KeyOrder()), ps...)
+}
+
+func copySchemaVersion(v *schema.Version) (*schema.Version, error) {
+	// Props are immutable
+	ps := make([]*props.Prop, 0, len(v.Props()))
+	for _, p := range v.Props() {
+		ps = append(ps, p)
+	}
+
+	return schema.BuildVersion(v.SchemaId(), v.Version(), v.CreatedAt(), copyKeyOrder(v.KeyOrder()), ps...)
+}
+
+func copyUser(u *user.User) (*user.User, error) {
+	agg, err := copyAggregateRoot(u.Base())
+	if err != nil {
+		return nil, err
+	}
+
+	return user.BuildUser(agg, u.Username(), u.HashedPassword(), u.Access())
+}
+
+func copyAuthorization(a *security.Authorization) (*security.Authorization, error) {
+	return security.BuildAuthorization(
+		a.Id(),
+		a.Name(),
+		a.Prefix(),
+		a.HashedApiKey(),
+		a.ResourceId(),
+		a.Access(),
+		a.CreatedAt(),
+		copyTime(a.LastUsedAt()),
+		copyTime(a.ExpiresAt()),
+		copyTime(a.RevokedAt()),
+	)
+}
+
+func copySession(s *user.Session) (*user.Session, error) {
+	return user.BuildSession(
+		s.Id(),
+		s.Username(),
+		s.HashedRefreshToken(),
+		s.CreatedAt(),
+		s.ExpiresAt(),
+		copyTime(s.RevokedAt()),
+	)
+}
+
+func copyRole(r *user.Role) (*user.Role, error) {
+	permissions := make([]user.Permission, len(r.Permissions()))
+	copy(permissions, r.Permissions())
+
+	return user.BuildRole(r.Id(), r.Name(), permissions)
+}
+
+func copyRoleAssignment(a *user.RoleAssignment) (*user.RoleAssignment, error) {
+	return user.BuildRoleAssignment(a.Id(), a.Username(), a.RoleId(), a.Scope(), a.CreatedAt())
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	copied := *t
+	return &copied
+}
+
+// paginateInMem sorts matching items and keeps the page located after the
+// cursor. Items are identified by their index.
+func paginateInMem(
+	pagination models.Pagination,
+	n int,
+	item func(i int) (models.ReadOnlyAggregateRoot, string),
+) []int {
+	indexes := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		agg, name := item(i)
+		if pagination.Cursor == nil || pagination.Cursor.After(
+			pagination.Sort,
+			name,
+			sortTime(pagination.Sort, agg),
+			agg.Id().Value(),
+		) {
+			indexes = append(indexes, i)
+		}
+	}
+
+	sort.Slice(indexes, func(a, b int) bool {
+		aggA, nameA := item(indexes[a])
+		aggB, nameB := item(indexes[b])
+
+		// b after a means a goes first
+		cursor := models.NewCursor(pagination.Sort, aggA, nameA)
+		return cursor.After(pagination.Sort, nameB, sortTime(pagination.Sort, aggB), aggB.Id().Value())
+	})
+
+	if len(indexes) > pagination.Limit {
+		indexes = indexes[:pagination.Limit]
+	}
+
+	return indexes
+}
+
+func sortTime(s models.Sort, agg models.ReadOnlyAggregateRoot) time.Time {
+	if s.Field == models.SORT_BY_UPDATED_AT {
+		return agg.UpdatedAt()
+	}
+
+	return agg.CreatedAt()
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
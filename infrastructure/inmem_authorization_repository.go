@@ -2,11 +2,16 @@ package infrastructure
 
 import (
 	"context"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/aboglioli/configd/domain/security"
+	"github.com/aboglioli/configd/pkg/models"
 )
 
+var _ security.AuthorizationRepository = (*InMemAuthorizationRepository)(nil)
+
 type InMemAuthorizationRepository struct {
 	mux            sync.Mutex
 	authorizations map[string]*security.Authorization
@@ -18,6 +23,22 @@ func NewInMemAuthorizationRepository() *InMemAuthorizationRepository {
 	}
 }
 
+func (r *InMemAuthorizationRepository) FindById(
+	ctx context.Context,
+	id models.Id,
+) (*security.Authorization, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	for _, authorization := range r.authorizations {
+		if authorization.Id().Equals(id) {
+			return copyAuthorization(authorization)
+		}
+	}
+
+	return nil, security.ErrNotFound
+}
+
 func (r *InMemAuthorizationRepository) FindByApiKey(
 	ctx context.Context,
 	hashedApiKey security.HashedApiKey,
@@ -26,21 +47,119 @@ func (r *InMemAuthorizationRepository) FindByApiKey(
 	defer r.mux.Unlock()
 
 	if s, ok := r.authorizations[hashedApiKey.Value()]; ok {
-		return s, nil
+		return copyAuthorization(s)
 	}
 
 	return nil, security.ErrNotFound
 }
 
+func (r *InMemAuthorizationRepository) FindByResourceId(
+	ctx context.Context,
+	resourceId models.Id,
+) ([]*security.Authorization, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	found := make([]*security.Authorization, 0)
+	for _, authorization := range r.authorizations {
+		if !authorization.ResourceId().Equals(resourceId) {
+			continue
+		}
+
+		authorization, err := copyAuthorization(authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		found = append(found, authorization)
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].CreatedAt().Before(found[j].CreatedAt())
+	})
+
+	return found, nil
+}
+
 func (r *InMemAuthorizationRepository) Save(ctx context.Context, authorization *security.Authorization) error {
 	r.mux.Lock()
 	defer r.mux.Unlock()
 
+	lastUsedAt := authorization.LastUsedAt()
+	expiresAt := authorization.ExpiresAt()
+	revokedAt := authorization.RevokedAt()
+
+	// Stale authorizations cannot enable their API keys again
+	if stored, ok := r.authorizations[authorization.HashedApiKey().Value()]; ok {
+		if stored.LastUsedAt() != nil && (lastUsedAt == nil || stored.LastUsedAt().After(*lastUsedAt)) {
+			lastUsedAt = stored.LastUsedAt()
+		}
+
+		if stored.ExpiresAt() != nil && (expiresAt == nil || stored.ExpiresAt().Before(*expiresAt)) {
+			expiresAt = stored.ExpiresAt()
+		}
+
+		if stored.RevokedAt() != nil {
+			revokedAt = stored.RevokedAt()
+		}
+	}
+
+	authorization, err := security.BuildAuthorization(
+		authorization.Id(),
+		authorization.Name(),
+		authorization.Prefix(),
+		authorization.HashedApiKey(),
+		authorization.ResourceId(),
+		authorization.Access(),
+		authorization.CreatedAt(),
+		copyTime(lastUsedAt),
+		copyTime(expiresAt),
+		copyTime(revokedAt),
+	)
+	if err != nil {
+		return err
+	}
+
 	r.authorizations[authorization.HashedApiKey().Value()] = authorization
 
 	return nil
 }
 
+func (r *InMemAuthorizationRepository) UpdateLastUsedAt(
+	ctx context.Context,
+	id models.Id,
+	lastUsedAt time.Time,
+) error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	for key, a := range r.authorizations {
+		if !a.Id().Equals(id) || a.RevokedAt() != nil {
+			continue
+		}
+
+		updated, err := security.BuildAuthorization(
+			a.Id(),
+			a.Name(),
+			a.Prefix(),
+			a.HashedApiKey(),
+			a.ResourceId(),
+			a.Access(),
+			a.CreatedAt(),
+			&lastUsedAt,
+			copyTime(a.ExpiresAt()),
+			copyTime(a.RevokedAt()),
+		)
+		if err != nil {
+			return err
+		}
+
+		r.authorizations[key] = updated
+	}
+
+	return nil
+}
+
 func (r *InMemAuthorizationRepository) Delete(ctx context.Context, hashedApiKey security.HashedApiKey) error {
 	r.mux.Lock()
 	defer r.mux.Unlock()
@@ -49,3 +168,16 @@ func (r *InMemAuthorizationRepository) Delete(ctx context.Context, hashedApiKey
 
 	return nil
 }
+
+func (r *InMemAuthorizationRepository) DeleteByResourceId(ctx context.Context, resourceId models.Id) error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	for hashedApiKey, authorization := range r.authorizations {
+		if authorization.ResourceId().Equals(resourceId) {
+			delete(r.authorizations, hashedApiKey)
+		}
+	}
+
+	return nil
+}
@@ -25,8 +25,8 @@ func (r *InMemConfigRepository) FindById(ctx context.Context, id models.Id) (*co
 	r.mux.Lock()
 	defer r.mux.Unlock()
 
-	if s, ok := r.configs[id.Value()]; ok {
-		return s, nil
+	if c, ok := r.configs[id.Value()]; ok && !c.Base().IsDeleted() {
+		return copyConfig(c)
 	}
 
 	return nil, config.ErrNotFound
@@ -39,7 +39,43 @@ func (r *InMemConfigRepository) FindBySchemaId(ctx context.Context, schemaId mod
 	found := make([]*config.Config, 0)
 
 	for _, c := range r.configs {
-		if c.SchemaId().Equals(schemaId) {
+		if c.SchemaId().Equals(schemaId) && !c.Base().IsDeleted() {
+			c, err := copyConfig(c)
+			if err != nil {
+				return nil, err
+			}
+
+			found = append(found, c)
+		}
+	}
+
+	return found, nil
+}
+
+func (r *InMemConfigRepository) FindDeletedById(ctx context.Context, id models.Id) (*config.Config, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if c, ok := r.configs[id.Value()]; ok && c.Base().IsDeleted() {
+		return copyConfig(c)
+	}
+
+	return nil, config.ErrNotFound
+}
+
+func (r *InMemConfigRepository) FindDeleted(ctx context.Context) ([]*config.Config, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	found := make([]*config.Config, 0)
+
+	for _, c := range r.configs {
+		if c.Base().IsDeleted() {
+			c, err := copyConfig(c)
+			if err != nil {
+				return nil, err
+			}
+
 			found = append(found, c)
 		}
 	}
@@ -47,11 +83,60 @@ func (r *InMemConfigRepository) FindBySchemaId(ctx context.Context, schemaId mod
 	return found, nil
 }
 
+func (r *InMemConfigRepository) Find(ctx context.Context, criteria config.Criteria) ([]*config.Config, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	matching := make([]*config.Config, 0)
+	for _, c := range r.configs {
+		if c.Base().IsDeleted() {
+			continue
+		}
+
+		if criteria.SchemaId != nil && !c.SchemaId().Equals(*criteria.SchemaId) {
+			continue
+		}
+
+		if criteria.Name != "" && !containsFold(c.Name().Value(), criteria.Name) {
+			continue
+		}
+
+		matching = append(matching, c)
+	}
+
+	indexes := paginateInMem(criteria.Pagination, len(matching), func(i int) (models.ReadOnlyAggregateRoot, string) {
+		return matching[i].Base(), matching[i].Name().Value()
+	})
+
+	found := make([]*config.Config, 0, len(indexes))
+	for _, i := range indexes {
+		c, err := copyConfig(matching[i])
+		if err != nil {
+			return nil, err
+		}
+
+		found = append(found, c)
+	}
+
+	return found, nil
+}
+
 func (r *InMemConfigRepository) Save(ctx context.Context, config *config.Config) error {
 	r.mux.Lock()
 	defer r.mux.Unlock()
 
-	r.configs[config.Base().Id().Value()] = config
+	if stored, ok := r.configs[config.Base().Id().Value()]; ok {
+		if config.Base().Version() != stored.Base().Version()+1 {
+			return models.ErrVersionConflict
+		}
+	}
+
+	c, err := copyConfig(config)
+	if err != nil {
+		return err
+	}
+
+	r.configs[c.Base().Id().Value()] = c
 
 	return nil
 }
@@ -1,28 +1,40 @@
 package infrastructure
 
 import (
+	"sync"
+
 	"github.com/aboglioli/configd/pkg/events"
 )
 
+var _ events.EventPublisher = (*InMemEventBus)(nil)
+var _ events.EventSubscriber = (*InMemEventBus)(nil)
+
+type inMemSubscription struct {
+	id uint64
+	fn events.SubscriptionFunc
+}
+
 type InMemEventBus struct {
-	subscriptions map[string][]events.SubscriptionFunc
+	mux           sync.RWMutex
+	lastId        uint64
+	subscriptions map[string][]inMemSubscription
 }
 
 func NewInMemEventBus() *InMemEventBus {
 	return &InMemEventBus{
-		subscriptions: make(map[string][]events.SubscriptionFunc),
+		subscriptions: make(map[string][]inMemSubscription),
 	}
 }
 
 func (eb *InMemEventBus) Publish(events ...events.Event) error {
 	for _, event := range events {
-		subs, ok := eb.subscriptions[event.Topic().Value()]
-		if !ok {
-			continue
-		}
+		// Copy subscriptions so handlers can subscribe or unsubscribe
+		eb.mux.RLock()
+		subs := append([]inMemSubscription(nil), eb.subscriptions[event.Topic().Value()]...)
+		eb.mux.RUnlock()
 
 		for _, sub := range subs {
-			if err := sub(event); err != nil {
+			if err := sub.fn(event); err != nil {
 				return err
 			}
 		}
@@ -31,15 +43,43 @@ func (eb *InMemEventBus) Publish(events ...events.Event) error {
 	return nil
 }
 
-func (eb *InMemEventBus) Subscribe(fn events.SubscriptionFunc, topics ...events.Topic) {
+func (eb *InMemEventBus) Subscribe(fn events.SubscriptionFunc, topics ...events.Topic) events.UnsubscribeFunc {
+	eb.mux.Lock()
+	defer eb.mux.Unlock()
+
+	eb.lastId += 1
+	sub := inMemSubscription{
+		id: eb.lastId,
+		fn: fn,
+	}
+
 	for _, topic := range topics {
-		subs, ok := eb.subscriptions[topic.Value()]
-		if !ok {
-			subs = make([]events.SubscriptionFunc, 0)
-		}
+		eb.subscriptions[topic.Value()] = append(eb.subscriptions[topic.Value()], sub)
+	}
 
-		subs = append(subs, fn)
+	return func() {
+		eb.unsubscribe(sub.id, topics...)
+	}
+}
+
+func (eb *InMemEventBus) unsubscribe(id uint64, topics ...events.Topic) {
+	eb.mux.Lock()
+	defer eb.mux.Unlock()
+
+	for _, topic := range topics {
+		subs := eb.subscriptions[topic.Value()]
 
-		eb.subscriptions[topic.Value()] = subs
+		remaining := make([]inMemSubscription, 0, len(subs))
+		for _, sub := range subs {
+			if sub.id != id {
+				remaining = append(remaining, sub)
+			}
+		}
+
+		if len(remaining) == 0 {
+			delete(eb.subscriptions, topic.Value())
+		} else {
+			eb.subscriptions[topic.Value()] = remaining
+		}
 	}
 }
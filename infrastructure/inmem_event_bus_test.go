@@ -0,0 +1,87 @@
+package infrastructure
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/aboglioli/configd/pkg/events"
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemEventBusUnsubscribe(t *testing.T) {
+	eb := NewInMemEventBus()
+
+	created := events.NewTopic("config", "created")
+	deleted := events.NewTopic("config", "deleted")
+
+	var mux sync.Mutex
+	received := make([]string, 0)
+	unsubscribe := eb.Subscribe(func(evt events.Event) error {
+		mux.Lock()
+		defer mux.Unlock()
+
+		received = append(received, evt.Topic().Value())
+
+		return nil
+	}, created, deleted)
+
+	// Other subscriptions are kept
+	other := 0
+	unsubscribeOther := eb.Subscribe(func(evt events.Event) error {
+		other++
+		return nil
+	}, created)
+
+	publish := func(topic events.Topic) {
+		evt, err := events.NewEvent("my-config", topic, nil)
+		utils.Ok(err)
+		utils.Ok(eb.Publish(evt))
+	}
+
+	publish(created)
+	publish(deleted)
+
+	unsubscribe()
+
+	publish(created)
+	publish(deleted)
+
+	assert.Equal(t, []string{"config.created", "config.deleted"}, received)
+	assert.Equal(t, 2, other)
+
+	unsubscribeOther()
+
+	// Topics without subscriptions are removed
+	eb.mux.RLock()
+	assert.Empty(t, eb.subscriptions)
+	eb.mux.RUnlock()
+}
+
+func TestInMemEventBusConcurrentSubscriptions(t *testing.T) {
+	eb := NewInMemEventBus()
+	topic := events.NewTopic("config", "changed")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			unsubscribe := eb.Subscribe(func(evt events.Event) error {
+				return nil
+			}, topic)
+
+			evt, err := events.NewEvent("my-config", topic, nil)
+			utils.Ok(err)
+			utils.Ok(eb.Publish(evt))
+
+			unsubscribe()
+		}()
+	}
+	wg.Wait()
+
+	eb.mux.RLock()
+	assert.Empty(t, eb.subscriptions)
+	eb.mux.RUnlock()
+}
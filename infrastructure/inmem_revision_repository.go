@@ -0,0 +1,92 @@
+package infrastructure
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var _ config.RevisionRepository = (*InMemRevisionRepository)(nil)
+
+type InMemRevisionRepository struct {
+	mux       sync.Mutex
+	revisions map[string]map[uint]*config.Revision
+}
+
+func NewInMemRevisionRepository() *InMemRevisionRepository {
+	return &InMemRevisionRepository{
+		revisions: make(map[string]map[uint]*config.Revision),
+	}
+}
+
+func (r *InMemRevisionRepository) FindByConfigId(ctx context.Context, configId models.Id) ([]*config.Revision, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	found := make([]*config.Revision, 0, len(r.revisions[configId.Value()]))
+	for _, rev := range r.revisions[configId.Value()] {
+		rev, err := copyRevision(rev)
+		if err != nil {
+			return nil, err
+		}
+
+		found = append(found, rev)
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].Version() < found[j].Version()
+	})
+
+	return found, nil
+}
+
+func (r *InMemRevisionRepository) FindByVersion(
+	ctx context.Context,
+	configId models.Id,
+	version uint,
+) (*config.Revision, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if rev, ok := r.revisions[configId.Value()][version]; ok {
+		return copyRevision(rev)
+	}
+
+	return nil, config.ErrRevisionNotFound
+}
+
+func (r *InMemRevisionRepository) Save(ctx context.Context, revision *config.Revision) error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	revisions, ok := r.revisions[revision.ConfigId().Value()]
+	if !ok {
+		revisions = make(map[uint]*config.Revision)
+		r.revisions[revision.ConfigId().Value()] = revisions
+	}
+
+	if _, ok := revisions[revision.Version()]; ok {
+		return nil
+	}
+
+	rev, err := copyRevision(revision)
+	if err != nil {
+		return err
+	}
+
+	revisions[rev.Version()] = rev
+
+	return nil
+}
+
+func (r *InMemRevisionRepository) DeleteByConfigId(ctx context.Context, configId models.Id) error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	delete(r.revisions, configId.Value())
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package infrastructure
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var _ user.RoleAssignmentRepository = (*InMemRoleAssignmentRepository)(nil)
+
+type InMemRoleAssignmentRepository struct {
+	mux         sync.Mutex
+	assignments map[string]*user.RoleAssignment
+}
+
+func NewInMemRoleAssignmentRepository() *InMemRoleAssignmentRepository {
+	return &InMemRoleAssignmentRepository{
+		assignments: make(map[string]*user.RoleAssignment),
+	}
+}
+
+func (r *InMemRoleAssignmentRepository) FindById(ctx context.Context, id models.Id) (*user.RoleAssignment, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if a, ok := r.assignments[id.Value()]; ok {
+		return copyRoleAssignment(a)
+	}
+
+	return nil, user.ErrRoleAssignmentNotFound
+}
+
+func (r *InMemRoleAssignmentRepository) FindByUsername(
+	ctx context.Context,
+	username user.Username,
+) ([]*user.RoleAssignment, error) {
+	return r.find(func(a *user.RoleAssignment) bool {
+		return a.Username().Equals(username)
+	})
+}
+
+func (r *InMemRoleAssignmentRepository) FindByRoleId(
+	ctx context.Context,
+	roleId models.Id,
+) ([]*user.RoleAssignment, error) {
+	return r.find(func(a *user.RoleAssignment) bool {
+		return a.RoleId().Equals(roleId)
+	})
+}
+
+func (r *InMemRoleAssignmentRepository) find(
+	matches func(a *user.RoleAssignment) bool,
+) ([]*user.RoleAssignment, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	found := make([]*user.RoleAssignment, 0)
+	for _, a := range r.assignments {
+		if !matches(a) {
+			continue
+		}
+
+		a, err := copyRoleAssignment(a)
+		if err != nil {
+			return nil, err
+		}
+
+		found = append(found, a)
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].CreatedAt().Before(found[j].CreatedAt())
+	})
+
+	return found, nil
+}
+
+func (r *InMemRoleAssignmentRepository) Save(ctx context.Context, assignment *user.RoleAssignment) error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	a, err := copyRoleAssignment(assignment)
+	if err != nil {
+		return err
+	}
+
+	r.assignments[a.Id().Value()] = a
+
+	return nil
+}
+
+func (r *InMemRoleAssignmentRepository) Delete(ctx context.Context, id models.Id) error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	delete(r.assignments, id.Value())
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package infrastructure
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var _ user.RoleRepository = (*InMemRoleRepository)(nil)
+
+type InMemRoleRepository struct {
+	mux   sync.Mutex
+	roles map[string]*user.Role
+}
+
+func NewInMemRoleRepository() *InMemRoleRepository {
+	return &InMemRoleRepository{
+		roles: make(map[string]*user.Role),
+	}
+}
+
+func (r *InMemRoleRepository) FindById(ctx context.Context, id models.Id) (*user.Role, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if role, ok := r.roles[id.Value()]; ok {
+		return copyRole(role)
+	}
+
+	return nil, user.ErrRoleNotFound
+}
+
+func (r *InMemRoleRepository) FindAll(ctx context.Context) ([]*user.Role, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	roles := make([]*user.Role, 0, len(r.roles))
+	for _, role := range r.roles {
+		role, err := copyRole(role)
+		if err != nil {
+			return nil, err
+		}
+
+		roles = append(roles, role)
+	}
+
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i].Id().Value() < roles[j].Id().Value()
+	})
+
+	return roles, nil
+}
+
+func (r *InMemRoleRepository) Save(ctx context.Context, role *user.Role) error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	role, err := copyRole(role)
+	if err != nil {
+		return err
+	}
+
+	r.roles[role.Id().Value()] = role
+
+	return nil
+}
+
+func (r *InMemRoleRepository) Delete(ctx context.Context, id models.Id) error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	delete(r.roles, id.Value())
+
+	return nil
+}
@@ -25,18 +25,94 @@ func (r *InMemSchemaRepository) FindById(ctx context.Context, id models.Id) (*sc
 	r.mux.Lock()
 	defer r.mux.Unlock()
 
-	if s, ok := r.schemas[id.Value()]; ok {
-		return s, nil
+	if s, ok := r.schemas[id.Value()]; ok && !s.Base().IsDeleted() {
+		return copySchema(s)
 	}
 
 	return nil, schema.ErrNotFound
 }
 
+func (r *InMemSchemaRepository) FindDeletedById(ctx context.Context, id models.Id) (*schema.Schema, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if s, ok := r.schemas[id.Value()]; ok && s.Base().IsDeleted() {
+		return copySchema(s)
+	}
+
+	return nil, schema.ErrNotFound
+}
+
+func (r *InMemSchemaRepository) FindDeleted(ctx context.Context) ([]*schema.Schema, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	found := make([]*schema.Schema, 0)
+
+	for _, s := range r.schemas {
+		if s.Base().IsDeleted() {
+			s, err := copySchema(s)
+			if err != nil {
+				return nil, err
+			}
+
+			found = append(found, s)
+		}
+	}
+
+	return found, nil
+}
+
+func (r *InMemSchemaRepository) Find(ctx context.Context, criteria schema.Criteria) ([]*schema.Schema, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	matching := make([]*schema.Schema, 0)
+	for _, s := range r.schemas {
+		if s.Base().IsDeleted() {
+			continue
+		}
+
+		if criteria.Name != "" && !containsFold(s.Name().Value(), criteria.Name) {
+			continue
+		}
+
+		matching = append(matching, s)
+	}
+
+	indexes := paginateInMem(criteria.Pagination, len(matching), func(i int) (models.ReadOnlyAggregateRoot, string) {
+		return matching[i].Base(), matching[i].Name().Value()
+	})
+
+	found := make([]*schema.Schema, 0, len(indexes))
+	for _, i := range indexes {
+		s, err := copySchema(matching[i])
+		if err != nil {
+			return nil, err
+		}
+
+		found = append(found, s)
+	}
+
+	return found, nil
+}
+
 func (r *InMemSchemaRepository) Save(ctx context.Context, schema *schema.Schema) error {
 	r.mux.Lock()
 	defer r.mux.Unlock()
 
-	r.schemas[schema.Base().Id().Value()] = schema
+	if stored, ok := r.schemas[schema.Base().Id().Value()]; ok {
+		if schema.Base().Version() != stored.Base().Version()+1 {
+			return models.ErrVersionConflict
+		}
+	}
+
+	s, err := copySchema(schema)
+	if err != nil {
+		return err
+	}
+
+	r.schemas[s.Base().Id().Value()] = s
 
 	return nil
 }
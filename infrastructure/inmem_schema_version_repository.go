@@ -0,0 +1,110 @@
+package infrastructure
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var _ schema.VersionRepository = (*InMemSchemaVersionRepository)(nil)
+
+type InMemSchemaVersionRepository struct {
+	mux      sync.Mutex
+	versions map[string]map[uint]*schema.Version
+}
+
+func NewInMemSchemaVersionRepository() *InMemSchemaVersionRepository {
+	return &InMemSchemaVersionRepository{
+		versions: make(map[string]map[uint]*schema.Version),
+	}
+}
+
+func (r *InMemSchemaVersionRepository) FindBySchemaId(ctx context.Context, schemaId models.Id) ([]*schema.Version, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	found := make([]*schema.Version, 0, len(r.versions[schemaId.Value()]))
+	for _, v := range r.versions[schemaId.Value()] {
+		v, err := copySchemaVersion(v)
+		if err != nil {
+			return nil, err
+		}
+
+		found = append(found, v)
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].Version() < found[j].Version()
+	})
+
+	return found, nil
+}
+
+func (r *InMemSchemaVersionRepository) FindByVersion(
+	ctx context.Context,
+	schemaId models.Id,
+	version uint,
+) (*schema.Version, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if v, ok := r.versions[schemaId.Value()][version]; ok {
+		return copySchemaVersion(v)
+	}
+
+	return nil, schema.ErrVersionNotFound
+}
+
+func (r *InMemSchemaVersionRepository) FindLatest(ctx context.Context, schemaId models.Id) (*schema.Version, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	var latest *schema.Version
+	for _, v := range r.versions[schemaId.Value()] {
+		if latest == nil || v.Version() > latest.Version() {
+			latest = v
+		}
+	}
+
+	if latest == nil {
+		return nil, schema.ErrVersionNotFound
+	}
+
+	return copySchemaVersion(latest)
+}
+
+func (r *InMemSchemaVersionRepository) Save(ctx context.Context, version *schema.Version) error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	versions, ok := r.versions[version.SchemaId().Value()]
+	if !ok {
+		versions = make(map[uint]*schema.Version)
+		r.versions[version.SchemaId().Value()] = versions
+	}
+
+	if _, ok := versions[version.Version()]; ok {
+		return nil
+	}
+
+	v, err := copySchemaVersion(version)
+	if err != nil {
+		return err
+	}
+
+	versions[v.Version()] = v
+
+	return nil
+}
+
+func (r *InMemSchemaVersionRepository) DeleteBySchemaId(ctx context.Context, schemaId models.Id) error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	delete(r.versions, schemaId.Value())
+
+	return nil
+}
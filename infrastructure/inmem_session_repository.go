@@ -0,0 +1,96 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var _ user.SessionRepository = (*InMemSessionRepository)(nil)
+
+type InMemSessionRepository struct {
+	mux      sync.Mutex
+	sessions map[string]*user.Session
+}
+
+func NewInMemSessionRepository() *InMemSessionRepository {
+	return &InMemSessionRepository{
+		sessions: make(map[string]*user.Session),
+	}
+}
+
+func (r *InMemSessionRepository) FindById(ctx context.Context, id models.Id) (*user.Session, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if s, ok := r.sessions[id.Value()]; ok {
+		return copySession(s)
+	}
+
+	return nil, user.ErrSessionNotFound
+}
+
+func (r *InMemSessionRepository) Save(ctx context.Context, session *user.Session) error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	revokedAt := session.RevokedAt()
+	if stored, ok := r.sessions[session.Id().Value()]; ok && stored.RevokedAt() != nil {
+		revokedAt = stored.RevokedAt()
+	}
+
+	s, err := user.BuildSession(
+		session.Id(),
+		session.Username(),
+		session.HashedRefreshToken(),
+		session.CreatedAt(),
+		session.ExpiresAt(),
+		copyTime(revokedAt),
+	)
+	if err != nil {
+		return err
+	}
+
+	r.sessions[s.Id().Value()] = s
+
+	return nil
+}
+
+func (r *InMemSessionRepository) SaveRefreshed(
+	ctx context.Context,
+	session *user.Session,
+	previousHashedRefreshToken string,
+) error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	stored, ok := r.sessions[session.Id().Value()]
+	if !ok || stored.RevokedAt() != nil || stored.HashedRefreshToken() != previousHashedRefreshToken {
+		return user.ErrInvalidRefreshToken
+	}
+
+	s, err := copySession(session)
+	if err != nil {
+		return err
+	}
+
+	r.sessions[s.Id().Value()] = s
+
+	return nil
+}
+
+func (r *InMemSessionRepository) DeleteInactive(ctx context.Context, now time.Time) error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	for id, s := range r.sessions {
+		if s.Check(now) != nil {
+			delete(r.sessions, id)
+		}
+	}
+
+	return nil
+}
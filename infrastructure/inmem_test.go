@@ -0,0 +1,51 @@
+package infrastructure
+
+import (
+	"testing"
+)
+
+func TestInMemRepositories(t *testing.T) {
+	t.Run("config", func(t *testing.T) {
+		testConfigRepository(t, NewInMemConfigRepository())
+	})
+
+	t.Run("revision", func(t *testing.T) {
+		testRevisionRepository(t, NewInMemRevisionRepository())
+	})
+
+	t.Run("schema", func(t *testing.T) {
+		testSchemaRepository(t, NewInMemSchemaRepository())
+	})
+
+	t.Run("schema version", func(t *testing.T) {
+		testSchemaVersionRepository(t, NewInMemSchemaVersionRepository())
+	})
+
+	t.Run("config find", func(t *testing.T) {
+		testConfigFind(t, NewInMemConfigRepository())
+	})
+
+	t.Run("schema find", func(t *testing.T) {
+		testSchemaFind(t, NewInMemSchemaRepository())
+	})
+
+	t.Run("user", func(t *testing.T) {
+		testUserRepository(t, NewInMemUserRepository())
+	})
+
+	t.Run("authorization", func(t *testing.T) {
+		testAuthorizationRepository(t, NewInMemAuthorizationRepository())
+	})
+
+	t.Run("session", func(t *testing.T) {
+		testSessionRepository(t, NewInMemSessionRepository())
+	})
+
+	t.Run("role", func(t *testing.T) {
+		testRoleRepository(t, NewInMemRoleRepository())
+	})
+
+	t.Run("role assignment", func(t *testing.T) {
+		testRoleAssignmentRepository(t, NewInMemRoleAssignmentRepository())
+	})
+}
@@ -25,7 +25,7 @@ func (r *InMemUserRepository) FindByUsername(ctx context.Context, username user.
 	defer r.mux.Unlock()
 
 	if u, ok := r.users[username.Value()]; ok {
-		return u, nil
+		return copyUser(u)
 	}
 
 	return nil, user.ErrNotFound
@@ -35,7 +35,12 @@ func (r *InMemUserRepository) Save(ctx context.Context, user *user.User) error {
 	r.mux.Lock()
 	defer r.mux.Unlock()
 
-	r.users[user.Username().Value()] = user
+	u, err := copyUser(user)
+	if err != nil {
+		return err
+	}
+
+	r.users[u.Username().Value()] = u
 
 	return nil
 }
@@ -0,0 +1,108 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+type migration struct {
+	version    uint
+	statements []string
+}
+
+type sqlDialect struct {
+	// Placeholder for the first query argument
+	placeholder string
+	// Optional statement executed at the beginning of each migration
+	// transaction to serialize concurrent runners
+	lock string
+	// Collation giving byte-wise string ordering
+	binaryCollation string
+	// Converts timestamps to their stored representation
+	timeValue func(t time.Time) interface{}
+}
+
+// param returns the placeholder for the n-th (1-based) query argument.
+func (d sqlDialect) param(n int) string {
+	if d.placeholder == "?" {
+		return "?"
+	}
+
+	return fmt.Sprintf("$%d", n)
+}
+
+// migrate applies pending migrations in ascending version order. Each one runs
+// inside its own transaction and is recorded in the schema_migrations table.
+func migrate(ctx context.Context, db *sql.DB, dialect sqlDialect, migrations []migration) error {
+	if _, err := db.ExecContext(
+		ctx,
+		`CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY
+		)`,
+	); err != nil {
+		return err
+	}
+
+	sorted := append([]migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].version < sorted[j].version
+	})
+
+	for _, m := range sorted {
+		if err := applyMigration(ctx, db, dialect, m); err != nil {
+			return fmt.Errorf("migration %d: %s", m.version, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func applyMigration(ctx context.Context, db *sql.DB, dialect sqlDialect, m migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if dialect.lock != "" {
+		if _, err := tx.ExecContext(ctx, dialect.lock); err != nil {
+			return err
+		}
+	}
+
+	var applied int
+	if err := tx.QueryRowContext(
+		ctx,
+		"SELECT COUNT(*) FROM schema_migrations WHERE version = "+dialect.placeholder,
+		m.version,
+	).Scan(&applied); err != nil {
+		return err
+	}
+
+	if applied > 0 {
+		return tx.Commit()
+	}
+
+	for _, stmt := range m.statements {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		"INSERT INTO schema_migrations (version) VALUES ("+dialect.placeholder+")",
+		m.version,
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
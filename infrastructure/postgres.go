@@ -0,0 +1,185 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+var postgresDialect = sqlDialect{
+	placeholder: "$1",
+	// Arbitrary key shared by every configd instance running migrations
+	lock:            "SELECT pg_advisory_xact_lock(4242)",
+	binaryCollation: `COLLATE "C"`,
+	timeValue: func(t time.Time) interface{} {
+		return t
+	},
+}
+
+var postgresMigrations = []migration{
+	{
+		version: 1,
+		statements: []string{
+			`CREATE TABLE schemas (
+				id TEXT PRIMARY KEY,
+				name TEXT NOT NULL,
+				props JSONB NOT NULL,
+				created_at TIMESTAMPTZ NOT NULL,
+				updated_at TIMESTAMPTZ NOT NULL,
+				deleted_at TIMESTAMPTZ,
+				version BIGINT NOT NULL
+			)`,
+			`CREATE TABLE configs (
+				id TEXT PRIMARY KEY,
+				schema_id TEXT NOT NULL,
+				name TEXT NOT NULL,
+				config JSONB NOT NULL,
+				created_at TIMESTAMPTZ NOT NULL,
+				updated_at TIMESTAMPTZ NOT NULL,
+				deleted_at TIMESTAMPTZ,
+				version BIGINT NOT NULL
+			)`,
+			`CREATE INDEX configs_schema_id_idx ON configs (schema_id)`,
+			`CREATE TABLE users (
+				username TEXT PRIMARY KEY,
+				hashed_password TEXT NOT NULL,
+				access TEXT NOT NULL,
+				created_at TIMESTAMPTZ NOT NULL,
+				updated_at TIMESTAMPTZ NOT NULL,
+				deleted_at TIMESTAMPTZ,
+				version BIGINT NOT NULL
+			)`,
+			`CREATE TABLE authorizations (
+				hashed_api_key TEXT PRIMARY KEY,
+				resource_id TEXT NOT NULL,
+				access TEXT NOT NULL
+			)`,
+		},
+	},
+	{
+		version: 2,
+		statements: []string{
+			`ALTER TABLE schemas ADD COLUMN validation_mode TEXT NOT NULL DEFAULT 'warn'`,
+		},
+	},
+	{
+		version: 3,
+		statements: []string{
+			`CREATE TABLE config_revisions (
+				config_id TEXT NOT NULL,
+				version BIGINT NOT NULL,
+				config JSONB NOT NULL,
+				author TEXT NOT NULL,
+				created_at TIMESTAMPTZ NOT NULL,
+				PRIMARY KEY (config_id, version)
+			)`,
+			// Existing configs start their history at their current version
+			`INSERT INTO config_revisions (config_id, version, config, author, created_at)
+			SELECT id, version, config, '', updated_at FROM configs`,
+		},
+	},
+	{
+		version: 4,
+		statements: []string{
+			// Zero tracks the latest schema version
+			`ALTER TABLE configs ADD COLUMN schema_version BIGINT NOT NULL DEFAULT 0`,
+			`CREATE TABLE schema_versions (
+				schema_id TEXT NOT NULL,
+				version BIGINT NOT NULL,
+				props JSONB NOT NULL,
+				created_at TIMESTAMPTZ NOT NULL,
+				PRIMARY KEY (schema_id, version)
+			)`,
+			// Existing schemas start their history at their current version
+			`INSERT INTO schema_versions (schema_id, version, props, created_at)
+			SELECT id, version, props, updated_at FROM schemas`,
+		},
+	},
+	{
+		version: 5,
+		statements: []string{
+			`ALTER TABLE authorizations ADD COLUMN id TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE authorizations ADD COLUMN name TEXT NOT NULL DEFAULT 'default'`,
+			`ALTER TABLE authorizations ADD COLUMN prefix TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE authorizations ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now()`,
+			`ALTER TABLE authorizations ADD COLUMN last_used_at TIMESTAMPTZ`,
+			`ALTER TABLE authorizations ADD COLUMN expires_at TIMESTAMPTZ`,
+			`ALTER TABLE authorizations ADD COLUMN revoked_at TIMESTAMPTZ`,
+			// Existing keys were issued when creating configs, their prefix
+			// is unknown
+			`UPDATE authorizations SET id = substr(hashed_api_key, 1, 16)`,
+			`CREATE UNIQUE INDEX authorizations_id_idx ON authorizations (id)`,
+			`CREATE INDEX authorizations_resource_id_idx ON authorizations (resource_id)`,
+		},
+	},
+	{
+		version: 6,
+		statements: []string{
+			`CREATE TABLE sessions (
+				id TEXT PRIMARY KEY,
+				username TEXT NOT NULL,
+				hashed_refresh_token TEXT NOT NULL,
+				created_at TIMESTAMPTZ NOT NULL,
+				expires_at TIMESTAMPTZ NOT NULL,
+				revoked_at TIMESTAMPTZ
+			)`,
+			`CREATE INDEX sessions_expires_at_idx ON sessions (expires_at)`,
+		},
+	},
+	{
+		version: 7,
+		statements: []string{
+			`CREATE TABLE roles (
+				id TEXT PRIMARY KEY,
+				name TEXT NOT NULL,
+				permissions JSONB NOT NULL
+			)`,
+			`CREATE TABLE role_assignments (
+				id TEXT PRIMARY KEY,
+				username TEXT NOT NULL,
+				role_id TEXT NOT NULL,
+				scope TEXT NOT NULL,
+				created_at TIMESTAMPTZ NOT NULL
+			)`,
+			`CREATE INDEX role_assignments_username_idx ON role_assignments (username)`,
+			`CREATE INDEX role_assignments_role_id_idx ON role_assignments (role_id)`,
+			// Existing users keep their access as a global builtin role
+			`INSERT INTO role_assignments (id, username, role_id, scope, created_at)
+			SELECT 'access-' || username, username, CASE access WHEN 'full_access' THEN 'admin' ELSE 'viewer' END, '*', created_at
+			FROM users`,
+		},
+	},
+	{
+		version: 8,
+		statements: []string{
+			// Existing documents render their keys sorted, as they did
+			`ALTER TABLE configs ADD COLUMN key_order JSONB NOT NULL DEFAULT '[]'`,
+			`ALTER TABLE config_revisions ADD COLUMN key_order JSONB NOT NULL DEFAULT '[]'`,
+			`ALTER TABLE schemas ADD COLUMN key_order JSONB NOT NULL DEFAULT '[]'`,
+			`ALTER TABLE schema_versions ADD COLUMN key_order JSONB NOT NULL DEFAULT '[]'`,
+		},
+	},
+}
+
+// OpenPostgres connects to the PostgreSQL database described by url and
+// applies pending migrations.
+func OpenPostgres(url string) (*sql.DB, error) {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	if err := migrate(context.Background(), db, postgresDialect, postgresMigrations); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
@@ -0,0 +1,145 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var _ config.RevisionRepository = (*PostgresRevisionRepository)(nil)
+
+type PostgresRevisionRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRevisionRepository(db *sql.DB) *PostgresRevisionRepository {
+	return &PostgresRevisionRepository{
+		db: db,
+	}
+}
+
+func (r *PostgresRevisionRepository) FindByConfigId(ctx context.Context, configId models.Id) ([]*config.Revision, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT config_id, version, config, key_order, author, created_at
+		FROM config_revisions
+		WHERE config_id = $1
+		ORDER BY version`,
+		configId.Value(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make([]*config.Revision, 0)
+	for rows.Next() {
+		rev, err := scanPostgresRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		found = append(found, rev)
+	}
+
+	return found, rows.Err()
+}
+
+func (r *PostgresRevisionRepository) FindByVersion(
+	ctx context.Context,
+	configId models.Id,
+	version uint,
+) (*config.Revision, error) {
+	row := r.db.QueryRowContext(
+		ctx,
+		`SELECT config_id, version, config, key_order, author, created_at
+		FROM config_revisions
+		WHERE config_id = $1 AND version = $2`,
+		configId.Value(),
+		version,
+	)
+
+	rev, err := scanPostgresRevision(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, config.ErrRevisionNotFound
+	}
+
+	return rev, err
+}
+
+func (r *PostgresRevisionRepository) Save(ctx context.Context, revision *config.Revision) error {
+	data, err := json.Marshal(revision.Config())
+	if err != nil {
+		return err
+	}
+
+	keyOrder, err := json.Marshal(revision.KeyOrder())
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(
+		ctx,
+		`INSERT INTO config_revisions (config_id, version, config, key_order, author, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (config_id, version) DO NOTHING`,
+		revision.ConfigId().Value(),
+		revision.Version(),
+		string(data),
+		string(keyOrder),
+		revision.Author(),
+		revision.CreatedAt(),
+	)
+
+	return err
+}
+
+func (r *PostgresRevisionRepository) DeleteByConfigId(ctx context.Context, configId models.Id) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM config_revisions WHERE config_id = $1`, configId.Value())
+	return err
+}
+
+func scanPostgresRevision(row rowScanner) (*config.Revision, error) {
+	var (
+		rawConfigId string
+		version     uint
+		rawConfig   []byte
+		rawKeyOrder []byte
+		author      string
+		createdAt   time.Time
+	)
+
+	if err := row.Scan(
+		&rawConfigId,
+		&version,
+		&rawConfig,
+		&rawKeyOrder,
+		&author,
+		&createdAt,
+	); err != nil {
+		return nil, err
+	}
+
+	configId, err := models.BuildId(rawConfigId)
+	if err != nil {
+		return nil, err
+	}
+
+	var data config.ConfigData
+	if err := json.Unmarshal(rawConfig, &data); err != nil {
+		return nil, err
+	}
+
+	var keyOrder codec.KeyOrder
+	if err := json.Unmarshal(rawKeyOrder, &keyOrder); err != nil {
+		return nil, err
+	}
+
+	return config.BuildRevision(configId, version, data, keyOrder, author, createdAt)
+}
@@ -0,0 +1,128 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var _ user.RoleAssignmentRepository = (*PostgresRoleAssignmentRepository)(nil)
+
+type PostgresRoleAssignmentRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRoleAssignmentRepository(db *sql.DB) *PostgresRoleAssignmentRepository {
+	return &PostgresRoleAssignmentRepository{
+		db: db,
+	}
+}
+
+func (r *PostgresRoleAssignmentRepository) FindById(ctx context.Context, id models.Id) (*user.RoleAssignment, error) {
+	row := r.db.QueryRowContext(
+		ctx,
+		`SELECT id, username, role_id, scope, created_at
+		FROM role_assignments
+		WHERE id = $1`,
+		id.Value(),
+	)
+
+	a, err := scanPostgresRoleAssignment(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, user.ErrRoleAssignmentNotFound
+	}
+
+	return a, err
+}
+
+func (r *PostgresRoleAssignmentRepository) FindByUsername(
+	ctx context.Context,
+	username user.Username,
+) ([]*user.RoleAssignment, error) {
+	return r.find(
+		ctx,
+		`SELECT id, username, role_id, scope, created_at
+		FROM role_assignments
+		WHERE username = $1
+		ORDER BY created_at`,
+		username.Value(),
+	)
+}
+
+func (r *PostgresRoleAssignmentRepository) FindByRoleId(
+	ctx context.Context,
+	roleId models.Id,
+) ([]*user.RoleAssignment, error) {
+	return r.find(
+		ctx,
+		`SELECT id, username, role_id, scope, created_at
+		FROM role_assignments
+		WHERE role_id = $1
+		ORDER BY created_at`,
+		roleId.Value(),
+	)
+}
+
+func (r *PostgresRoleAssignmentRepository) find(
+	ctx context.Context,
+	query string,
+	args ...interface{},
+) ([]*user.RoleAssignment, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make([]*user.RoleAssignment, 0)
+	for rows.Next() {
+		a, err := scanPostgresRoleAssignment(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		found = append(found, a)
+	}
+
+	return found, rows.Err()
+}
+
+func (r *PostgresRoleAssignmentRepository) Save(ctx context.Context, a *user.RoleAssignment) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO role_assignments (id, username, role_id, scope, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO UPDATE SET
+			role_id = excluded.role_id,
+			scope = excluded.scope`,
+		a.Id().Value(),
+		a.Username().Value(),
+		a.RoleId().Value(),
+		a.Scope().Value(),
+		a.CreatedAt(),
+	)
+
+	return err
+}
+
+func (r *PostgresRoleAssignmentRepository) Delete(ctx context.Context, id models.Id) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM role_assignments WHERE id = $1`, id.Value())
+	return err
+}
+
+func scanPostgresRoleAssignment(row rowScanner) (*user.RoleAssignment, error) {
+	var (
+		rawId, rawUsername, rawRoleId, rawScope string
+		createdAt                               time.Time
+	)
+
+	if err := row.Scan(&rawId, &rawUsername, &rawRoleId, &rawScope, &createdAt); err != nil {
+		return nil, err
+	}
+
+	return buildRoleAssignment(rawId, rawUsername, rawRoleId, rawScope, createdAt)
+}
@@ -0,0 +1,91 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var _ user.RoleRepository = (*PostgresRoleRepository)(nil)
+
+type PostgresRoleRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRoleRepository(db *sql.DB) *PostgresRoleRepository {
+	return &PostgresRoleRepository{
+		db: db,
+	}
+}
+
+func (r *PostgresRoleRepository) FindById(ctx context.Context, id models.Id) (*user.Role, error) {
+	row := r.db.QueryRowContext(
+		ctx,
+		`SELECT id, name, permissions
+		FROM roles
+		WHERE id = $1`,
+		id.Value(),
+	)
+
+	role, err := scanRole(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, user.ErrRoleNotFound
+	}
+
+	return role, err
+}
+
+func (r *PostgresRoleRepository) FindAll(ctx context.Context) ([]*user.Role, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT id, name, permissions
+		FROM roles
+		ORDER BY id`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := make([]*user.Role, 0)
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		roles = append(roles, role)
+	}
+
+	return roles, rows.Err()
+}
+
+func (r *PostgresRoleRepository) Save(ctx context.Context, role *user.Role) error {
+	permissions, err := json.Marshal(role.Permissions())
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(
+		ctx,
+		`INSERT INTO roles (id, name, permissions)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (id) DO UPDATE SET
+			name = excluded.name,
+			permissions = excluded.permissions`,
+		role.Id().Value(),
+		role.Name(),
+		string(permissions),
+	)
+
+	return err
+}
+
+func (r *PostgresRoleRepository) Delete(ctx context.Context, id models.Id) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM roles WHERE id = $1`, id.Value())
+	return err
+}
@@ -0,0 +1,242 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var _ schema.SchemaRepository = (*PostgresSchemaRepository)(nil)
+
+type PostgresSchemaRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresSchemaRepository(db *sql.DB) *PostgresSchemaRepository {
+	return &PostgresSchemaRepository{
+		db: db,
+	}
+}
+
+func (r *PostgresSchemaRepository) FindById(ctx context.Context, id models.Id) (*schema.Schema, error) {
+	row := r.db.QueryRowContext(
+		ctx,
+		`SELECT id, name, validation_mode, props, key_order, created_at, updated_at, deleted_at, version
+		FROM schemas
+		WHERE id = $1 AND deleted_at IS NULL`,
+		id.Value(),
+	)
+
+	s, err := scanPostgresSchema(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, schema.ErrNotFound
+	}
+
+	return s, err
+}
+
+func (r *PostgresSchemaRepository) FindDeletedById(ctx context.Context, id models.Id) (*schema.Schema, error) {
+	row := r.db.QueryRowContext(
+		ctx,
+		`SELECT id, name, validation_mode, props, key_order, created_at, updated_at, deleted_at, version
+		FROM schemas
+		WHERE id = $1 AND deleted_at IS NOT NULL`,
+		id.Value(),
+	)
+
+	s, err := scanPostgresSchema(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, schema.ErrNotFound
+	}
+
+	return s, err
+}
+
+func (r *PostgresSchemaRepository) FindDeleted(ctx context.Context) ([]*schema.Schema, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT id, name, validation_mode, props, key_order, created_at, updated_at, deleted_at, version
+		FROM schemas
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make([]*schema.Schema, 0)
+	for rows.Next() {
+		s, err := scanPostgresSchema(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		found = append(found, s)
+	}
+
+	return found, rows.Err()
+}
+
+func (r *PostgresSchemaRepository) Find(ctx context.Context, criteria schema.Criteria) ([]*schema.Schema, error) {
+	q := newFindQuery(postgresDialect)
+
+	if criteria.Name != "" {
+		q.nameContains(criteria.Name)
+	}
+
+	query, args := q.build(
+		`SELECT id, name, validation_mode, props, key_order, created_at, updated_at, deleted_at, version
+		FROM schemas`,
+		criteria.Pagination,
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make([]*schema.Schema, 0)
+	for rows.Next() {
+		s, err := scanPostgresSchema(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		found = append(found, s)
+	}
+
+	return found, rows.Err()
+}
+
+func (r *PostgresSchemaRepository) Save(ctx context.Context, s *schema.Schema) error {
+	props, err := json.Marshal(s.ToMap())
+	if err != nil {
+		return err
+	}
+
+	keyOrder, err := json.Marshal(s.KeyOrder())
+	if err != nil {
+		return err
+	}
+
+	// Updates only apply over the previous version
+	res, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO schemas (id, name, validation_mode, props, key_order, created_at, updated_at, deleted_at, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (id) DO UPDATE SET
+			name = excluded.name,
+			validation_mode = excluded.validation_mode,
+			props = excluded.props,
+			key_order = excluded.key_order,
+			updated_at = excluded.updated_at,
+			deleted_at = excluded.deleted_at,
+			version = excluded.version
+		WHERE schemas.version = excluded.version - 1`,
+		s.Base().Id().Value(),
+		s.Name().Value(),
+		s.ValidationMode().String(),
+		string(props),
+		string(keyOrder),
+		s.Base().CreatedAt(),
+		s.Base().UpdatedAt(),
+		s.Base().DeletedAt(),
+		s.Base().Version(),
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return models.ErrVersionConflict
+	}
+
+	return nil
+}
+
+func (r *PostgresSchemaRepository) Delete(ctx context.Context, id models.Id) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM schemas WHERE id = $1`, id.Value())
+	return err
+}
+
+func scanPostgresSchema(row rowScanner) (*schema.Schema, error) {
+	var (
+		rawId, rawName        string
+		rawValidationMode     string
+		rawProps, rawKeyOrder []byte
+		createdAt, updatedAt  time.Time
+		deletedAt             sql.NullTime
+		version               uint
+	)
+
+	if err := row.Scan(
+		&rawId,
+		&rawName,
+		&rawValidationMode,
+		&rawProps,
+		&rawKeyOrder,
+		&createdAt,
+		&updatedAt,
+		&deletedAt,
+		&version,
+	); err != nil {
+		return nil, err
+	}
+
+	id, err := models.BuildId(rawId)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := schema.NewName(rawName)
+	if err != nil {
+		return nil, err
+	}
+
+	validationMode, err := schema.NewValidationMode(rawValidationMode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Props are stored with the same format returned by Schema.ToMap
+	var m map[string]interface{}
+	if err := json.Unmarshal(rawProps, &m); err != nil {
+		return nil, err
+	}
+
+	var keyOrder codec.KeyOrder
+	if err := json.Unmarshal(rawKeyOrder, &keyOrder); err != nil {
+		return nil, err
+	}
+
+	props, err := schema.PropsFromMap(m)
+	if err != nil {
+		return nil, err
+	}
+
+	agg, err := models.BuildAggregateRoot(
+		id,
+		createdAt,
+		updatedAt,
+		nullableTimeFromPostgres(deletedAt),
+		version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.BuildSchema(agg, name, validationMode, keyOrder, props...)
+}
@@ -0,0 +1,167 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var _ schema.VersionRepository = (*PostgresSchemaVersionRepository)(nil)
+
+type PostgresSchemaVersionRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresSchemaVersionRepository(db *sql.DB) *PostgresSchemaVersionRepository {
+	return &PostgresSchemaVersionRepository{
+		db: db,
+	}
+}
+
+func (r *PostgresSchemaVersionRepository) FindBySchemaId(ctx context.Context, schemaId models.Id) ([]*schema.Version, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT schema_id, version, props, key_order, created_at
+		FROM schema_versions
+		WHERE schema_id = $1
+		ORDER BY version`,
+		schemaId.Value(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make([]*schema.Version, 0)
+	for rows.Next() {
+		v, err := scanPostgresSchemaVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		found = append(found, v)
+	}
+
+	return found, rows.Err()
+}
+
+func (r *PostgresSchemaVersionRepository) FindByVersion(
+	ctx context.Context,
+	schemaId models.Id,
+	version uint,
+) (*schema.Version, error) {
+	row := r.db.QueryRowContext(
+		ctx,
+		`SELECT schema_id, version, props, key_order, created_at
+		FROM schema_versions
+		WHERE schema_id = $1 AND version = $2`,
+		schemaId.Value(),
+		version,
+	)
+
+	v, err := scanPostgresSchemaVersion(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, schema.ErrVersionNotFound
+	}
+
+	return v, err
+}
+
+func (r *PostgresSchemaVersionRepository) FindLatest(ctx context.Context, schemaId models.Id) (*schema.Version, error) {
+	row := r.db.QueryRowContext(
+		ctx,
+		`SELECT schema_id, version, props, key_order, created_at
+		FROM schema_versions
+		WHERE schema_id = $1
+		ORDER BY version DESC
+		LIMIT 1`,
+		schemaId.Value(),
+	)
+
+	v, err := scanPostgresSchemaVersion(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, schema.ErrVersionNotFound
+	}
+
+	return v, err
+}
+
+func (r *PostgresSchemaVersionRepository) Save(ctx context.Context, version *schema.Version) error {
+	props, err := json.Marshal(version.ToMap())
+	if err != nil {
+		return err
+	}
+
+	keyOrder, err := json.Marshal(version.KeyOrder())
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(
+		ctx,
+		`INSERT INTO schema_versions (schema_id, version, props, key_order, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (schema_id, version) DO NOTHING`,
+		version.SchemaId().Value(),
+		version.Version(),
+		string(props),
+		string(keyOrder),
+		version.CreatedAt(),
+	)
+
+	return err
+}
+
+func (r *PostgresSchemaVersionRepository) DeleteBySchemaId(ctx context.Context, schemaId models.Id) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM schema_versions WHERE schema_id = $1`, schemaId.Value())
+	return err
+}
+
+func scanPostgresSchemaVersion(row rowScanner) (*schema.Version, error) {
+	var (
+		rawSchemaId string
+		version     uint
+		rawProps    []byte
+		rawKeyOrder []byte
+		createdAt   time.Time
+	)
+
+	if err := row.Scan(
+		&rawSchemaId,
+		&version,
+		&rawProps,
+		&rawKeyOrder,
+		&createdAt,
+	); err != nil {
+		return nil, err
+	}
+
+	schemaId, err := models.BuildId(rawSchemaId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Props are stored with the same format returned by Version.ToMap
+	var m map[string]interface{}
+	if err := json.Unmarshal(rawProps, &m); err != nil {
+		return nil, err
+	}
+
+	var keyOrder codec.KeyOrder
+	if err := json.Unmarshal(rawKeyOrder, &keyOrder); err != nil {
+		return nil, err
+	}
+
+	props, err := schema.PropsFromMap(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.BuildVersion(schemaId, version, createdAt, keyOrder, props...)
+}
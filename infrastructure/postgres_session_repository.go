@@ -0,0 +1,139 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var _ user.SessionRepository = (*PostgresSessionRepository)(nil)
+
+type PostgresSessionRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresSessionRepository(db *sql.DB) *PostgresSessionRepository {
+	return &PostgresSessionRepository{
+		db: db,
+	}
+}
+
+func (r *PostgresSessionRepository) FindById(ctx context.Context, id models.Id) (*user.Session, error) {
+	row := r.db.QueryRowContext(
+		ctx,
+		`SELECT id, username, hashed_refresh_token, created_at, expires_at, revoked_at
+		FROM sessions
+		WHERE id = $1`,
+		id.Value(),
+	)
+
+	s, err := scanPostgresSession(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, user.ErrSessionNotFound
+	}
+
+	return s, err
+}
+
+func (r *PostgresSessionRepository) Save(ctx context.Context, s *user.Session) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO sessions (id, username, hashed_refresh_token, created_at, expires_at, revoked_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO UPDATE SET
+			hashed_refresh_token = excluded.hashed_refresh_token,
+			expires_at = excluded.expires_at,
+			revoked_at = COALESCE(sessions.revoked_at, excluded.revoked_at)`,
+		s.Id().Value(),
+		s.Username().Value(),
+		s.HashedRefreshToken(),
+		s.CreatedAt(),
+		s.ExpiresAt(),
+		s.RevokedAt(),
+	)
+
+	return err
+}
+
+func (r *PostgresSessionRepository) SaveRefreshed(
+	ctx context.Context,
+	s *user.Session,
+	previousHashedRefreshToken string,
+) error {
+	res, err := r.db.ExecContext(
+		ctx,
+		`UPDATE sessions
+		SET hashed_refresh_token = $1, expires_at = $2
+		WHERE id = $3 AND hashed_refresh_token = $4 AND revoked_at IS NULL`,
+		s.HashedRefreshToken(),
+		s.ExpiresAt(),
+		s.Id().Value(),
+		previousHashedRefreshToken,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return user.ErrInvalidRefreshToken
+	}
+
+	return nil
+}
+
+func (r *PostgresSessionRepository) DeleteInactive(ctx context.Context, now time.Time) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`DELETE FROM sessions WHERE expires_at <= $1 OR revoked_at IS NOT NULL`,
+		now,
+	)
+
+	return err
+}
+
+func scanPostgresSession(row rowScanner) (*user.Session, error) {
+	var (
+		rawId, rawUsername, hashedRefreshToken string
+		createdAt, expiresAt                   time.Time
+		revokedAt                              sql.NullTime
+	)
+
+	if err := row.Scan(
+		&rawId,
+		&rawUsername,
+		&hashedRefreshToken,
+		&createdAt,
+		&expiresAt,
+		&revokedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	id, err := models.BuildId(rawId)
+	if err != nil {
+		return nil, err
+	}
+
+	username, err := user.NewUsername(rawUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	return user.BuildSession(
+		id,
+		username,
+		hashedRefreshToken,
+		createdAt,
+		expiresAt,
+		nullableTimeFromPostgres(revokedAt),
+	)
+}
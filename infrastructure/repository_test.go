@@ -0,0 +1,853 @@
+package infrastructure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/domain/props"
+	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/domain/security"
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/models"
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// Shared checks run against every persistent backend
+
+func testConfigRepository(t *testing.T, repo config.ConfigRepository) {
+	ctx := context.Background()
+
+	id, err := models.BuildId("my-config")
+	utils.Ok(err)
+
+	schemaId, err := models.BuildId("my-schema")
+	utils.Ok(err)
+
+	name, err := config.NewName("My Config")
+	utils.Ok(err)
+
+	createdAt := time.Date(2022, 1, 10, 12, 30, 0, 123456000, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+
+	agg, err := models.BuildAggregateRoot(id, createdAt, updatedAt, nil, 3)
+	utils.Ok(err)
+
+	c, err := config.BuildConfig(agg, schemaId, 2, name, config.ConfigData{
+		"port": float64(8080),
+		"obj": map[string]interface{}{
+			"values": []interface{}{"a", "b"},
+		},
+	}, codec.KeyOrder{"/port", "/obj", "/obj/values"})
+	utils.Ok(err)
+
+	if assert.NoError(t, repo.Save(ctx, c)) {
+		found, err := repo.FindById(ctx, id)
+		if assert.NoError(t, err) {
+			assert.Equal(t, c.Config(), found.Config())
+			assert.Equal(t, c.KeyOrder(), found.KeyOrder())
+			assert.Equal(t, c.Name(), found.Name())
+			assert.Equal(t, c.SchemaId(), found.SchemaId())
+			assert.Equal(t, uint(2), found.SchemaVersion())
+			assert.True(t, createdAt.Equal(found.Base().CreatedAt()))
+			assert.True(t, updatedAt.Equal(found.Base().UpdatedAt()))
+			assert.Nil(t, found.Base().DeletedAt())
+			assert.Equal(t, uint(3), found.Base().Version())
+		}
+
+		bySchema, err := repo.FindBySchemaId(ctx, schemaId)
+		assert.NoError(t, err)
+		assert.Len(t, bySchema, 1)
+	}
+
+	// Saving over a version other than the previous one is a conflict
+	assert.Equal(t, models.ErrVersionConflict, repo.Save(ctx, c))
+
+	agg, err = models.BuildAggregateRoot(id, createdAt, updatedAt, nil, 4)
+	utils.Ok(err)
+
+	next, err := config.BuildConfig(agg, schemaId, 0, name, config.ConfigData{"port": float64(9090)}, nil)
+	utils.Ok(err)
+
+	if assert.NoError(t, repo.Save(ctx, next)) {
+		found, err := repo.FindById(ctx, id)
+		if assert.NoError(t, err) {
+			assert.Equal(t, next.Config(), found.Config())
+			assert.Equal(t, uint(0), found.SchemaVersion())
+			assert.Equal(t, uint(4), found.Base().Version())
+		}
+	}
+
+	// Soft delete
+	utils.Ok(next.Delete())
+
+	if assert.NoError(t, repo.Save(ctx, next)) {
+		_, err := repo.FindById(ctx, id)
+		assert.Equal(t, config.ErrNotFound, err)
+
+		bySchema, err := repo.FindBySchemaId(ctx, schemaId)
+		assert.NoError(t, err)
+		assert.Empty(t, bySchema)
+
+		found, err := repo.FindDeletedById(ctx, id)
+		if assert.NoError(t, err) {
+			assert.True(t, next.Base().DeletedAt().Equal(*found.Base().DeletedAt()))
+		}
+
+		deleted, err := repo.FindDeleted(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, deleted, 1)
+	}
+
+	assert.NoError(t, repo.Delete(ctx, id))
+
+	_, err = repo.FindDeletedById(ctx, id)
+	assert.Equal(t, config.ErrNotFound, err)
+
+	_, err = repo.FindById(ctx, id)
+	assert.Equal(t, config.ErrNotFound, err)
+}
+
+func testSchemaRepository(t *testing.T, repo schema.SchemaRepository) {
+	ctx := context.Background()
+
+	port, err := props.NewInteger("port", props.WithRequired(), props.WithDefault(8080), props.WithInterval(80, 9000))
+	utils.Ok(err)
+
+	envs, err := props.NewString("envs", props.WithArray(), props.WithEnum("dev", "prod"))
+	utils.Ok(err)
+
+	obj, err := props.NewObject("obj", props.WithProps(port, envs))
+	utils.Ok(err)
+
+	id, err := models.BuildId("my-schema")
+	utils.Ok(err)
+
+	name, err := schema.NewName("My Schema")
+	utils.Ok(err)
+
+	s, err := schema.NewSchema(id, name, schema.WARN_VALIDATION, codec.KeyOrder{"/obj", "/obj/port", "/obj/envs"}, obj)
+	utils.Ok(err)
+
+	if assert.NoError(t, repo.Save(ctx, s)) {
+		found, err := repo.FindById(ctx, id)
+		if assert.NoError(t, err) {
+			assert.Equal(t, s.ToMap(), found.ToMap())
+			assert.Equal(t, s.KeyOrder(), found.KeyOrder())
+			assert.Equal(t, s.Name(), found.Name())
+			assert.WithinDuration(t, s.Base().CreatedAt(), found.Base().CreatedAt(), time.Millisecond)
+			assert.Equal(t, s.Base().Version(), found.Base().Version())
+		}
+	}
+
+	assert.Equal(t, models.ErrVersionConflict, repo.Save(ctx, s))
+
+	found, err := repo.FindById(ctx, id)
+	utils.Ok(err)
+	utils.Ok(found.ChangeValidationMode(schema.STRICT_VALIDATION))
+	assert.NoError(t, repo.Save(ctx, found))
+
+	// Soft delete
+	_, err = repo.FindDeletedById(ctx, id)
+	assert.Equal(t, schema.ErrNotFound, err)
+
+	found, err = repo.FindById(ctx, id)
+	utils.Ok(err)
+	utils.Ok(found.Delete())
+
+	if assert.NoError(t, repo.Save(ctx, found)) {
+		_, err := repo.FindById(ctx, id)
+		assert.Equal(t, schema.ErrNotFound, err)
+
+		deleted, err := repo.FindDeleted(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, deleted, 1)
+
+		restored, err := repo.FindDeletedById(ctx, id)
+		utils.Ok(err)
+		utils.Ok(restored.Restore())
+		assert.NoError(t, repo.Save(ctx, restored))
+
+		_, err = repo.FindById(ctx, id)
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, repo.Delete(ctx, id))
+
+	_, err = repo.FindById(ctx, id)
+	assert.Equal(t, schema.ErrNotFound, err)
+}
+
+func testUserRepository(t *testing.T, repo user.UserRepository) {
+	ctx := context.Background()
+
+	username, err := user.NewUsername("admin")
+	utils.Ok(err)
+
+	password, err := user.NewPassword("12345678")
+	utils.Ok(err)
+
+	u, err := user.NewUser(username, password, user.FULL_ACCESS)
+	utils.Ok(err)
+
+	if assert.NoError(t, repo.Save(ctx, u)) {
+		found, err := repo.FindByUsername(ctx, username)
+		if assert.NoError(t, err) {
+			assert.Equal(t, u.HashedPassword(), found.HashedPassword())
+			assert.Equal(t, u.Access(), found.Access())
+			assert.True(t, found.HashedPassword().Validate(password))
+		}
+	}
+
+	assert.NoError(t, repo.Delete(ctx, username))
+
+	_, err = repo.FindByUsername(ctx, username)
+	assert.Equal(t, user.ErrNotFound, err)
+}
+
+func testAuthorizationRepository(t *testing.T, repo security.AuthorizationRepository) {
+	ctx := context.Background()
+
+	resourceId, err := models.BuildId("my-config")
+	utils.Ok(err)
+
+	createdAt := time.Date(2022, 1, 10, 12, 30, 0, 123456000, time.UTC)
+
+	buildAuthorization := func(name string, createdAt time.Time) *security.Authorization {
+		apiKey, err := security.GenerateApiKey()
+		utils.Ok(err)
+
+		hash, err := apiKey.Hash()
+		utils.Ok(err)
+
+		id, err := models.NewUuid()
+		utils.Ok(err)
+
+		auth, err := security.BuildAuthorization(
+			id,
+			name,
+			apiKey.Prefix(),
+			hash,
+			resourceId,
+			security.READ_ONLY_ACCESS,
+			createdAt,
+			nil,
+			nil,
+			nil,
+		)
+		utils.Ok(err)
+
+		return auth
+	}
+
+	auth := buildAuthorization("default", createdAt.Add(time.Minute))
+
+	if assert.NoError(t, repo.Save(ctx, auth)) {
+		found, err := repo.FindByApiKey(ctx, auth.HashedApiKey())
+		if assert.NoError(t, err) {
+			assert.Equal(t, auth.Id(), found.Id())
+			assert.Equal(t, auth.Name(), found.Name())
+			assert.Equal(t, auth.Prefix(), found.Prefix())
+			assert.Equal(t, auth.ResourceId(), found.ResourceId())
+			assert.Equal(t, auth.Access(), found.Access())
+			assert.True(t, auth.CreatedAt().Equal(found.CreatedAt()))
+			assert.Nil(t, found.LastUsedAt())
+			assert.Nil(t, found.ExpiresAt())
+			assert.Nil(t, found.RevokedAt())
+		}
+
+		found, err = repo.FindById(ctx, auth.Id())
+		if assert.NoError(t, err) {
+			assert.Equal(t, auth.HashedApiKey(), found.HashedApiKey())
+		}
+	}
+
+	// Lifecycle timestamps
+	usedAt := createdAt.Add(time.Hour)
+	auth.Use(usedAt)
+	auth.ExpireAt(usedAt.Add(24 * time.Hour))
+	utils.Ok(auth.Revoke(usedAt.Add(time.Second)))
+
+	if assert.NoError(t, repo.Save(ctx, auth)) {
+		found, err := repo.FindById(ctx, auth.Id())
+		if assert.NoError(t, err) && assert.NotNil(t, found.RevokedAt()) {
+			assert.True(t, auth.LastUsedAt().Equal(*found.LastUsedAt()))
+			assert.True(t, auth.ExpiresAt().Equal(*found.ExpiresAt()))
+			assert.True(t, auth.RevokedAt().Equal(*found.RevokedAt()))
+			assert.Equal(t, security.ErrRevoked, found.Check(usedAt))
+		}
+	}
+
+	// Stale copies cannot enable revoked keys again
+	concurrent := buildAuthorization("concurrent", createdAt)
+	utils.Ok(repo.Save(ctx, concurrent))
+
+	assert.NoError(t, repo.UpdateLastUsedAt(ctx, concurrent.Id(), usedAt))
+
+	stale, err := repo.FindById(ctx, concurrent.Id())
+	utils.Ok(err)
+	assert.True(t, usedAt.Equal(*stale.LastUsedAt()))
+
+	revoked, err := repo.FindById(ctx, concurrent.Id())
+	utils.Ok(err)
+	revoked.ExpireAt(usedAt.Add(time.Hour))
+	utils.Ok(revoked.Revoke(usedAt))
+	utils.Ok(repo.Save(ctx, revoked))
+
+	// Uses of revoked keys are not recorded
+	if assert.NoError(t, repo.UpdateLastUsedAt(ctx, concurrent.Id(), usedAt.Add(time.Minute))) {
+		found, err := repo.FindById(ctx, concurrent.Id())
+		if assert.NoError(t, err) {
+			assert.True(t, usedAt.Equal(*found.LastUsedAt()))
+		}
+	}
+
+	stale.Use(usedAt.Add(2 * time.Hour))
+	if assert.NoError(t, repo.Save(ctx, stale)) {
+		found, err := repo.FindById(ctx, concurrent.Id())
+		if assert.NoError(t, err) && assert.NotNil(t, found.RevokedAt()) && assert.NotNil(t, found.ExpiresAt()) {
+			assert.True(t, revoked.RevokedAt().Equal(*found.RevokedAt()))
+			assert.True(t, revoked.ExpiresAt().Equal(*found.ExpiresAt()))
+			assert.True(t, stale.LastUsedAt().Equal(*found.LastUsedAt()))
+		}
+	}
+
+	utils.Ok(repo.Delete(ctx, concurrent.HashedApiKey()))
+
+	// Listed by creation
+	older := buildAuthorization("older", createdAt)
+	utils.Ok(repo.Save(ctx, older))
+
+	found, err := repo.FindByResourceId(ctx, resourceId)
+	if assert.NoError(t, err) && assert.Len(t, found, 2) {
+		assert.Equal(t, older.Id(), found[0].Id())
+		assert.Equal(t, auth.Id(), found[1].Id())
+	}
+
+	otherId, err := models.BuildId("other-config")
+	utils.Ok(err)
+
+	found, err = repo.FindByResourceId(ctx, otherId)
+	if assert.NoError(t, err) {
+		assert.Empty(t, found)
+	}
+
+	assert.NoError(t, repo.Delete(ctx, auth.HashedApiKey()))
+
+	_, err = repo.FindByApiKey(ctx, auth.HashedApiKey())
+	assert.Equal(t, security.ErrNotFound, err)
+
+	_, err = repo.FindById(ctx, auth.Id())
+	assert.Equal(t, security.ErrNotFound, err)
+
+	utils.Ok(repo.Save(ctx, auth))
+	assert.NoError(t, repo.DeleteByResourceId(ctx, resourceId))
+
+	_, err = repo.FindByApiKey(ctx, auth.HashedApiKey())
+	assert.Equal(t, security.ErrNotFound, err)
+
+	found, err = repo.FindByResourceId(ctx, resourceId)
+	if assert.NoError(t, err) {
+		assert.Empty(t, found)
+	}
+}
+
+func testSessionRepository(t *testing.T, repo user.SessionRepository) {
+	ctx := context.Background()
+
+	username, err := user.NewUsername("admin")
+	utils.Ok(err)
+
+	now := time.Date(2022, 1, 10, 12, 30, 0, 123456000, time.UTC)
+
+	s, refreshToken, err := user.NewSession(username, time.Hour, now)
+	utils.Ok(err)
+
+	if assert.NoError(t, repo.Save(ctx, s)) {
+		found, err := repo.FindById(ctx, s.Id())
+		if assert.NoError(t, err) {
+			assert.Equal(t, s.Username(), found.Username())
+			assert.Equal(t, s.HashedRefreshToken(), found.HashedRefreshToken())
+			assert.True(t, s.CreatedAt().Equal(found.CreatedAt()))
+			assert.True(t, s.ExpiresAt().Equal(found.ExpiresAt()))
+			assert.Nil(t, found.RevokedAt())
+		}
+	}
+
+	// Refreshed once per refresh token
+	later := now.Add(time.Minute)
+
+	concurrent, err := repo.FindById(ctx, s.Id())
+	utils.Ok(err)
+
+	previous := s.HashedRefreshToken()
+	_, err = s.Refresh(refreshToken, time.Hour, later)
+	utils.Ok(err)
+
+	if assert.NoError(t, repo.SaveRefreshed(ctx, s, previous)) {
+		found, err := repo.FindById(ctx, s.Id())
+		if assert.NoError(t, err) {
+			assert.Equal(t, s.HashedRefreshToken(), found.HashedRefreshToken())
+			assert.True(t, s.ExpiresAt().Equal(found.ExpiresAt()))
+		}
+	}
+
+	_, err = concurrent.Refresh(refreshToken, time.Hour, later)
+	utils.Ok(err)
+	assert.Equal(t, user.ErrInvalidRefreshToken, repo.SaveRefreshed(ctx, concurrent, previous))
+
+	// Revoked sessions are neither refreshed nor restored by stale copies
+	stale, err := repo.FindById(ctx, s.Id())
+	utils.Ok(err)
+
+	utils.Ok(s.Revoke(later))
+
+	if assert.NoError(t, repo.Save(ctx, s)) {
+		found, err := repo.FindById(ctx, s.Id())
+		if assert.NoError(t, err) && assert.NotNil(t, found.RevokedAt()) {
+			assert.Equal(t, s.HashedRefreshToken(), found.HashedRefreshToken())
+			assert.True(t, s.ExpiresAt().Equal(found.ExpiresAt()))
+			assert.True(t, s.RevokedAt().Equal(*found.RevokedAt()))
+		}
+	}
+
+	assert.Equal(t, user.ErrInvalidRefreshToken, repo.SaveRefreshed(ctx, stale, stale.HashedRefreshToken()))
+
+	if assert.NoError(t, repo.Save(ctx, stale)) {
+		found, err := repo.FindById(ctx, s.Id())
+		if assert.NoError(t, err) && assert.NotNil(t, found.RevokedAt()) {
+			assert.True(t, s.RevokedAt().Equal(*found.RevokedAt()))
+		}
+	}
+
+	active, _, err := user.NewSession(username, time.Hour, now)
+	utils.Ok(err)
+	utils.Ok(repo.Save(ctx, active))
+
+	expired, _, err := user.NewSession(username, time.Minute, now)
+	utils.Ok(err)
+	utils.Ok(repo.Save(ctx, expired))
+
+	assert.NoError(t, repo.DeleteInactive(ctx, now.Add(30*time.Minute)))
+
+	_, err = repo.FindById(ctx, s.Id())
+	assert.Equal(t, user.ErrSessionNotFound, err)
+
+	_, err = repo.FindById(ctx, expired.Id())
+	assert.Equal(t, user.ErrSessionNotFound, err)
+
+	_, err = repo.FindById(ctx, active.Id())
+	assert.NoError(t, err)
+}
+
+func testRoleRepository(t *testing.T, repo user.RoleRepository) {
+	ctx := context.Background()
+
+	buildRole := func(rawId string, permissions ...user.Permission) *user.Role {
+		id, err := models.BuildId(rawId)
+		utils.Ok(err)
+
+		r, err := user.NewRole(id, rawId, permissions)
+		utils.Ok(err)
+
+		return r
+	}
+
+	reader := buildRole("reader", user.CONFIG_READ_PERMISSION)
+	manager := buildRole("key-manager", user.CONFIG_READ_PERMISSION, user.KEY_MANAGE_PERMISSION)
+
+	if assert.NoError(t, repo.Save(ctx, reader)) && assert.NoError(t, repo.Save(ctx, manager)) {
+		found, err := repo.FindById(ctx, manager.Id())
+		if assert.NoError(t, err) {
+			assert.Equal(t, manager.Name(), found.Name())
+			assert.Equal(t, manager.Permissions(), found.Permissions())
+			assert.False(t, found.IsBuiltin())
+		}
+
+		all, err := repo.FindAll(ctx)
+		if assert.NoError(t, err) && assert.Len(t, all, 2) {
+			assert.Equal(t, manager.Id(), all[0].Id())
+			assert.Equal(t, reader.Id(), all[1].Id())
+		}
+	}
+
+	utils.Ok(reader.Update("Reader", []user.Permission{user.SCHEMA_READ_PERMISSION, user.CONFIG_READ_PERMISSION}))
+
+	if assert.NoError(t, repo.Save(ctx, reader)) {
+		found, err := repo.FindById(ctx, reader.Id())
+		if assert.NoError(t, err) {
+			assert.Equal(t, "Reader", found.Name())
+			assert.Equal(t, reader.Permissions(), found.Permissions())
+		}
+	}
+
+	assert.NoError(t, repo.Delete(ctx, reader.Id()))
+
+	_, err := repo.FindById(ctx, reader.Id())
+	assert.Equal(t, user.ErrRoleNotFound, err)
+
+	utils.Ok(repo.Delete(ctx, manager.Id()))
+}
+
+func testRoleAssignmentRepository(t *testing.T, repo user.RoleAssignmentRepository) {
+	ctx := context.Background()
+
+	username, err := user.NewUsername("team-a-lead")
+	utils.Ok(err)
+
+	otherUsername, err := user.NewUsername("team-b-lead")
+	utils.Ok(err)
+
+	createdAt := time.Date(2022, 1, 10, 12, 30, 0, 123456000, time.UTC)
+
+	assign := func(username user.Username, rawRoleId, rawScope string, createdAt time.Time) *user.RoleAssignment {
+		id, err := models.NewUuid()
+		utils.Ok(err)
+
+		roleId, err := models.BuildId(rawRoleId)
+		utils.Ok(err)
+
+		scope, err := user.NewScope(rawScope)
+		utils.Ok(err)
+
+		a, err := user.BuildRoleAssignment(id, username, roleId, scope, createdAt)
+		utils.Ok(err)
+
+		utils.Ok(repo.Save(ctx, a))
+
+		return a
+	}
+
+	editor := assign(username, user.EDITOR_ROLE, "schema:team-a-*", createdAt.Add(time.Minute))
+	viewer := assign(username, user.VIEWER_ROLE, "*", createdAt)
+	other := assign(otherUsername, user.EDITOR_ROLE, "config:billing", createdAt)
+
+	found, err := repo.FindById(ctx, editor.Id())
+	if assert.NoError(t, err) {
+		assert.Equal(t, editor.Username(), found.Username())
+		assert.Equal(t, editor.RoleId(), found.RoleId())
+		assert.Equal(t, editor.Scope(), found.Scope())
+		assert.True(t, editor.CreatedAt().Equal(found.CreatedAt()))
+	}
+
+	assignments, err := repo.FindByUsername(ctx, username)
+	if assert.NoError(t, err) && assert.Len(t, assignments, 2) {
+		assert.Equal(t, viewer.Id(), assignments[0].Id())
+		assert.Equal(t, editor.Id(), assignments[1].Id())
+	}
+
+	assignments, err = repo.FindByRoleId(ctx, editor.RoleId())
+	if assert.NoError(t, err) && assert.Len(t, assignments, 2) {
+		assert.Equal(t, other.Id(), assignments[0].Id())
+		assert.Equal(t, editor.Id(), assignments[1].Id())
+	}
+
+	for _, a := range []*user.RoleAssignment{editor, viewer, other} {
+		assert.NoError(t, repo.Delete(ctx, a.Id()))
+	}
+
+	_, err = repo.FindById(ctx, editor.Id())
+	assert.Equal(t, user.ErrRoleAssignmentNotFound, err)
+
+	assignments, err = repo.FindByUsername(ctx, username)
+	if assert.NoError(t, err) {
+		assert.Empty(t, assignments)
+	}
+}
+
+func testRevisionRepository(t *testing.T, repo config.RevisionRepository) {
+	ctx := context.Background()
+
+	configId, err := models.BuildId("my-config")
+	utils.Ok(err)
+
+	createdAt := time.Date(2022, 1, 10, 12, 30, 0, 123456000, time.UTC)
+
+	for v := uint(1); v <= 3; v++ {
+		rev, err := config.BuildRevision(
+			configId,
+			v,
+			config.ConfigData{"version": float64(v), "author": "admin"},
+			codec.KeyOrder{"/version", "/author"},
+			"admin",
+			createdAt.Add(time.Duration(v)*time.Minute),
+		)
+		utils.Ok(err)
+
+		assert.NoError(t, repo.Save(ctx, rev))
+	}
+
+	// Revisions are immutable
+	overwrite, err := config.BuildRevision(configId, 2, config.ConfigData{"version": "other"}, nil, "", createdAt)
+	utils.Ok(err)
+	assert.NoError(t, repo.Save(ctx, overwrite))
+
+	found, err := repo.FindByVersion(ctx, configId, 2)
+	if assert.NoError(t, err) {
+		assert.Equal(t, config.ConfigData{"version": float64(2), "author": "admin"}, found.Config())
+		assert.Equal(t, codec.KeyOrder{"/version", "/author"}, found.KeyOrder())
+		assert.Equal(t, "admin", found.Author())
+		assert.True(t, createdAt.Add(2*time.Minute).Equal(found.CreatedAt()))
+	}
+
+	all, err := repo.FindByConfigId(ctx, configId)
+	if assert.NoError(t, err) && assert.Len(t, all, 3) {
+		for i, rev := range all {
+			assert.Equal(t, uint(i+1), rev.Version())
+		}
+	}
+
+	_, err = repo.FindByVersion(ctx, configId, 4)
+	assert.Equal(t, config.ErrRevisionNotFound, err)
+
+	assert.NoError(t, repo.DeleteByConfigId(ctx, configId))
+
+	all, err = repo.FindByConfigId(ctx, configId)
+	assert.NoError(t, err)
+	assert.Empty(t, all)
+}
+
+func testSchemaVersionRepository(t *testing.T, repo schema.VersionRepository) {
+	ctx := context.Background()
+
+	schemaId, err := models.BuildId("my-schema")
+	utils.Ok(err)
+
+	createdAt := time.Date(2022, 1, 10, 12, 30, 0, 123456000, time.UTC)
+
+	for v := uint(1); v <= 3; v++ {
+		port, err := props.NewInteger("port", props.WithDefault(int(8080+v)))
+		utils.Ok(err)
+
+		version, err := schema.BuildVersion(schemaId, v, createdAt.Add(time.Duration(v)*time.Minute), codec.KeyOrder{"/port"}, port)
+		utils.Ok(err)
+
+		assert.NoError(t, repo.Save(ctx, version))
+	}
+
+	// Versions are immutable
+	host, err := props.NewString("host")
+	utils.Ok(err)
+
+	overwrite, err := schema.BuildVersion(schemaId, 2, createdAt, nil, host)
+	utils.Ok(err)
+	assert.NoError(t, repo.Save(ctx, overwrite))
+
+	found, err := repo.FindByVersion(ctx, schemaId, 2)
+	if assert.NoError(t, err) {
+		assert.Contains(t, found.Props(), "port")
+		assert.NotContains(t, found.Props(), "host")
+		assert.Equal(t, 8082, found.Props()["port"].Default())
+		assert.Equal(t, codec.KeyOrder{"/port"}, found.KeyOrder())
+		assert.True(t, createdAt.Add(2*time.Minute).Equal(found.CreatedAt()))
+	}
+
+	latest, err := repo.FindLatest(ctx, schemaId)
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint(3), latest.Version())
+	}
+
+	all, err := repo.FindBySchemaId(ctx, schemaId)
+	if assert.NoError(t, err) && assert.Len(t, all, 3) {
+		for i, v := range all {
+			assert.Equal(t, uint(i+1), v.Version())
+		}
+	}
+
+	_, err = repo.FindByVersion(ctx, schemaId, 4)
+	assert.Equal(t, schema.ErrVersionNotFound, err)
+
+	assert.NoError(t, repo.DeleteBySchemaId(ctx, schemaId))
+
+	_, err = repo.FindLatest(ctx, schemaId)
+	assert.Equal(t, schema.ErrVersionNotFound, err)
+}
+
+func testConfigFind(t *testing.T, repo config.ConfigRepository) {
+	ctx := context.Background()
+
+	schemaA, err := models.BuildId("schema-a")
+	utils.Ok(err)
+
+	schemaB, err := models.BuildId("schema-b")
+	utils.Ok(err)
+
+	createdAt := time.Date(2022, 1, 10, 12, 30, 0, 0, time.UTC)
+
+	// Names sort differently than creation dates
+	rows := []struct {
+		id       string
+		name     string
+		schemaId models.Id
+		deleted  bool
+	}{
+		{"find-1", "Delta", schemaA, false},
+		{"find-2", "alpha_1", schemaA, false},
+		{"find-3", "Charlie", schemaB, false},
+		{"find-4", "Bravo", schemaA, false},
+		{"find-5", "alpha%", schemaB, false},
+		{"find-6", "Echo", schemaA, true},
+	}
+
+	ids := make([]models.Id, 0, len(rows))
+	for i, row := range rows {
+		id, err := models.BuildId(row.id)
+		utils.Ok(err)
+
+		name, err := config.NewName(row.name)
+		utils.Ok(err)
+
+		var deletedAt *time.Time
+		if row.deleted {
+			deletedAt = &createdAt
+		}
+
+		at := createdAt.Add(time.Duration(i) * time.Minute)
+		agg, err := models.BuildAggregateRoot(id, at, at, deletedAt, 1)
+		utils.Ok(err)
+
+		c, err := config.BuildConfig(agg, row.schemaId, 0, name, config.ConfigData{"i": float64(i)}, nil)
+		utils.Ok(err)
+		utils.Ok(repo.Save(ctx, c))
+
+		ids = append(ids, id)
+	}
+
+	defer func() {
+		for _, id := range ids {
+			utils.Ok(repo.Delete(ctx, id))
+		}
+	}()
+
+	findAll := func(criteria config.Criteria) []string {
+		found := make([]string, 0)
+		for {
+			page, err := repo.Find(ctx, criteria)
+			utils.Ok(err)
+
+			for _, c := range page {
+				found = append(found, c.Base().Id().Value())
+			}
+
+			if len(page) < criteria.Pagination.Limit {
+				return found
+			}
+
+			last := page[len(page)-1]
+			criteria.Pagination.Cursor = models.NewCursor(criteria.Pagination.Sort, last.Base(), last.Name().Value())
+		}
+	}
+
+	type test struct {
+		name     string
+		criteria config.Criteria
+		expected []string
+	}
+
+	tests := []test{
+		{
+			name: "by name",
+			criteria: config.Criteria{
+				Pagination: models.Pagination{Sort: models.Sort{Field: models.SORT_BY_NAME}, Limit: 2},
+			},
+			expected: []string{"find-4", "find-3", "find-1", "find-5", "find-2"},
+		},
+		{
+			name: "by creation date descending",
+			criteria: config.Criteria{
+				Pagination: models.Pagination{Sort: models.Sort{Field: models.SORT_BY_CREATED_AT, Desc: true}, Limit: 3},
+			},
+			expected: []string{"find-5", "find-4", "find-3", "find-2", "find-1"},
+		},
+		{
+			name: "by schema",
+			criteria: config.Criteria{
+				SchemaId:   &schemaB,
+				Pagination: models.Pagination{Sort: models.Sort{Field: models.SORT_BY_UPDATED_AT}, Limit: 1},
+			},
+			expected: []string{"find-3", "find-5"},
+		},
+		{
+			name: "by name substring",
+			criteria: config.Criteria{
+				Name:       "ALPHA",
+				Pagination: models.Pagination{Sort: models.Sort{Field: models.SORT_BY_NAME}, Limit: 10},
+			},
+			expected: []string{"find-5", "find-2"},
+		},
+		{
+			name: "by name with wildcards",
+			criteria: config.Criteria{
+				Name:       "a%",
+				Pagination: models.Pagination{Sort: models.Sort{Field: models.SORT_BY_NAME}, Limit: 10},
+			},
+			expected: []string{"find-5"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, findAll(test.criteria))
+		})
+	}
+}
+
+func testSchemaFind(t *testing.T, repo schema.SchemaRepository) {
+	ctx := context.Background()
+
+	prop, err := props.NewString("env")
+	utils.Ok(err)
+
+	ids := make([]models.Id, 0)
+	for _, rawName := range []string{"Zulu", "Yankee", "X-ray"} {
+		id, err := models.NewSlug(rawName)
+		utils.Ok(err)
+
+		name, err := schema.NewName(rawName)
+		utils.Ok(err)
+
+		s, err := schema.NewSchema(id, name, schema.WARN_VALIDATION, nil, prop)
+		utils.Ok(err)
+		utils.Ok(repo.Save(ctx, s))
+
+		ids = append(ids, id)
+	}
+
+	defer func() {
+		for _, id := range ids {
+			utils.Ok(repo.Delete(ctx, id))
+		}
+	}()
+
+	sort := models.Sort{Field: models.SORT_BY_NAME}
+
+	first, err := repo.Find(ctx, schema.Criteria{
+		Pagination: models.Pagination{Sort: sort, Limit: 2},
+	})
+	if assert.NoError(t, err) && assert.Len(t, first, 2) {
+		assert.Equal(t, "X-ray", first[0].Name().Value())
+		assert.Equal(t, "Yankee", first[1].Name().Value())
+
+		second, err := repo.Find(ctx, schema.Criteria{
+			Pagination: models.Pagination{
+				Sort:   sort,
+				Cursor: models.NewCursor(sort, first[1].Base(), first[1].Name().Value()),
+				Limit:  2,
+			},
+		})
+		if assert.NoError(t, err) && assert.Len(t, second, 1) {
+			assert.Equal(t, "Zulu", second[0].Name().Value())
+		}
+	}
+
+	found, err := repo.Find(ctx, schema.Criteria{
+		Name:       "ank",
+		Pagination: models.Pagination{Sort: sort, Limit: 10},
+	})
+	if assert.NoError(t, err) && assert.Len(t, found, 1) {
+		assert.Equal(t, "Yankee", found[0].Name().Value())
+	}
+}
@@ -0,0 +1,71 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+// Roles and role assignments are read the same way by SQL backends.
+// Permissions are stored as a JSON array.
+func scanRole(row rowScanner) (*user.Role, error) {
+	var rawId, name, rawPermissions string
+
+	if err := row.Scan(&rawId, &name, &rawPermissions); err != nil {
+		return nil, err
+	}
+
+	id, err := models.BuildId(rawId)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	if err := json.Unmarshal([]byte(rawPermissions), &values); err != nil {
+		return nil, err
+	}
+
+	permissions := make([]user.Permission, 0, len(values))
+	for _, v := range values {
+		p, err := user.NewPermission(v)
+		if err != nil {
+			return nil, err
+		}
+
+		permissions = append(permissions, p)
+	}
+
+	return user.BuildRole(id, name, permissions)
+}
+
+func buildRoleAssignment(
+	rawId string,
+	rawUsername string,
+	rawRoleId string,
+	rawScope string,
+	createdAt time.Time,
+) (*user.RoleAssignment, error) {
+	id, err := models.BuildId(rawId)
+	if err != nil {
+		return nil, err
+	}
+
+	username, err := user.NewUsername(rawUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	roleId, err := models.BuildId(rawRoleId)
+	if err != nil {
+		return nil, err
+	}
+
+	scope, err := user.NewScope(rawScope)
+	if err != nil {
+		return nil, err
+	}
+
+	return user.BuildRoleAssignment(id, username, roleId, scope, createdAt)
+}
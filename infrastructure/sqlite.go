@@ -0,0 +1,209 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+var sqliteDialect = sqlDialect{
+	placeholder: "?",
+	timeValue: func(t time.Time) interface{} {
+		return timeToSqlite(t)
+	},
+}
+
+var sqliteMigrations = []migration{
+	{
+		version: 1,
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS schemas (
+				id TEXT PRIMARY KEY,
+				name TEXT NOT NULL,
+				props TEXT NOT NULL,
+				created_at INTEGER NOT NULL,
+				updated_at INTEGER NOT NULL,
+				deleted_at INTEGER,
+				version INTEGER NOT NULL
+			)`,
+			`CREATE TABLE IF NOT EXISTS configs (
+				id TEXT PRIMARY KEY,
+				schema_id TEXT NOT NULL,
+				name TEXT NOT NULL,
+				config TEXT NOT NULL,
+				created_at INTEGER NOT NULL,
+				updated_at INTEGER NOT NULL,
+				deleted_at INTEGER,
+				version INTEGER NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS configs_schema_id_idx ON configs (schema_id)`,
+			`CREATE TABLE IF NOT EXISTS users (
+				username TEXT PRIMARY KEY,
+				hashed_password TEXT NOT NULL,
+				access TEXT NOT NULL,
+				created_at INTEGER NOT NULL,
+				updated_at INTEGER NOT NULL,
+				deleted_at INTEGER,
+				version INTEGER NOT NULL
+			)`,
+			`CREATE TABLE IF NOT EXISTS authorizations (
+				hashed_api_key TEXT PRIMARY KEY,
+				resource_id TEXT NOT NULL,
+				access TEXT NOT NULL
+			)`,
+		},
+	},
+	{
+		version: 2,
+		statements: []string{
+			`ALTER TABLE schemas ADD COLUMN validation_mode TEXT NOT NULL DEFAULT 'warn'`,
+		},
+	},
+	{
+		version: 3,
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS config_revisions (
+				config_id TEXT NOT NULL,
+				version INTEGER NOT NULL,
+				config TEXT NOT NULL,
+				author TEXT NOT NULL,
+				created_at INTEGER NOT NULL,
+				PRIMARY KEY (config_id, version)
+			)`,
+			// Existing configs start their history at their current version
+			`INSERT INTO config_revisions (config_id, version, config, author, created_at)
+			SELECT id, version, config, '', updated_at FROM configs`,
+		},
+	},
+	{
+		version: 4,
+		statements: []string{
+			// Zero tracks the latest schema version
+			`ALTER TABLE configs ADD COLUMN schema_version INTEGER NOT NULL DEFAULT 0`,
+			`CREATE TABLE IF NOT EXISTS schema_versions (
+				schema_id TEXT NOT NULL,
+				version INTEGER NOT NULL,
+				props TEXT NOT NULL,
+				created_at INTEGER NOT NULL,
+				PRIMARY KEY (schema_id, version)
+			)`,
+			// Existing schemas start their history at their current version
+			`INSERT INTO schema_versions (schema_id, version, props, created_at)
+			SELECT id, version, props, updated_at FROM schemas`,
+		},
+	},
+	{
+		version: 5,
+		statements: []string{
+			`ALTER TABLE authorizations ADD COLUMN id TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE authorizations ADD COLUMN name TEXT NOT NULL DEFAULT 'default'`,
+			`ALTER TABLE authorizations ADD COLUMN prefix TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE authorizations ADD COLUMN created_at INTEGER NOT NULL DEFAULT 0`,
+			`ALTER TABLE authorizations ADD COLUMN last_used_at INTEGER`,
+			`ALTER TABLE authorizations ADD COLUMN expires_at INTEGER`,
+			`ALTER TABLE authorizations ADD COLUMN revoked_at INTEGER`,
+			// Existing keys were issued when creating configs, their prefix
+			// is unknown and so is their creation
+			`UPDATE authorizations SET id = substr(hashed_api_key, 1, 16)`,
+			`UPDATE authorizations SET created_at = CAST(strftime('%s', 'now') AS INTEGER) * 1000000000`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS authorizations_id_idx ON authorizations (id)`,
+			`CREATE INDEX IF NOT EXISTS authorizations_resource_id_idx ON authorizations (resource_id)`,
+		},
+	},
+	{
+		version: 6,
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS sessions (
+				id TEXT PRIMARY KEY,
+				username TEXT NOT NULL,
+				hashed_refresh_token TEXT NOT NULL,
+				created_at INTEGER NOT NULL,
+				expires_at INTEGER NOT NULL,
+				revoked_at INTEGER
+			)`,
+			`CREATE INDEX IF NOT EXISTS sessions_expires_at_idx ON sessions (expires_at)`,
+		},
+	},
+	{
+		version: 7,
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS roles (
+				id TEXT PRIMARY KEY,
+				name TEXT NOT NULL,
+				permissions TEXT NOT NULL
+			)`,
+			`CREATE TABLE IF NOT EXISTS role_assignments (
+				id TEXT PRIMARY KEY,
+				username TEXT NOT NULL,
+				role_id TEXT NOT NULL,
+				scope TEXT NOT NULL,
+				created_at INTEGER NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS role_assignments_username_idx ON role_assignments (username)`,
+			`CREATE INDEX IF NOT EXISTS role_assignments_role_id_idx ON role_assignments (role_id)`,
+			// Existing users keep their access as a global builtin role
+			`INSERT INTO role_assignments (id, username, role_id, scope, created_at)
+			SELECT 'access-' || username, username, CASE access WHEN 'full_access' THEN 'admin' ELSE 'viewer' END, '*', created_at
+			FROM users`,
+		},
+	},
+	{
+		version: 8,
+		statements: []string{
+			// Existing documents render their keys sorted, as they did
+			`ALTER TABLE configs ADD COLUMN key_order TEXT NOT NULL DEFAULT '[]'`,
+			`ALTER TABLE config_revisions ADD COLUMN key_order TEXT NOT NULL DEFAULT '[]'`,
+			`ALTER TABLE schemas ADD COLUMN key_order TEXT NOT NULL DEFAULT '[]'`,
+			`ALTER TABLE schema_versions ADD COLUMN key_order TEXT NOT NULL DEFAULT '[]'`,
+		},
+	},
+}
+
+// OpenSqlite opens (or creates) the SQLite database at path and applies
+// pending migrations.
+func OpenSqlite(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite serializes writes anyway and in-memory databases only live as
+	// long as their connection.
+	db.SetMaxOpenConns(1)
+
+	if err := migrate(context.Background(), db, sqliteDialect, sqliteMigrations); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// Timestamps are stored as unix nanoseconds to keep full precision and
+// ordering.
+func timeToSqlite(t time.Time) int64 {
+	return t.UnixNano()
+}
+
+func nullableTimeToSqlite(t *time.Time) sql.NullInt64 {
+	if t == nil {
+		return sql.NullInt64{}
+	}
+
+	return sql.NullInt64{Int64: timeToSqlite(*t), Valid: true}
+}
+
+func timeFromSqlite(t int64) time.Time {
+	return time.Unix(0, t)
+}
+
+func nullableTimeFromSqlite(t sql.NullInt64) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	tt := timeFromSqlite(t.Int64)
+	return &tt
+}
@@ -0,0 +1,205 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/aboglioli/configd/domain/security"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var _ security.AuthorizationRepository = (*SqliteAuthorizationRepository)(nil)
+
+type SqliteAuthorizationRepository struct {
+	db *sql.DB
+}
+
+func NewSqliteAuthorizationRepository(db *sql.DB) *SqliteAuthorizationRepository {
+	return &SqliteAuthorizationRepository{
+		db: db,
+	}
+}
+
+func (r *SqliteAuthorizationRepository) FindById(
+	ctx context.Context,
+	id models.Id,
+) (*security.Authorization, error) {
+	row := r.db.QueryRowContext(
+		ctx,
+		`SELECT id, name, prefix, hashed_api_key, resource_id, access, created_at, last_used_at, expires_at, revoked_at
+		FROM authorizations
+		WHERE id = ?`,
+		id.Value(),
+	)
+
+	a, err := scanSqliteAuthorization(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, security.ErrNotFound
+	}
+
+	return a, err
+}
+
+func (r *SqliteAuthorizationRepository) FindByApiKey(
+	ctx context.Context,
+	hashedApiKey security.HashedApiKey,
+) (*security.Authorization, error) {
+	row := r.db.QueryRowContext(
+		ctx,
+		`SELECT id, name, prefix, hashed_api_key, resource_id, access, created_at, last_used_at, expires_at, revoked_at
+		FROM authorizations
+		WHERE hashed_api_key = ?`,
+		hashedApiKey.Value(),
+	)
+
+	a, err := scanSqliteAuthorization(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, security.ErrNotFound
+	}
+
+	return a, err
+}
+
+func (r *SqliteAuthorizationRepository) FindByResourceId(
+	ctx context.Context,
+	resourceId models.Id,
+) ([]*security.Authorization, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT id, name, prefix, hashed_api_key, resource_id, access, created_at, last_used_at, expires_at, revoked_at
+		FROM authorizations
+		WHERE resource_id = ?
+		ORDER BY created_at`,
+		resourceId.Value(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make([]*security.Authorization, 0)
+	for rows.Next() {
+		a, err := scanSqliteAuthorization(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		found = append(found, a)
+	}
+
+	return found, rows.Err()
+}
+
+func (r *SqliteAuthorizationRepository) Save(ctx context.Context, authorization *security.Authorization) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO authorizations (id, name, prefix, hashed_api_key, resource_id, access, created_at, last_used_at, expires_at, revoked_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (hashed_api_key) DO UPDATE SET
+			name = excluded.name,
+			resource_id = excluded.resource_id,
+			access = excluded.access,
+			last_used_at = COALESCE(MAX(authorizations.last_used_at, excluded.last_used_at), authorizations.last_used_at, excluded.last_used_at),
+			expires_at = COALESCE(MIN(authorizations.expires_at, excluded.expires_at), authorizations.expires_at, excluded.expires_at),
+			revoked_at = COALESCE(authorizations.revoked_at, excluded.revoked_at)`,
+		authorization.Id().Value(),
+		authorization.Name(),
+		authorization.Prefix(),
+		authorization.HashedApiKey().Value(),
+		authorization.ResourceId().Value(),
+		string(authorization.Access()),
+		timeToSqlite(authorization.CreatedAt()),
+		nullableTimeToSqlite(authorization.LastUsedAt()),
+		nullableTimeToSqlite(authorization.ExpiresAt()),
+		nullableTimeToSqlite(authorization.RevokedAt()),
+	)
+
+	return err
+}
+
+func (r *SqliteAuthorizationRepository) UpdateLastUsedAt(
+	ctx context.Context,
+	id models.Id,
+	lastUsedAt time.Time,
+) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`UPDATE authorizations
+		SET last_used_at = ?
+		WHERE id = ? AND revoked_at IS NULL`,
+		timeToSqlite(lastUsedAt),
+		id.Value(),
+	)
+
+	return err
+}
+
+func (r *SqliteAuthorizationRepository) Delete(ctx context.Context, hashedApiKey security.HashedApiKey) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM authorizations WHERE hashed_api_key = ?`, hashedApiKey.Value())
+	return err
+}
+
+func (r *SqliteAuthorizationRepository) DeleteByResourceId(ctx context.Context, resourceId models.Id) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM authorizations WHERE resource_id = ?`, resourceId.Value())
+	return err
+}
+
+func scanSqliteAuthorization(row rowScanner) (*security.Authorization, error) {
+	var (
+		rawId, name, prefix              string
+		rawHashedApiKey, rawResourceId   string
+		rawAccess                        string
+		createdAt                        int64
+		lastUsedAt, expiresAt, revokedAt sql.NullInt64
+	)
+
+	if err := row.Scan(
+		&rawId,
+		&name,
+		&prefix,
+		&rawHashedApiKey,
+		&rawResourceId,
+		&rawAccess,
+		&createdAt,
+		&lastUsedAt,
+		&expiresAt,
+		&revokedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	id, err := models.BuildId(rawId)
+	if err != nil {
+		return nil, err
+	}
+
+	hashedApiKey, err := security.NewHashedApiKey(rawHashedApiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceId, err := models.BuildId(rawResourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	access, err := security.NewAccess(rawAccess)
+	if err != nil {
+		return nil, err
+	}
+
+	return security.BuildAuthorization(
+		id,
+		name,
+		prefix,
+		hashedApiKey,
+		resourceId,
+		access,
+		timeFromSqlite(createdAt),
+		nullableTimeFromSqlite(lastUsedAt),
+		nullableTimeFromSqlite(expiresAt),
+		nullableTimeFromSqlite(revokedAt),
+	)
+}
@@ -0,0 +1,266 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/aboglioli/configd/domain/config"
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var _ config.ConfigRepository = (*SqliteConfigRepository)(nil)
+
+type SqliteConfigRepository struct {
+	db *sql.DB
+}
+
+func NewSqliteConfigRepository(db *sql.DB) *SqliteConfigRepository {
+	return &SqliteConfigRepository{
+		db: db,
+	}
+}
+
+func (r *SqliteConfigRepository) FindById(ctx context.Context, id models.Id) (*config.Config, error) {
+	row := r.db.QueryRowContext(
+		ctx,
+		`SELECT id, schema_id, schema_version, name, config, key_order, created_at, updated_at, deleted_at, version
+		FROM configs
+		WHERE id = ? AND deleted_at IS NULL`,
+		id.Value(),
+	)
+
+	c, err := scanSqliteConfig(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, config.ErrNotFound
+	}
+
+	return c, err
+}
+
+func (r *SqliteConfigRepository) FindBySchemaId(ctx context.Context, schemaId models.Id) ([]*config.Config, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT id, schema_id, schema_version, name, config, key_order, created_at, updated_at, deleted_at, version
+		FROM configs
+		WHERE schema_id = ? AND deleted_at IS NULL`,
+		schemaId.Value(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make([]*config.Config, 0)
+	for rows.Next() {
+		c, err := scanSqliteConfig(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		found = append(found, c)
+	}
+
+	return found, rows.Err()
+}
+
+func (r *SqliteConfigRepository) FindDeletedById(ctx context.Context, id models.Id) (*config.Config, error) {
+	row := r.db.QueryRowContext(
+		ctx,
+		`SELECT id, schema_id, schema_version, name, config, key_order, created_at, updated_at, deleted_at, version
+		FROM configs
+		WHERE id = ? AND deleted_at IS NOT NULL`,
+		id.Value(),
+	)
+
+	c, err := scanSqliteConfig(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, config.ErrNotFound
+	}
+
+	return c, err
+}
+
+func (r *SqliteConfigRepository) FindDeleted(ctx context.Context) ([]*config.Config, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT id, schema_id, schema_version, name, config, key_order, created_at, updated_at, deleted_at, version
+		FROM configs
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make([]*config.Config, 0)
+	for rows.Next() {
+		c, err := scanSqliteConfig(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		found = append(found, c)
+	}
+
+	return found, rows.Err()
+}
+
+func (r *SqliteConfigRepository) Find(ctx context.Context, criteria config.Criteria) ([]*config.Config, error) {
+	q := newFindQuery(sqliteDialect)
+
+	if criteria.SchemaId != nil {
+		q.equals("schema_id", criteria.SchemaId.Value())
+	}
+
+	if criteria.Name != "" {
+		q.nameContains(criteria.Name)
+	}
+
+	query, args := q.build(
+		`SELECT id, schema_id, schema_version, name, config, key_order, created_at, updated_at, deleted_at, version
+		FROM configs`,
+		criteria.Pagination,
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make([]*config.Config, 0)
+	for rows.Next() {
+		c, err := scanSqliteConfig(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		found = append(found, c)
+	}
+
+	return found, rows.Err()
+}
+
+func (r *SqliteConfigRepository) Save(ctx context.Context, c *config.Config) error {
+	data, err := json.Marshal(c.Config())
+	if err != nil {
+		return err
+	}
+
+	keyOrder, err := json.Marshal(c.KeyOrder())
+	if err != nil {
+		return err
+	}
+
+	// Updates only apply over the previous version
+	res, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO configs (id, schema_id, schema_version, name, config, key_order, created_at, updated_at, deleted_at, version)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			schema_id = excluded.schema_id,
+			schema_version = excluded.schema_version,
+			name = excluded.name,
+			config = excluded.config,
+			key_order = excluded.key_order,
+			updated_at = excluded.updated_at,
+			deleted_at = excluded.deleted_at,
+			version = excluded.version
+		WHERE configs.version = excluded.version - 1`,
+		c.Base().Id().Value(),
+		c.SchemaId().Value(),
+		c.SchemaVersion(),
+		c.Name().Value(),
+		string(data),
+		string(keyOrder),
+		timeToSqlite(c.Base().CreatedAt()),
+		timeToSqlite(c.Base().UpdatedAt()),
+		nullableTimeToSqlite(c.Base().DeletedAt()),
+		c.Base().Version(),
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return models.ErrVersionConflict
+	}
+
+	return nil
+}
+
+func (r *SqliteConfigRepository) Delete(ctx context.Context, id models.Id) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM configs WHERE id = ?`, id.Value())
+	return err
+}
+
+func scanSqliteConfig(row rowScanner) (*config.Config, error) {
+	var (
+		rawId, rawSchemaId, rawName, rawConfig, rawKeyOrder string
+		createdAt, updatedAt                                int64
+		deletedAt                                           sql.NullInt64
+		schemaVersion, version                              uint
+	)
+
+	if err := row.Scan(
+		&rawId,
+		&rawSchemaId,
+		&schemaVersion,
+		&rawName,
+		&rawConfig,
+		&rawKeyOrder,
+		&createdAt,
+		&updatedAt,
+		&deletedAt,
+		&version,
+	); err != nil {
+		return nil, err
+	}
+
+	id, err := models.BuildId(rawId)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaId, err := models.BuildId(rawSchemaId)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := config.NewName(rawName)
+	if err != nil {
+		return nil, err
+	}
+
+	var data config.ConfigData
+	if err := json.Unmarshal([]byte(rawConfig), &data); err != nil {
+		return nil, err
+	}
+
+	var keyOrder codec.KeyOrder
+	if err := json.Unmarshal([]byte(rawKeyOrder), &keyOrder); err != nil {
+		return nil, err
+	}
+
+	agg, err := models.BuildAggregateRoot(
+		id,
+		timeFromSqlite(createdAt),
+		timeFromSqlite(updatedAt),
+		nullableTimeFromSqlite(deletedAt),
+		version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return config.BuildConfig(agg, schemaId, schemaVersion, name, data, keyOrder)
+}
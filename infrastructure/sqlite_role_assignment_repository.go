@@ -0,0 +1,127 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var _ user.RoleAssignmentRepository = (*SqliteRoleAssignmentRepository)(nil)
+
+type SqliteRoleAssignmentRepository struct {
+	db *sql.DB
+}
+
+func NewSqliteRoleAssignmentRepository(db *sql.DB) *SqliteRoleAssignmentRepository {
+	return &SqliteRoleAssignmentRepository{
+		db: db,
+	}
+}
+
+func (r *SqliteRoleAssignmentRepository) FindById(ctx context.Context, id models.Id) (*user.RoleAssignment, error) {
+	row := r.db.QueryRowContext(
+		ctx,
+		`SELECT id, username, role_id, scope, created_at
+		FROM role_assignments
+		WHERE id = ?`,
+		id.Value(),
+	)
+
+	a, err := scanSqliteRoleAssignment(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, user.ErrRoleAssignmentNotFound
+	}
+
+	return a, err
+}
+
+func (r *SqliteRoleAssignmentRepository) FindByUsername(
+	ctx context.Context,
+	username user.Username,
+) ([]*user.RoleAssignment, error) {
+	return r.find(
+		ctx,
+		`SELECT id, username, role_id, scope, created_at
+		FROM role_assignments
+		WHERE username = ?
+		ORDER BY created_at`,
+		username.Value(),
+	)
+}
+
+func (r *SqliteRoleAssignmentRepository) FindByRoleId(
+	ctx context.Context,
+	roleId models.Id,
+) ([]*user.RoleAssignment, error) {
+	return r.find(
+		ctx,
+		`SELECT id, username, role_id, scope, created_at
+		FROM role_assignments
+		WHERE role_id = ?
+		ORDER BY created_at`,
+		roleId.Value(),
+	)
+}
+
+func (r *SqliteRoleAssignmentRepository) find(
+	ctx context.Context,
+	query string,
+	args ...interface{},
+) ([]*user.RoleAssignment, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make([]*user.RoleAssignment, 0)
+	for rows.Next() {
+		a, err := scanSqliteRoleAssignment(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		found = append(found, a)
+	}
+
+	return found, rows.Err()
+}
+
+func (r *SqliteRoleAssignmentRepository) Save(ctx context.Context, a *user.RoleAssignment) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO role_assignments (id, username, role_id, scope, created_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			role_id = excluded.role_id,
+			scope = excluded.scope`,
+		a.Id().Value(),
+		a.Username().Value(),
+		a.RoleId().Value(),
+		a.Scope().Value(),
+		timeToSqlite(a.CreatedAt()),
+	)
+
+	return err
+}
+
+func (r *SqliteRoleAssignmentRepository) Delete(ctx context.Context, id models.Id) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM role_assignments WHERE id = ?`, id.Value())
+	return err
+}
+
+func scanSqliteRoleAssignment(row rowScanner) (*user.RoleAssignment, error) {
+	var (
+		rawId, rawUsername, rawRoleId, rawScope string
+		createdAt                               int64
+	)
+
+	if err := row.Scan(&rawId, &rawUsername, &rawRoleId, &rawScope, &createdAt); err != nil {
+		return nil, err
+	}
+
+	return buildRoleAssignment(rawId, rawUsername, rawRoleId, rawScope, timeFromSqlite(createdAt))
+}
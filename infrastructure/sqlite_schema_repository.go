@@ -0,0 +1,239 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var _ schema.SchemaRepository = (*SqliteSchemaRepository)(nil)
+
+type SqliteSchemaRepository struct {
+	db *sql.DB
+}
+
+func NewSqliteSchemaRepository(db *sql.DB) *SqliteSchemaRepository {
+	return &SqliteSchemaRepository{
+		db: db,
+	}
+}
+
+func (r *SqliteSchemaRepository) FindById(ctx context.Context, id models.Id) (*schema.Schema, error) {
+	row := r.db.QueryRowContext(
+		ctx,
+		`SELECT id, name, validation_mode, props, key_order, created_at, updated_at, deleted_at, version
+		FROM schemas
+		WHERE id = ? AND deleted_at IS NULL`,
+		id.Value(),
+	)
+
+	s, err := scanSqliteSchema(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, schema.ErrNotFound
+	}
+
+	return s, err
+}
+
+func (r *SqliteSchemaRepository) FindDeletedById(ctx context.Context, id models.Id) (*schema.Schema, error) {
+	row := r.db.QueryRowContext(
+		ctx,
+		`SELECT id, name, validation_mode, props, key_order, created_at, updated_at, deleted_at, version
+		FROM schemas
+		WHERE id = ? AND deleted_at IS NOT NULL`,
+		id.Value(),
+	)
+
+	s, err := scanSqliteSchema(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, schema.ErrNotFound
+	}
+
+	return s, err
+}
+
+func (r *SqliteSchemaRepository) FindDeleted(ctx context.Context) ([]*schema.Schema, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT id, name, validation_mode, props, key_order, created_at, updated_at, deleted_at, version
+		FROM schemas
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make([]*schema.Schema, 0)
+	for rows.Next() {
+		s, err := scanSqliteSchema(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		found = append(found, s)
+	}
+
+	return found, rows.Err()
+}
+
+func (r *SqliteSchemaRepository) Find(ctx context.Context, criteria schema.Criteria) ([]*schema.Schema, error) {
+	q := newFindQuery(sqliteDialect)
+
+	if criteria.Name != "" {
+		q.nameContains(criteria.Name)
+	}
+
+	query, args := q.build(
+		`SELECT id, name, validation_mode, props, key_order, created_at, updated_at, deleted_at, version
+		FROM schemas`,
+		criteria.Pagination,
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make([]*schema.Schema, 0)
+	for rows.Next() {
+		s, err := scanSqliteSchema(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		found = append(found, s)
+	}
+
+	return found, rows.Err()
+}
+
+func (r *SqliteSchemaRepository) Save(ctx context.Context, s *schema.Schema) error {
+	props, err := json.Marshal(s.ToMap())
+	if err != nil {
+		return err
+	}
+
+	keyOrder, err := json.Marshal(s.KeyOrder())
+	if err != nil {
+		return err
+	}
+
+	// Updates only apply over the previous version
+	res, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO schemas (id, name, validation_mode, props, key_order, created_at, updated_at, deleted_at, version)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			name = excluded.name,
+			validation_mode = excluded.validation_mode,
+			props = excluded.props,
+			key_order = excluded.key_order,
+			updated_at = excluded.updated_at,
+			deleted_at = excluded.deleted_at,
+			version = excluded.version
+		WHERE schemas.version = excluded.version - 1`,
+		s.Base().Id().Value(),
+		s.Name().Value(),
+		s.ValidationMode().String(),
+		string(props),
+		string(keyOrder),
+		timeToSqlite(s.Base().CreatedAt()),
+		timeToSqlite(s.Base().UpdatedAt()),
+		nullableTimeToSqlite(s.Base().DeletedAt()),
+		s.Base().Version(),
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return models.ErrVersionConflict
+	}
+
+	return nil
+}
+
+func (r *SqliteSchemaRepository) Delete(ctx context.Context, id models.Id) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM schemas WHERE id = ?`, id.Value())
+	return err
+}
+
+func scanSqliteSchema(row rowScanner) (*schema.Schema, error) {
+	var (
+		rawId, rawName, rawValidationMode, rawProps, rawKeyOrder string
+		createdAt, updatedAt                                     int64
+		deletedAt                                                sql.NullInt64
+		version                                                  uint
+	)
+
+	if err := row.Scan(
+		&rawId,
+		&rawName,
+		&rawValidationMode,
+		&rawProps,
+		&rawKeyOrder,
+		&createdAt,
+		&updatedAt,
+		&deletedAt,
+		&version,
+	); err != nil {
+		return nil, err
+	}
+
+	id, err := models.BuildId(rawId)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := schema.NewName(rawName)
+	if err != nil {
+		return nil, err
+	}
+
+	validationMode, err := schema.NewValidationMode(rawValidationMode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Props are stored with the same format returned by Schema.ToMap
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(rawProps), &m); err != nil {
+		return nil, err
+	}
+
+	var keyOrder codec.KeyOrder
+	if err := json.Unmarshal([]byte(rawKeyOrder), &keyOrder); err != nil {
+		return nil, err
+	}
+
+	props, err := schema.PropsFromMap(m)
+	if err != nil {
+		return nil, err
+	}
+
+	agg, err := models.BuildAggregateRoot(
+		id,
+		timeFromSqlite(createdAt),
+		timeFromSqlite(updatedAt),
+		nullableTimeFromSqlite(deletedAt),
+		version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.BuildSchema(agg, name, validationMode, keyOrder, props...)
+}
@@ -0,0 +1,166 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/aboglioli/configd/domain/schema"
+	"github.com/aboglioli/configd/pkg/codec"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var _ schema.VersionRepository = (*SqliteSchemaVersionRepository)(nil)
+
+type SqliteSchemaVersionRepository struct {
+	db *sql.DB
+}
+
+func NewSqliteSchemaVersionRepository(db *sql.DB) *SqliteSchemaVersionRepository {
+	return &SqliteSchemaVersionRepository{
+		db: db,
+	}
+}
+
+func (r *SqliteSchemaVersionRepository) FindBySchemaId(ctx context.Context, schemaId models.Id) ([]*schema.Version, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT schema_id, version, props, key_order, created_at
+		FROM schema_versions
+		WHERE schema_id = ?
+		ORDER BY version`,
+		schemaId.Value(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make([]*schema.Version, 0)
+	for rows.Next() {
+		v, err := scanSqliteSchemaVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		found = append(found, v)
+	}
+
+	return found, rows.Err()
+}
+
+func (r *SqliteSchemaVersionRepository) FindByVersion(
+	ctx context.Context,
+	schemaId models.Id,
+	version uint,
+) (*schema.Version, error) {
+	row := r.db.QueryRowContext(
+		ctx,
+		`SELECT schema_id, version, props, key_order, created_at
+		FROM schema_versions
+		WHERE schema_id = ? AND version = ?`,
+		schemaId.Value(),
+		version,
+	)
+
+	v, err := scanSqliteSchemaVersion(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, schema.ErrVersionNotFound
+	}
+
+	return v, err
+}
+
+func (r *SqliteSchemaVersionRepository) FindLatest(ctx context.Context, schemaId models.Id) (*schema.Version, error) {
+	row := r.db.QueryRowContext(
+		ctx,
+		`SELECT schema_id, version, props, key_order, created_at
+		FROM schema_versions
+		WHERE schema_id = ?
+		ORDER BY version DESC
+		LIMIT 1`,
+		schemaId.Value(),
+	)
+
+	v, err := scanSqliteSchemaVersion(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, schema.ErrVersionNotFound
+	}
+
+	return v, err
+}
+
+func (r *SqliteSchemaVersionRepository) Save(ctx context.Context, version *schema.Version) error {
+	props, err := json.Marshal(version.ToMap())
+	if err != nil {
+		return err
+	}
+
+	keyOrder, err := json.Marshal(version.KeyOrder())
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(
+		ctx,
+		`INSERT INTO schema_versions (schema_id, version, props, key_order, created_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (schema_id, version) DO NOTHING`,
+		version.SchemaId().Value(),
+		version.Version(),
+		string(props),
+		string(keyOrder),
+		timeToSqlite(version.CreatedAt()),
+	)
+
+	return err
+}
+
+func (r *SqliteSchemaVersionRepository) DeleteBySchemaId(ctx context.Context, schemaId models.Id) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM schema_versions WHERE schema_id = ?`, schemaId.Value())
+	return err
+}
+
+func scanSqliteSchemaVersion(row rowScanner) (*schema.Version, error) {
+	var (
+		rawSchemaId string
+		version     uint
+		rawProps    string
+		rawKeyOrder string
+		createdAt   int64
+	)
+
+	if err := row.Scan(
+		&rawSchemaId,
+		&version,
+		&rawProps,
+		&rawKeyOrder,
+		&createdAt,
+	); err != nil {
+		return nil, err
+	}
+
+	schemaId, err := models.BuildId(rawSchemaId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Props are stored with the same format returned by Version.ToMap
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(rawProps), &m); err != nil {
+		return nil, err
+	}
+
+	var keyOrder codec.KeyOrder
+	if err := json.Unmarshal([]byte(rawKeyOrder), &keyOrder); err != nil {
+		return nil, err
+	}
+
+	props, err := schema.PropsFromMap(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.BuildVersion(schemaId, version, timeFromSqlite(createdAt), keyOrder, props...)
+}
@@ -0,0 +1,139 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var _ user.SessionRepository = (*SqliteSessionRepository)(nil)
+
+type SqliteSessionRepository struct {
+	db *sql.DB
+}
+
+func NewSqliteSessionRepository(db *sql.DB) *SqliteSessionRepository {
+	return &SqliteSessionRepository{
+		db: db,
+	}
+}
+
+func (r *SqliteSessionRepository) FindById(ctx context.Context, id models.Id) (*user.Session, error) {
+	row := r.db.QueryRowContext(
+		ctx,
+		`SELECT id, username, hashed_refresh_token, created_at, expires_at, revoked_at
+		FROM sessions
+		WHERE id = ?`,
+		id.Value(),
+	)
+
+	s, err := scanSqliteSession(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, user.ErrSessionNotFound
+	}
+
+	return s, err
+}
+
+func (r *SqliteSessionRepository) Save(ctx context.Context, s *user.Session) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO sessions (id, username, hashed_refresh_token, created_at, expires_at, revoked_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			hashed_refresh_token = excluded.hashed_refresh_token,
+			expires_at = excluded.expires_at,
+			revoked_at = COALESCE(sessions.revoked_at, excluded.revoked_at)`,
+		s.Id().Value(),
+		s.Username().Value(),
+		s.HashedRefreshToken(),
+		timeToSqlite(s.CreatedAt()),
+		timeToSqlite(s.ExpiresAt()),
+		nullableTimeToSqlite(s.RevokedAt()),
+	)
+
+	return err
+}
+
+func (r *SqliteSessionRepository) SaveRefreshed(
+	ctx context.Context,
+	s *user.Session,
+	previousHashedRefreshToken string,
+) error {
+	res, err := r.db.ExecContext(
+		ctx,
+		`UPDATE sessions
+		SET hashed_refresh_token = ?, expires_at = ?
+		WHERE id = ? AND hashed_refresh_token = ? AND revoked_at IS NULL`,
+		s.HashedRefreshToken(),
+		timeToSqlite(s.ExpiresAt()),
+		s.Id().Value(),
+		previousHashedRefreshToken,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return user.ErrInvalidRefreshToken
+	}
+
+	return nil
+}
+
+func (r *SqliteSessionRepository) DeleteInactive(ctx context.Context, now time.Time) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`DELETE FROM sessions WHERE expires_at <= ? OR revoked_at IS NOT NULL`,
+		timeToSqlite(now),
+	)
+
+	return err
+}
+
+func scanSqliteSession(row rowScanner) (*user.Session, error) {
+	var (
+		rawId, rawUsername, hashedRefreshToken string
+		createdAt, expiresAt                   int64
+		revokedAt                              sql.NullInt64
+	)
+
+	if err := row.Scan(
+		&rawId,
+		&rawUsername,
+		&hashedRefreshToken,
+		&createdAt,
+		&expiresAt,
+		&revokedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	id, err := models.BuildId(rawId)
+	if err != nil {
+		return nil, err
+	}
+
+	username, err := user.NewUsername(rawUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	return user.BuildSession(
+		id,
+		username,
+		hashedRefreshToken,
+		timeFromSqlite(createdAt),
+		timeFromSqlite(expiresAt),
+		nullableTimeFromSqlite(revokedAt),
+	)
+}
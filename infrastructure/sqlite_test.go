@@ -0,0 +1,120 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSqliteRepositories(t *testing.T) {
+	db, err := OpenSqlite(":memory:")
+	utils.Ok(err)
+	defer db.Close()
+
+	t.Run("config", func(t *testing.T) {
+		testConfigRepository(t, NewSqliteConfigRepository(db))
+	})
+
+	t.Run("revision", func(t *testing.T) {
+		testRevisionRepository(t, NewSqliteRevisionRepository(db))
+	})
+
+	t.Run("schema", func(t *testing.T) {
+		testSchemaRepository(t, NewSqliteSchemaRepository(db))
+	})
+
+	t.Run("schema version", func(t *testing.T) {
+		testSchemaVersionRepository(t, NewSqliteSchemaVersionRepository(db))
+	})
+
+	t.Run("config find", func(t *testing.T) {
+		testConfigFind(t, NewSqliteConfigRepository(db))
+	})
+
+	t.Run("schema find", func(t *testing.T) {
+		testSchemaFind(t, NewSqliteSchemaRepository(db))
+	})
+
+	t.Run("user", func(t *testing.T) {
+		testUserRepository(t, NewSqliteUserRepository(db))
+	})
+
+	t.Run("authorization", func(t *testing.T) {
+		testAuthorizationRepository(t, NewSqliteAuthorizationRepository(db))
+	})
+
+	t.Run("session", func(t *testing.T) {
+		testSessionRepository(t, NewSqliteSessionRepository(db))
+	})
+
+	t.Run("role", func(t *testing.T) {
+		testRoleRepository(t, NewSqliteRoleRepository(db))
+	})
+
+	t.Run("role assignment", func(t *testing.T) {
+		testRoleAssignmentRepository(t, NewSqliteRoleAssignmentRepository(db))
+	})
+}
+
+func TestSqliteMigrationsAreIdempotent(t *testing.T) {
+	path := t.TempDir() + "/configd.db"
+
+	db, err := OpenSqlite(path)
+	utils.Ok(err)
+	db.Close()
+
+	db, err = OpenSqlite(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+}
+
+func TestSqliteMigrationAssignsRolesFromAccess(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	utils.Ok(err)
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+
+	// Users registered before roles existed
+	utils.Ok(migrate(ctx, db, sqliteDialect, sqliteMigrations[:6]))
+
+	users := NewSqliteUserRepository(db)
+	for rawUsername, access := range map[string]user.Access{
+		"admin":  user.FULL_ACCESS,
+		"reader": user.READ_ONLY_ACCESS,
+	} {
+		username, err := user.NewUsername(rawUsername)
+		utils.Ok(err)
+
+		password, err := user.NewPassword("password123")
+		utils.Ok(err)
+
+		u, err := user.NewUser(username, password, access)
+		utils.Ok(err)
+		utils.Ok(users.Save(ctx, u))
+	}
+
+	utils.Ok(migrate(ctx, db, sqliteDialect, sqliteMigrations))
+
+	assignments := NewSqliteRoleAssignmentRepository(db)
+	for rawUsername, role := range map[string]string{
+		"admin":  user.ADMIN_ROLE,
+		"reader": user.VIEWER_ROLE,
+	} {
+		username, err := user.NewUsername(rawUsername)
+		utils.Ok(err)
+
+		found, err := assignments.FindByUsername(ctx, username)
+		if assert.NoError(t, err) && assert.Len(t, found, 1) {
+			assert.Equal(t, role, found[0].RoleId().Value())
+			assert.True(t, found[0].Scope().IsGlobal())
+		}
+	}
+}
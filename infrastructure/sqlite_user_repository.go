@@ -0,0 +1,121 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/aboglioli/configd/domain/user"
+	"github.com/aboglioli/configd/pkg/models"
+)
+
+var _ user.UserRepository = (*SqliteUserRepository)(nil)
+
+type SqliteUserRepository struct {
+	db *sql.DB
+}
+
+func NewSqliteUserRepository(db *sql.DB) *SqliteUserRepository {
+	return &SqliteUserRepository{
+		db: db,
+	}
+}
+
+func (r *SqliteUserRepository) FindByUsername(ctx context.Context, username user.Username) (*user.User, error) {
+	row := r.db.QueryRowContext(
+		ctx,
+		`SELECT username, hashed_password, access, created_at, updated_at, deleted_at, version
+		FROM users
+		WHERE username = ?`,
+		username.Value(),
+	)
+
+	u, err := scanSqliteUser(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, user.ErrNotFound
+	}
+
+	return u, err
+}
+
+func (r *SqliteUserRepository) Save(ctx context.Context, u *user.User) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO users (username, hashed_password, access, created_at, updated_at, deleted_at, version)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (username) DO UPDATE SET
+			hashed_password = excluded.hashed_password,
+			access = excluded.access,
+			updated_at = excluded.updated_at,
+			deleted_at = excluded.deleted_at,
+			version = excluded.version`,
+		u.Username().Value(),
+		u.HashedPassword().Value(),
+		string(u.Access()),
+		timeToSqlite(u.Base().CreatedAt()),
+		timeToSqlite(u.Base().UpdatedAt()),
+		nullableTimeToSqlite(u.Base().DeletedAt()),
+		u.Base().Version(),
+	)
+
+	return err
+}
+
+func (r *SqliteUserRepository) Delete(ctx context.Context, username user.Username) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM users WHERE username = ?`, username.Value())
+	return err
+}
+
+func scanSqliteUser(row rowScanner) (*user.User, error) {
+	var (
+		rawUsername, rawHashedPassword, rawAccess string
+		createdAt, updatedAt                      int64
+		deletedAt                                 sql.NullInt64
+		version                                   uint
+	)
+
+	if err := row.Scan(
+		&rawUsername,
+		&rawHashedPassword,
+		&rawAccess,
+		&createdAt,
+		&updatedAt,
+		&deletedAt,
+		&version,
+	); err != nil {
+		return nil, err
+	}
+
+	username, err := user.NewUsername(rawUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := user.NewHashedPassword(rawHashedPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	access, err := user.NewAccess(rawAccess)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := models.BuildId(username.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	agg, err := models.BuildAggregateRoot(
+		id,
+		timeFromSqlite(createdAt),
+		timeFromSqlite(updatedAt),
+		nullableTimeFromSqlite(deletedAt),
+		version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return user.BuildUser(agg, username, hashedPassword, access)
+}
@@ -0,0 +1,240 @@
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is a document format accepted and rendered by the API.
+type Format string
+
+const (
+	JSON_FORMAT Format = "json"
+	YAML_FORMAT Format = "yaml"
+	TOML_FORMAT Format = "toml"
+)
+
+// FormatFromContentType returns the format of a request body. Bodies without
+// Content-Type are JSON.
+func FormatFromContentType(contentType string) (Format, error) {
+	if contentType == "" {
+		return JSON_FORMAT, nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", err
+	}
+
+	if format, ok := formatFromMediaType(mediaType); ok {
+		return format, nil
+	}
+
+	return "", fmt.Errorf("unsupported content type %s", mediaType)
+}
+
+// FormatFromAccept returns the preferred format listed in an Accept header,
+// JSON being the default.
+func FormatFromAccept(accept string) Format {
+	type candidate struct {
+		format Format
+		q      float64
+	}
+
+	candidates := make([]candidate, 0)
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		format, ok := formatFromMediaType(mediaType)
+		if !ok {
+			continue
+		}
+
+		q := 1.0
+		if raw, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(raw, 64); err == nil {
+				q = parsed
+			}
+		}
+
+		candidates = append(candidates, candidate{format, q})
+	}
+
+	// Stable to keep the listed order between equal weights
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+
+	if len(candidates) == 0 || candidates[0].q <= 0 {
+		return JSON_FORMAT
+	}
+
+	return candidates[0].format
+}
+
+func formatFromMediaType(mediaType string) (Format, bool) {
+	switch mediaType {
+	case "application/json":
+		return JSON_FORMAT, true
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return YAML_FORMAT, true
+	case "application/toml", "text/toml":
+		return TOML_FORMAT, true
+	}
+
+	return "", false
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case YAML_FORMAT:
+		return "application/yaml; charset=utf-8"
+	case TOML_FORMAT:
+		return "application/toml; charset=utf-8"
+	}
+
+	return "application/json; charset=utf-8"
+}
+
+// Decode parses a document into the values produced by encoding/json:
+// objects with string keys, float64 numbers, strings, bools and nil. This way
+// props checks behave the same whatever the format of the document. Dates
+// are decoded as RFC 3339 strings.
+func Decode(f Format, data []byte) (interface{}, error) {
+	var v interface{}
+
+	switch f {
+	case JSON_FORMAT:
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+
+		return v, nil
+	case YAML_FORMAT:
+		if err := yaml.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+	case TOML_FORMAT:
+		var m map[string]interface{}
+		if err := toml.Unmarshal(data, &m); err != nil {
+			return nil, err
+		}
+
+		v = m
+	default:
+		return nil, fmt.Errorf("unsupported format %s", f)
+	}
+
+	return normalize(v)
+}
+
+func normalize(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			n, err := normalize(e)
+			if err != nil {
+				return nil, err
+			}
+
+			m[k] = n
+		}
+
+		return m, nil
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			n, err := normalize(e)
+			if err != nil {
+				return nil, err
+			}
+
+			m[fmt.Sprint(k)] = n
+		}
+
+		return m, nil
+	case []map[string]interface{}:
+		arr := make([]interface{}, 0, len(v))
+		for _, e := range v {
+			n, err := normalize(e)
+			if err != nil {
+				return nil, err
+			}
+
+			arr = append(arr, n)
+		}
+
+		return arr, nil
+	case []interface{}:
+		arr := make([]interface{}, 0, len(v))
+		for _, e := range v {
+			n, err := normalize(e)
+			if err != nil {
+				return nil, err
+			}
+
+			arr = append(arr, n)
+		}
+
+		return arr, nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("unsupported number %v", v)
+		}
+
+		return v, nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case string, bool, nil:
+		return v, nil
+	}
+
+	return nil, fmt.Errorf("unsupported value %v", v)
+}
+
+// Encode renders v, any value encodable by encoding/json, honoring its json
+// tags. Keys keep the order produced by encoding/json: struct fields in
+// declaration order, map keys sorted and Ordered values in their own order.
+func Encode(f Format, v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if f == JSON_FORMAT {
+		return data, nil
+	}
+
+	tree, err := decodeOrdered(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch f {
+	case YAML_FORMAT:
+		return encodeYaml(tree)
+	case TOML_FORMAT:
+		return encodeToml(tree)
+	}
+
+	return nil, fmt.Errorf("unsupported format %s", f)
+}
@@ -0,0 +1,348 @@
+package codec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatFromContentType(t *testing.T) {
+	type test struct {
+		name        string
+		contentType string
+		expected    Format
+		err         bool
+	}
+
+	tests := []test{
+		{
+			name:     "no content type",
+			expected: JSON_FORMAT,
+		},
+		{
+			name:        "json",
+			contentType: "application/json; charset=utf-8",
+			expected:    JSON_FORMAT,
+		},
+		{
+			name:        "yaml",
+			contentType: "application/x-yaml",
+			expected:    YAML_FORMAT,
+		},
+		{
+			name:        "toml",
+			contentType: "application/toml",
+			expected:    TOML_FORMAT,
+		},
+		{
+			name:        "unsupported",
+			contentType: "text/plain",
+			err:         true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			format, err := FormatFromContentType(test.contentType)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.expected, format)
+			}
+		})
+	}
+}
+
+func TestFormatFromAccept(t *testing.T) {
+	type test struct {
+		name     string
+		accept   string
+		expected Format
+	}
+
+	tests := []test{
+		{
+			name:     "no accept",
+			expected: JSON_FORMAT,
+		},
+		{
+			name:     "any",
+			accept:   "*/*",
+			expected: JSON_FORMAT,
+		},
+		{
+			name:     "yaml",
+			accept:   "text/yaml",
+			expected: YAML_FORMAT,
+		},
+		{
+			name:     "first listed",
+			accept:   "text/html, application/toml, application/yaml",
+			expected: TOML_FORMAT,
+		},
+		{
+			name:     "weighted",
+			accept:   "application/json;q=0.5, application/yaml;q=0.9",
+			expected: YAML_FORMAT,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, FormatFromAccept(test.accept))
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	expected := map[string]interface{}{
+		"port":    float64(8080),
+		"ratio":   0.5,
+		"debug":   true,
+		"hosts":   []interface{}{"a", "b"},
+		"started": "2022-01-10T12:30:00Z",
+		"db": map[string]interface{}{
+			"user": "admin",
+		},
+	}
+
+	type test struct {
+		name   string
+		format Format
+		doc    string
+		err    bool
+	}
+
+	tests := []test{
+		{
+			name:   "json",
+			format: JSON_FORMAT,
+			doc: `{
+				"port": 8080,
+				"ratio": 0.5,
+				"debug": true,
+				"hosts": ["a", "b"],
+				"started": "2022-01-10T12:30:00Z",
+				"db": { "user": "admin" }
+			}`,
+		},
+		{
+			name:   "yaml",
+			format: YAML_FORMAT,
+			doc: `
+port: 8080
+ratio: 0.5
+debug: true
+hosts:
+  - a
+  - b
+started: 2022-01-10T12:30:00Z
+db:
+  user: admin
+`,
+		},
+		{
+			name:   "toml",
+			format: TOML_FORMAT,
+			doc: `
+port = 8080
+ratio = 0.5
+debug = true
+hosts = ["a", "b"]
+started = 2022-01-10T12:30:00Z
+
+[db]
+user = "admin"
+`,
+		},
+		{
+			name:   "invalid yaml",
+			format: YAML_FORMAT,
+			doc:    "port: [8080",
+			err:    true,
+		},
+		{
+			name:   "invalid toml",
+			format: TOML_FORMAT,
+			doc:    "port = ",
+			err:    true,
+		},
+		{
+			name:   "yaml infinity",
+			format: YAML_FORMAT,
+			doc:    "ratio: .inf",
+			err:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v, err := Decode(test.format, []byte(test.doc))
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, expected, v)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	type db struct {
+		User string `json:"user"`
+	}
+
+	type service struct {
+		Name    string                 `json:"name"`
+		Port    int                    `json:"port"`
+		Ratio   float64                `json:"ratio"`
+		Debug   bool                   `json:"debug"`
+		Default interface{}            `json:"default"`
+		Hosts   []string               `json:"hosts"`
+		Zones   []db                   `json:"zones"`
+		Db      db                     `json:"db"`
+		Labels  map[string]interface{} `json:"labels"`
+	}
+
+	v := service{
+		Name:  "Service",
+		Port:  8080,
+		Ratio: 0.5,
+		Debug: true,
+		Hosts: []string{"a", "b"},
+		Zones: []db{{User: "admin"}},
+		Db:    db{User: "admin"},
+		Labels: map[string]interface{}{
+			"team":    "core",
+			"app.env": "true",
+		},
+	}
+
+	type test struct {
+		name     string
+		format   Format
+		value    interface{}
+		expected string
+		err      bool
+	}
+
+	tests := []test{
+		{
+			name:     "json",
+			format:   JSON_FORMAT,
+			value:    v,
+			expected: `{"name":"Service","port":8080,"ratio":0.5,"debug":true,"default":null,"hosts":["a","b"],"zones":[{"user":"admin"}],"db":{"user":"admin"},"labels":{"app.env":"true","team":"core"}}`,
+		},
+		{
+			name:   "yaml",
+			format: YAML_FORMAT,
+			value:  v,
+			expected: `name: Service
+port: 8080
+ratio: 0.5
+debug: true
+default: null
+hosts:
+  - a
+  - b
+zones:
+  - user: admin
+db:
+  user: admin
+labels:
+  app.env: "true"
+  team: core
+`,
+		},
+		{
+			name:   "toml",
+			format: TOML_FORMAT,
+			value:  v,
+			expected: `name = "Service"
+port = 8080
+ratio = 0.5
+debug = true
+hosts = ["a", "b"]
+zones = [{ user = "admin" }]
+
+[db]
+user = "admin"
+
+[labels]
+"app.env" = "true"
+team = "core"
+`,
+		},
+		{
+			name:   "toml nested tables",
+			format: TOML_FORMAT,
+			value: map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": map[string]interface{}{"c": 1},
+				},
+				"empty": map[string]interface{}{},
+			},
+			expected: `[a.b]
+c = 1
+
+[empty]
+`,
+		},
+		{
+			name:   "toml root is not an object",
+			format: TOML_FORMAT,
+			value:  []int{1, 2},
+			err:    true,
+		},
+		{
+			name:   "toml null in array",
+			format: TOML_FORMAT,
+			value:  map[string]interface{}{"hosts": []interface{}{"a", nil}},
+			err:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := Encode(test.format, test.value)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.expected, string(data))
+			}
+		})
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	v := map[string]interface{}{
+		"port":  float64(8080),
+		"ratio": 1.5,
+		"name":  "true",
+		"db":    map[string]interface{}{"user": "admin"},
+	}
+
+	for _, format := range []Format{JSON_FORMAT, YAML_FORMAT, TOML_FORMAT} {
+		t.Run(string(format), func(t *testing.T) {
+			data, err := Encode(format, v)
+			assert.NoError(t, err)
+
+			decoded, err := Decode(format, data)
+			if assert.NoError(t, err) {
+				assert.Equal(t, v, decoded)
+			}
+		})
+	}
+}
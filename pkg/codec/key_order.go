@@ -0,0 +1,235 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// KeyOrder lists the object keys of a document in the order they are
+// written, as paths of keys separated by slashes (JSON pointers without array
+// indexes). Objects inside an array share the path of the array, so all of
+// them render their keys in the same order.
+type KeyOrder []string
+
+// DecodeKeyOrder returns the order of the keys written in a document.
+func DecodeKeyOrder(f Format, data []byte) (KeyOrder, error) {
+	order := newKeyOrderBuilder()
+
+	switch f {
+	case JSON_FORMAT:
+		tree, err := decodeOrdered(data)
+		if err != nil {
+			return nil, err
+		}
+
+		order.addOrdered("", tree)
+	case YAML_FORMAT:
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return nil, err
+		}
+
+		order.addYaml("", &node)
+	case TOML_FORMAT:
+		var m map[string]interface{}
+		md, err := toml.Decode(string(data), &m)
+		if err != nil {
+			return nil, err
+		}
+
+		// Keys of inline tables are listed before the table itself
+		for _, key := range md.Keys() {
+			path := ""
+			for _, k := range key {
+				path = keyPath(path, k)
+				order.add(path)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format %s", f)
+	}
+
+	return order.order, nil
+}
+
+// Sub returns the order of the keys of the object under key, relative to it.
+func (o KeyOrder) Sub(key string) KeyOrder {
+	prefix := keyPath("", key) + "/"
+
+	var sub KeyOrder
+	for _, path := range o {
+		if strings.HasPrefix(path, prefix) {
+			sub = append(sub, path[len(prefix)-1:])
+		}
+	}
+
+	return sub
+}
+
+// keyPath escapes key as JSON pointers do and appends it to path.
+func keyPath(path, key string) string {
+	key = strings.ReplaceAll(key, "~", "~0")
+	key = strings.ReplaceAll(key, "/", "~1")
+
+	return path + "/" + key
+}
+
+type keyOrderBuilder struct {
+	order KeyOrder
+	seen  map[string]bool
+}
+
+func newKeyOrderBuilder() *keyOrderBuilder {
+	return &keyOrderBuilder{
+		seen: make(map[string]bool),
+	}
+}
+
+func (b *keyOrderBuilder) add(path string) {
+	if !b.seen[path] {
+		b.seen[path] = true
+		b.order = append(b.order, path)
+	}
+}
+
+func (b *keyOrderBuilder) addOrdered(path string, v interface{}) {
+	switch v := v.(type) {
+	case orderedObject:
+		for i, k := range v.keys {
+			b.add(keyPath(path, k))
+			b.addOrdered(keyPath(path, k), v.values[i])
+		}
+	case []interface{}:
+		for _, e := range v {
+			b.addOrdered(path, e)
+		}
+	}
+}
+
+func (b *keyOrderBuilder) addYaml(path string, node *yaml.Node) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, n := range node.Content {
+			b.addYaml(path, n)
+		}
+	case yaml.AliasNode:
+		b.addYaml(path, node.Alias)
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+
+			// Merged keys belong to the object merging them
+			if key.Tag == "!!merge" {
+				b.addYaml(path, value)
+				continue
+			}
+
+			b.add(keyPath(path, key.Value))
+			b.addYaml(keyPath(path, key.Value), value)
+		}
+	}
+}
+
+// Ordered renders Value as JSON with the keys of its objects in Order. Keys
+// not listed in Order are rendered after the listed ones, sorted.
+type Ordered struct {
+	Value interface{}
+	Order KeyOrder
+}
+
+func (o Ordered) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(o.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(o.Order) == 0 {
+		return data, nil
+	}
+
+	tree, err := decodeOrdered(data)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make(map[string]int, len(o.Order))
+	for i, path := range o.Order {
+		indexes[path] = i
+	}
+
+	return json.Marshal(sortOrdered("", tree, indexes))
+}
+
+func sortOrdered(path string, v interface{}, indexes map[string]int) interface{} {
+	switch v := v.(type) {
+	case orderedObject:
+		positions := make([]int, len(v.keys))
+		for i := range positions {
+			positions[i] = i
+		}
+
+		// Keys come sorted from encoding/json, a stable sort keeps unlisted
+		// ones that way
+		sort.SliceStable(positions, func(a, b int) bool {
+			indexA, listedA := indexes[keyPath(path, v.keys[positions[a]])]
+			indexB, listedB := indexes[keyPath(path, v.keys[positions[b]])]
+
+			if listedA && listedB {
+				return indexA < indexB
+			}
+
+			return listedA && !listedB
+		})
+
+		sorted := orderedObject{}
+		for _, i := range positions {
+			sorted.keys = append(sorted.keys, v.keys[i])
+			sorted.values = append(sorted.values, sortOrdered(keyPath(path, v.keys[i]), v.values[i], indexes))
+		}
+
+		return sorted
+	case []interface{}:
+		arr := make([]interface{}, 0, len(v))
+		for _, e := range v {
+			arr = append(arr, sortOrdered(path, e, indexes))
+		}
+
+		return arr
+	}
+
+	return v
+}
+
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteString("{")
+	for i, k := range o.keys {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(o.values[i])
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteString(":")
+		buf.Write(value)
+	}
+	buf.WriteString("}")
+
+	return buf.Bytes(), nil
+}
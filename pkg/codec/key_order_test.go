@@ -0,0 +1,178 @@
+package codec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeKeyOrder(t *testing.T) {
+	type test struct {
+		name     string
+		format   Format
+		document string
+		expected KeyOrder
+	}
+
+	tests := []test{
+		{
+			name:     "json",
+			format:   JSON_FORMAT,
+			document: `{"name": "api", "server": {"port": 80, "host": "localhost"}, "a/b": 1}`,
+			expected: KeyOrder{"/name", "/server", "/server/port", "/server/host", "/a~1b"},
+		},
+		{
+			name:   "yaml",
+			format: YAML_FORMAT,
+			document: `
+name: api
+server:
+  port: 80
+  host: localhost
+services:
+  - url: http://a
+    retries: 1
+  - timeout: 2
+    url: http://b
+`,
+			expected: KeyOrder{
+				"/name",
+				"/server",
+				"/server/port",
+				"/server/host",
+				"/services",
+				"/services/url",
+				"/services/retries",
+				"/services/timeout",
+			},
+		},
+		{
+			name:   "yaml merge keys",
+			format: YAML_FORMAT,
+			document: `
+defaults: &defaults
+  timeout: 1
+  retries: 2
+server:
+  port: 80
+  <<: *defaults
+`,
+			expected: KeyOrder{
+				"/defaults",
+				"/defaults/timeout",
+				"/defaults/retries",
+				"/server",
+				"/server/port",
+				"/server/timeout",
+				"/server/retries",
+			},
+		},
+		{
+			name:   "toml",
+			format: TOML_FORMAT,
+			document: `
+name = "api"
+limits = { rate = 10, burst = 20 }
+
+[server]
+port = 80
+host = "localhost"
+
+[[services]]
+url = "http://a"
+retries = 1
+`,
+			expected: KeyOrder{
+				"/name",
+				"/limits",
+				"/limits/rate",
+				"/limits/burst",
+				"/server",
+				"/server/port",
+				"/server/host",
+				"/services",
+				"/services/url",
+				"/services/retries",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			order, err := DecodeKeyOrder(test.format, []byte(test.document))
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.expected, order)
+			}
+		})
+	}
+}
+
+func TestKeyOrderSub(t *testing.T) {
+	order := KeyOrder{"/name", "/config", "/config/port", "/config/server", "/config/server/host", "/configs/port"}
+
+	assert.Equal(t, KeyOrder{"/port", "/server", "/server/host"}, order.Sub("config"))
+	assert.Nil(t, order.Sub("name"))
+	assert.Nil(t, order.Sub("schema"))
+}
+
+func TestOrdered(t *testing.T) {
+	value := map[string]interface{}{
+		"name": "api",
+		"server": map[string]interface{}{
+			"port": 80,
+			"host": "localhost",
+		},
+		"services": []interface{}{
+			map[string]interface{}{"url": "http://a", "retries": 1},
+		},
+		"debug": true,
+	}
+
+	t.Run("listed keys first", func(t *testing.T) {
+		data, err := json.Marshal(Ordered{
+			Value: value,
+			Order: KeyOrder{"/server", "/server/port", "/server/host", "/services", "/services/url", "/name"},
+		})
+
+		if assert.NoError(t, err) {
+			assert.Equal(
+				t,
+				`{"server":{"port":80,"host":"localhost"},"services":[{"url":"http://a","retries":1}],"name":"api","debug":true}`,
+				string(data),
+			)
+		}
+	})
+
+	t.Run("sorted without order", func(t *testing.T) {
+		data, err := json.Marshal(Ordered{Value: value})
+
+		if assert.NoError(t, err) {
+			assert.Equal(
+				t,
+				`{"debug":true,"name":"api","server":{"host":"localhost","port":80},"services":[{"retries":1,"url":"http://a"}]}`,
+				string(data),
+			)
+		}
+	})
+
+	t.Run("yaml keeps the order of documents", func(t *testing.T) {
+		document := "name: api\nserver:\n  port: 80\n  host: localhost\ndebug: true\n"
+
+		v, err := Decode(YAML_FORMAT, []byte(document))
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		order, err := DecodeKeyOrder(YAML_FORMAT, []byte(document))
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		data, err := Encode(YAML_FORMAT, Ordered{Value: v, Order: order})
+		if assert.NoError(t, err) {
+			assert.Equal(t, document, string(data))
+		}
+	})
+}
@@ -0,0 +1,275 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// orderedObject is a JSON object keeping the order of its keys as encoded,
+// so YAML and TOML render them in the same order as JSON.
+type orderedObject struct {
+	keys   []string
+	values []interface{}
+}
+
+// decodeOrdered parses JSON into ordered objects, []interface{},
+// json.Number, string, bool and nil values.
+func decodeOrdered(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	return decodeOrderedValue(dec)
+}
+
+func decodeOrderedValue(dec *json.Decoder) (interface{}, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		obj := orderedObject{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := decodeOrderedValue(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			obj.keys = append(obj.keys, key.(string))
+			obj.values = append(obj.values, value)
+		}
+
+		// Closing delimiter
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+
+		return obj, nil
+	case json.Delim('['):
+		arr := make([]interface{}, 0)
+		for dec.More() {
+			value, err := decodeOrderedValue(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			arr = append(arr, value)
+		}
+
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+
+		return arr, nil
+	}
+
+	return token, nil
+}
+
+func encodeYaml(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(yamlNode(v)); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func yamlNode(v interface{}) *yaml.Node {
+	switch v := v.(type) {
+	case orderedObject:
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		if len(v.keys) == 0 {
+			node.Style = yaml.FlowStyle
+		}
+
+		for i, k := range v.keys {
+			node.Content = append(
+				node.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: k},
+				yamlNode(v.values[i]),
+			)
+		}
+
+		return node
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if len(v) == 0 {
+			node.Style = yaml.FlowStyle
+		}
+
+		for _, e := range v {
+			node.Content = append(node.Content, yamlNode(e))
+		}
+
+		return node
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(v.String(), ".eE") {
+			tag = "!!float"
+		}
+
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: v.String()}
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(v)}
+	}
+
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+}
+
+var bareTomlKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// encodeToml renders an object as a TOML document. TOML has no null, so null
+// values are left out.
+func encodeToml(v interface{}) ([]byte, error) {
+	obj, ok := v.(orderedObject)
+	if !ok {
+		return nil, fmt.Errorf("only objects can be rendered as TOML")
+	}
+
+	var buf bytes.Buffer
+	if err := writeTomlTable(&buf, nil, obj); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// writeTomlTable writes key/value pairs before sub-tables, as TOML requires.
+func writeTomlTable(buf *bytes.Buffer, path []string, obj orderedObject) error {
+	hasValues := false
+	for _, v := range obj.values {
+		if _, isTable := v.(orderedObject); !isTable && v != nil {
+			hasValues = true
+			break
+		}
+	}
+
+	// Tables only holding sub-tables are implicitly defined by them
+	if len(path) > 0 && (hasValues || len(obj.keys) == 0) {
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+
+		keys := make([]string, 0, len(path))
+		for _, k := range path {
+			keys = append(keys, tomlKey(k))
+		}
+
+		buf.WriteString("[" + strings.Join(keys, ".") + "]\n")
+	}
+
+	for i, k := range obj.keys {
+		v := obj.values[i]
+		if _, isTable := v.(orderedObject); isTable || v == nil {
+			continue
+		}
+
+		value, err := tomlValue(v)
+		if err != nil {
+			return err
+		}
+
+		buf.WriteString(tomlKey(k) + " = " + value + "\n")
+	}
+
+	for i, k := range obj.keys {
+		if table, isTable := obj.values[i].(orderedObject); isTable {
+			subPath := append(append([]string{}, path...), k)
+			if err := writeTomlTable(buf, subPath, table); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func tomlKey(k string) string {
+	if bareTomlKey.MatchString(k) {
+		return k
+	}
+
+	return tomlString(k)
+}
+
+func tomlValue(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case orderedObject:
+		pairs := make([]string, 0, len(v.keys))
+		for i, k := range v.keys {
+			if v.values[i] == nil {
+				continue
+			}
+
+			value, err := tomlValue(v.values[i])
+			if err != nil {
+				return "", err
+			}
+
+			pairs = append(pairs, tomlKey(k)+" = "+value)
+		}
+
+		if len(pairs) == 0 {
+			return "{}", nil
+		}
+
+		return "{ " + strings.Join(pairs, ", ") + " }", nil
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, e := range v {
+			if e == nil {
+				return "", fmt.Errorf("arrays with null values cannot be rendered as TOML")
+			}
+
+			value, err := tomlValue(e)
+			if err != nil {
+				return "", err
+			}
+
+			values = append(values, value)
+		}
+
+		return "[" + strings.Join(values, ", ") + "]", nil
+	case json.Number:
+		return v.String(), nil
+	case string:
+		return tomlString(v), nil
+	case bool:
+		return fmt.Sprint(v), nil
+	}
+
+	return "", fmt.Errorf("unsupported value %v", v)
+}
+
+// tomlString quotes a basic string. JSON escapes are valid TOML escapes.
+func tomlString(s string) string {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
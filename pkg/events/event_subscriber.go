@@ -2,6 +2,8 @@ package events
 
 type SubscriptionFunc func(evt Event) error
 
+type UnsubscribeFunc func()
+
 type EventSubscriber interface {
-	Subscribe(fn SubscriptionFunc, topics ...Topic)
+	Subscribe(fn SubscriptionFunc, topics ...Topic) UnsubscribeFunc
 }
@@ -1,17 +1,23 @@
 package models
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/aboglioli/configd/pkg/events"
 )
 
+// ErrVersionConflict is returned when saving an aggregate that was modified
+// concurrently since it was read.
+var ErrVersionConflict = errors.New("version conflict")
+
 type ReadOnlyAggregateRoot interface {
 	Id() Id
 	CreatedAt() time.Time
 	UpdatedAt() time.Time
 	DeletedAt() *time.Time
+	IsDeleted() bool
 	Events() []events.Event
 	Version() uint
 }
@@ -90,15 +96,44 @@ func (a *AggregateRoot) DeletedAt() *time.Time {
 	return a.deletedAt
 }
 
-func (a *AggregateRoot) Delete() {
-	now := time.Now()
-	a.deletedAt = &now
+func (a *AggregateRoot) IsDeleted() bool {
+	return a.deletedAt != nil
+}
+
+// Delete marks the aggregate as deleted. It is a new version so it can be
+// saved like any other change.
+func (a *AggregateRoot) Delete() error {
+	if a.deletedAt != nil {
+		return errors.New("already deleted")
+	}
+
+	a.Update()
+
+	deletedAt := a.updatedAt
+	a.deletedAt = &deletedAt
+
+	return nil
+}
+
+func (a *AggregateRoot) Restore() error {
+	if a.deletedAt == nil {
+		return errors.New("not deleted")
+	}
+
+	a.Update()
+	a.deletedAt = nil
+
+	return nil
 }
 
 func (a *AggregateRoot) Events() []events.Event {
 	return a.events
 }
 
+func (a *AggregateRoot) ClearEvents() {
+	a.events = make([]events.Event, 0)
+}
+
 func (a *AggregateRoot) Version() uint {
 	return a.version
 }
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteAndRestoreAggregateRoot(t *testing.T) {
+	id, err := BuildId("aggregate")
+	assert.NoError(t, err)
+
+	agg, err := NewAggregateRoot(id)
+	assert.NoError(t, err)
+
+	assert.Error(t, agg.Restore())
+
+	if assert.NoError(t, agg.Delete()) {
+		assert.True(t, agg.IsDeleted())
+		assert.Equal(t, agg.UpdatedAt(), *agg.DeletedAt())
+		assert.Equal(t, uint(2), agg.Version())
+	}
+
+	assert.Error(t, agg.Delete())
+
+	// Next instance, as loaded from a repository
+	agg, err = BuildAggregateRoot(id, agg.CreatedAt(), agg.UpdatedAt(), agg.DeletedAt(), agg.Version())
+	assert.NoError(t, err)
+
+	if assert.NoError(t, agg.Restore()) {
+		assert.False(t, agg.IsDeleted())
+		assert.Nil(t, agg.DeletedAt())
+		assert.Equal(t, uint(3), agg.Version())
+	}
+}
@@ -0,0 +1,176 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	DEFAULT_PAGE_LIMIT = 20
+	MAX_PAGE_LIMIT     = 100
+)
+
+type SortField string
+
+const (
+	SORT_BY_NAME       SortField = "name"
+	SORT_BY_CREATED_AT SortField = "created_at"
+	SORT_BY_UPDATED_AT SortField = "updated_at"
+)
+
+type Sort struct {
+	Field SortField
+	Desc  bool
+}
+
+// NewSort parses a sort field and an order (asc or desc). Results are sorted
+// by name in ascending order by default.
+func NewSort(field, order string) (Sort, error) {
+	sort := Sort{Field: SORT_BY_NAME}
+
+	switch f := SortField(field); f {
+	case "":
+	case SORT_BY_NAME, SORT_BY_CREATED_AT, SORT_BY_UPDATED_AT:
+		sort.Field = f
+	default:
+		return Sort{}, fmt.Errorf("invalid sort field %s", field)
+	}
+
+	switch order {
+	case "", "asc":
+	case "desc":
+		sort.Desc = true
+	default:
+		return Sort{}, fmt.Errorf("invalid sort order %s", order)
+	}
+
+	return sort, nil
+}
+
+// Cursor points to the last item of a page. Items are always ordered by the
+// sort field and then by id, so the pair identifies a position.
+type Cursor struct {
+	Name string     `json:"n,omitempty"`
+	Time *time.Time `json:"t,omitempty"`
+	Id   string     `json:"id"`
+}
+
+// NewCursor builds the cursor located at the given aggregate.
+func NewCursor(sort Sort, agg ReadOnlyAggregateRoot, name string) *Cursor {
+	cursor := &Cursor{Id: agg.Id().Value()}
+
+	switch sort.Field {
+	case SORT_BY_NAME:
+		cursor.Name = name
+	case SORT_BY_CREATED_AT:
+		t := agg.CreatedAt()
+		cursor.Time = &t
+	case SORT_BY_UPDATED_AT:
+		t := agg.UpdatedAt()
+		cursor.Time = &t
+	}
+
+	return cursor
+}
+
+func DecodeCursor(raw string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(b, &cursor); err != nil || cursor.Id == "" {
+		return nil, errors.New("invalid cursor")
+	}
+
+	return &cursor, nil
+}
+
+func (c *Cursor) Encode() string {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// After reports whether an item with the given sort value and id comes after
+// the cursor.
+func (c *Cursor) After(sort Sort, name string, t time.Time, id string) bool {
+	cmp := 0
+	switch sort.Field {
+	case SORT_BY_NAME:
+		cmp = compareStrings(name, c.Name)
+	default:
+		switch {
+		case t.Before(*c.Time):
+			cmp = -1
+		case t.After(*c.Time):
+			cmp = 1
+		}
+	}
+
+	if cmp == 0 {
+		cmp = compareStrings(id, c.Id)
+	}
+
+	if sort.Desc {
+		return cmp < 0
+	}
+
+	return cmp > 0
+}
+
+func compareStrings(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+type Pagination struct {
+	Sort   Sort
+	Cursor *Cursor
+	Limit  int
+}
+
+// NewPagination validates page parameters. An empty cursor means the first
+// page and a zero limit means DEFAULT_PAGE_LIMIT.
+func NewPagination(sort Sort, rawCursor string, limit int) (Pagination, error) {
+	if limit == 0 {
+		limit = DEFAULT_PAGE_LIMIT
+	}
+
+	if limit < 0 || limit > MAX_PAGE_LIMIT {
+		return Pagination{}, fmt.Errorf("limit must be between 1 and %d", MAX_PAGE_LIMIT)
+	}
+
+	p := Pagination{
+		Sort:  sort,
+		Limit: limit,
+	}
+
+	if rawCursor != "" {
+		cursor, err := DecodeCursor(rawCursor)
+		if err != nil {
+			return Pagination{}, err
+		}
+
+		if (sort.Field == SORT_BY_NAME) != (cursor.Time == nil) {
+			return Pagination{}, errors.New("cursor does not match sort field")
+		}
+
+		p.Cursor = cursor
+	}
+
+	return p, nil
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPagination(t *testing.T) {
+	id, err := BuildId("item-1")
+	assert.NoError(t, err)
+
+	createdAt := time.Date(2022, 1, 10, 12, 30, 0, 0, time.UTC)
+	agg, err := BuildAggregateRoot(id, createdAt, createdAt, nil, 1)
+	assert.NoError(t, err)
+
+	byName := Sort{Field: SORT_BY_NAME}
+	byCreation := Sort{Field: SORT_BY_CREATED_AT, Desc: true}
+
+	type test struct {
+		name     string
+		sort     Sort
+		cursor   string
+		limit    int
+		expected Pagination
+		err      bool
+	}
+
+	tests := []test{
+		{
+			name:     "default limit",
+			sort:     byName,
+			expected: Pagination{Sort: byName, Limit: DEFAULT_PAGE_LIMIT},
+		},
+		{
+			name:  "limit too big",
+			sort:  byName,
+			limit: MAX_PAGE_LIMIT + 1,
+			err:   true,
+		},
+		{
+			name:   "invalid cursor",
+			sort:   byName,
+			cursor: "not a cursor",
+			err:    true,
+		},
+		{
+			name:   "cursor for another sort",
+			sort:   byName,
+			cursor: NewCursor(byCreation, agg, "Item").Encode(),
+			err:    true,
+		},
+		{
+			name:   "valid cursor",
+			sort:   byCreation,
+			cursor: NewCursor(byCreation, agg, "Item").Encode(),
+			limit:  5,
+			expected: Pagination{
+				Sort:   byCreation,
+				Cursor: &Cursor{Time: &createdAt, Id: "item-1"},
+				Limit:  5,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := NewPagination(test.sort, test.cursor, test.limit)
+
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.expected.Sort, p.Sort)
+				assert.Equal(t, test.expected.Limit, p.Limit)
+				if test.expected.Cursor != nil {
+					assert.Equal(t, test.expected.Cursor.Id, p.Cursor.Id)
+					assert.True(t, test.expected.Cursor.Time.Equal(*p.Cursor.Time))
+				} else {
+					assert.Nil(t, p.Cursor)
+				}
+			}
+		})
+	}
+}
+
+func TestNewSort(t *testing.T) {
+	s, err := NewSort("", "")
+	assert.NoError(t, err)
+	assert.Equal(t, Sort{Field: SORT_BY_NAME}, s)
+
+	s, err = NewSort("updated_at", "desc")
+	assert.NoError(t, err)
+	assert.Equal(t, Sort{Field: SORT_BY_UPDATED_AT, Desc: true}, s)
+
+	_, err = NewSort("version", "")
+	assert.Error(t, err)
+
+	_, err = NewSort("name", "up")
+	assert.Error(t, err)
+}
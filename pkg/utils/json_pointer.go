@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// JoinPointer appends a key or array index to a JSON pointer.
+func JoinPointer(path string, key interface{}) string {
+	switch k := key.(type) {
+	case int:
+		return path + "/" + strconv.Itoa(k)
+	case string:
+		return path + "/" + pointerEscaper.Replace(k)
+	}
+
+	return path + "/" + pointerEscaper.Replace(fmt.Sprint(key))
+}
+
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// SplitPointer returns the unescaped keys of a JSON pointer. The empty
+// pointer refers to the whole document and has no keys.
+func SplitPointer(path string) ([]string, error) {
+	if path == "" {
+		return []string{}, nil
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %s", path)
+	}
+
+	keys := strings.Split(path[1:], "/")
+	for i, k := range keys {
+		keys[i] = pointerUnescaper.Replace(k)
+	}
+
+	return keys, nil
+}